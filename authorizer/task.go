@@ -100,6 +100,9 @@ func (ts *taskServiceValidator) CreateTask(ctx context.Context, t influxdb.TaskC
 	if err := ts.processPermissionError(a, p, err, loggerFields...); err != nil {
 		return nil, err
 	}
+	if err := ts.authorizeUpstream(ctx, t.Upstream); err != nil {
+		return nil, err
+	}
 	return ts.TaskService.CreateTask(ctx, t)
 }
 
@@ -118,9 +121,29 @@ func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id influxdb.ID,
 	if err := ts.processPermissionError(a, p, err, loggerFields...); err != nil {
 		return nil, err
 	}
+	if upd.Upstream != nil {
+		if err := ts.authorizeUpstream(ctx, *upd.Upstream); err != nil {
+			return nil, err
+		}
+	}
 	return ts.TaskService.UpdateTask(ctx, id, upd)
 }
 
+// authorizeUpstream checks that the upstream tasks of a task can be read.
+// Missing tasks are left for the task service to reject.
+func (ts *taskServiceValidator) authorizeUpstream(ctx context.Context, upstream []influxdb.ID) error {
+	for _, id := range upstream {
+		_, err := ts.FindTaskByID(ctx, id)
+		if err == influxdb.ErrTaskNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ts *taskServiceValidator) DeleteTask(ctx context.Context, id influxdb.ID) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
@@ -0,0 +1,96 @@
+package authorizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.BackfillService = (*BackfillService)(nil)
+
+// BackfillService wraps a influxdb.BackfillService and authorizes actions
+// against it appropriately, based on the task of the backfill.
+type BackfillService struct {
+	s  influxdb.BackfillService
+	ts *taskServiceValidator
+}
+
+// NewBackfillService constructs an instance of an authorizing backfill service.
+// The tasks of the backfills are looked up with ts, without authorization.
+func NewBackfillService(log *zap.Logger, s influxdb.BackfillService, ts influxdb.TaskService) *BackfillService {
+	return &BackfillService{
+		s:  s,
+		ts: &taskServiceValidator{TaskService: ts, log: log},
+	}
+}
+
+// authorize looks up the task and checks that the action is allowed on it.
+func (s *BackfillService) authorize(ctx context.Context, action influxdb.Action, taskID influxdb.ID, method string) (*influxdb.Task, error) {
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := s.ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizeFn := AuthorizeRead
+	if action == influxdb.WriteAction {
+		authorizeFn = AuthorizeWrite
+	}
+	a, p, err := authorizeFn(ctx, influxdb.TasksResourceType, task.ID, task.OrganizationID)
+	loggerFields := []zap.Field{zap.String("method", method), zap.Stringer("task_id", taskID)}
+	if err := s.ts.processPermissionError(a, p, err, loggerFields...); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CreateBackfill checks to see if the authorizer on context has write access to the task.
+func (s *BackfillService) CreateBackfill(ctx context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	task, err := s.authorize(ctx, influxdb.WriteAction, taskID, "CreateBackfill")
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != string(influxdb.TaskActive) {
+		return nil, ErrInactiveTask
+	}
+	return s.s.CreateBackfill(ctx, taskID, start, end)
+}
+
+// FindBackfills checks to see if the authorizer on context has read access to the task.
+func (s *BackfillService) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if _, err := s.authorize(ctx, influxdb.ReadAction, taskID, "FindBackfills"); err != nil {
+		return nil, err
+	}
+	return s.s.FindBackfills(ctx, taskID)
+}
+
+// FindBackfillByID checks to see if the authorizer on context has read access to the task.
+func (s *BackfillService) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if _, err := s.authorize(ctx, influxdb.ReadAction, taskID, "FindBackfillByID"); err != nil {
+		return nil, err
+	}
+	return s.s.FindBackfillByID(ctx, taskID, id)
+}
+
+// CancelBackfill checks to see if the authorizer on context has write access to the task.
+func (s *BackfillService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if _, err := s.authorize(ctx, influxdb.WriteAction, taskID, "CancelBackfill"); err != nil {
+		return err
+	}
+	return s.s.CancelBackfill(ctx, taskID, id)
+}
@@ -0,0 +1,143 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBackfillService(t *testing.T) {
+	const (
+		orgID  = influxdb.ID(10)
+		taskID = influxdb.ID(1)
+	)
+	var (
+		readTask = influxdb.Permission{
+			Action:   influxdb.ReadAction,
+			Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: influxdbtesting.IDPtr(orgID), ID: influxdbtesting.IDPtr(taskID)},
+		}
+		writeTask = influxdb.Permission{
+			Action:   influxdb.WriteAction,
+			Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: influxdbtesting.IDPtr(orgID), ID: influxdbtesting.IDPtr(taskID)},
+		}
+	)
+
+	newService := func(status influxdb.TaskStatus) influxdb.BackfillService {
+		ts := mock.NewTaskService()
+		ts.FindTaskByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Task, error) {
+			return &influxdb.Task{ID: id, OrganizationID: orgID, Status: string(status)}, nil
+		}
+		bs := &mock.BackfillService{
+			CreateBackfillFn: func(_ context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error) {
+				return &influxdb.Backfill{TaskID: taskID}, nil
+			},
+			FindBackfillsFn: func(_ context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+				return []*influxdb.Backfill{{TaskID: taskID}}, nil
+			},
+			FindBackfillByIDFn: func(_ context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+				return &influxdb.Backfill{ID: id, TaskID: taskID}, nil
+			},
+			CancelBackfillFn: func(context.Context, influxdb.ID, influxdb.ID) error {
+				return nil
+			},
+		}
+		return authorizer.NewBackfillService(zaptest.NewLogger(t), bs, ts)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		status  influxdb.TaskStatus
+		perm    influxdb.Permission
+		call    func(context.Context, influxdb.BackfillService) error
+		wantErr bool
+	}{
+		{
+			name: "create with write permission",
+			perm: writeTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				_, err := s.CreateBackfill(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+				return err
+			},
+		},
+		{
+			name: "create with read permission",
+			perm: readTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				_, err := s.CreateBackfill(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "create for inactive task",
+			status: influxdb.TaskInactive,
+			perm:   writeTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				_, err := s.CreateBackfill(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "find with read permission",
+			perm: readTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				if _, err := s.FindBackfills(ctx, taskID); err != nil {
+					return err
+				}
+				_, err := s.FindBackfillByID(ctx, taskID, 2)
+				return err
+			},
+		},
+		{
+			name: "find with permission on another task",
+			perm: influxdb.Permission{
+				Action:   influxdb.ReadAction,
+				Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: influxdbtesting.IDPtr(orgID), ID: influxdbtesting.IDPtr(3)},
+			},
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				_, err := s.FindBackfills(ctx, taskID)
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "cancel with write permission",
+			perm: writeTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				return s.CancelBackfill(ctx, taskID, 2)
+			},
+		},
+		{
+			name: "cancel with read permission",
+			perm: readTask,
+			call: func(ctx context.Context, s influxdb.BackfillService) error {
+				return s.CancelBackfill(ctx, taskID, 2)
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = influxdb.TaskActive
+			}
+			s := newService(status)
+
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, []influxdb.Permission{tt.perm}))
+			err := tt.call(ctx, s)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error")
+			} else if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"github.com/spf13/cobra"
+)
+
+// checkService is the subset of the check HTTP client used by the check
+// commands. The client speaks in *http.Check since checks are polymorphic.
+type checkService interface {
+	FindCheckByID(ctx context.Context, id influxdb.ID) (*http.Check, error)
+	FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*http.Check, int, error)
+	CreateCheck(ctx context.Context, c *http.Check) (*http.Check, error)
+	PatchCheck(ctx context.Context, id influxdb.ID, u influxdb.CheckUpdate) (*http.Check, error)
+	DeleteCheck(ctx context.Context, id influxdb.ID) error
+}
+
+type checkSVCsFn func() (checkService, influxdb.OrganizationService, error)
+
+func cmdCheck(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdCheckBuilder(newCheckSVCs, f, opt)
+	return builder.cmd()
+}
+
+type cmdCheckBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn checkSVCsFn
+
+	id          string
+	hideHeaders bool
+	json        bool
+	file        string
+	name        string
+	description string
+	status      string
+	org         organization
+}
+
+func newCmdCheckBuilder(svcsFn checkSVCsFn, f *globalFlags, opts genericCLIOpts) *cmdCheckBuilder {
+	return &cmdCheckBuilder{
+		globalFlags:    f,
+		genericCLIOpts: opts,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdCheckBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("check", nil)
+	cmd.Short = "Check management commands"
+	cmd.TraverseChildren = true
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdList(),
+		b.cmdUpdate(),
+	)
+
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Create check"
+	cmd.Long = `
+	Create a check from its JSON definition, as accepted by the /api/v2/checks API.
+
+	Examples:
+		# create a check from a file
+		influx check create --org $ORG_NAME --file $PATH_TO_CHECK_JSON
+
+		# create a check with a definition provided via STDIN
+		cat $PATH_TO_CHECK_JSON | influx check create --org $ORG_NAME
+`
+
+	cmd.Flags().StringVarP(&b.file, "file", "f", "", "Path to the JSON check definition")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
+	checkSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	bb, err := readDefinition(b.in, b.file, "check")
+	if err != nil {
+		return err
+	}
+
+	var c http.Check
+	if err := json.Unmarshal(bb, &c); err != nil {
+		return fmt.Errorf("failed to decode check definition: %v", err)
+	}
+
+	// an org provided via flags takes precedence over the one in the definition
+	if !c.OrgID.Valid() || b.org.id != "" || b.org.name != "" {
+		if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+			return err
+		}
+		if c.OrgID, err = b.org.getID(orgSVC); err != nil {
+			return err
+		}
+	}
+
+	created, err := checkSVC.CreateCheck(context.Background(), &c)
+	if err != nil {
+		return fmt.Errorf("failed to create check: %v", err)
+	}
+
+	return b.printChecks(checkPrintOpt{check: created})
+}
+
+func (b *cmdCheckBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete check"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The check ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	checkSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode check id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	c, err := checkSVC.FindCheckByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find check with id %q: %v", id, err)
+	}
+	if err := checkSVC.DeleteCheck(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete check with id %q: %v", id, err)
+	}
+
+	return b.printChecks(checkPrintOpt{
+		deleted: true,
+		check:   c,
+	})
+}
+
+func (b *cmdCheckBuilder) cmdList() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdListRunEFn)
+	cmd.Short = "List checks"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "The check name")
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The check ID")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdListRunEFn(cmd *cobra.Command, args []string) error {
+	checkSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode check id %q: %v", b.id, err)
+		}
+		c, err := checkSVC.FindCheckByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve check: %v", err)
+		}
+		return b.printChecks(checkPrintOpt{checks: []*http.Check{c}})
+	}
+
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	filter := influxdb.CheckFilter{OrgID: &orgID}
+	if b.name != "" {
+		filter.Name = &b.name
+	}
+
+	checks, _, err := checkSVC.FindChecks(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve checks: %v", err)
+	}
+
+	return b.printChecks(checkPrintOpt{checks: checks})
+}
+
+func (b *cmdCheckBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn)
+	cmd.Short = "Update check"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The check ID (required)")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "New check name")
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "New check description")
+	cmd.Flags().StringVarP(&b.status, "status", "s", "", "New check status, active or inactive")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	checkSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode check id %q: %v", b.id, err)
+	}
+
+	var update influxdb.CheckUpdate
+	if b.name != "" {
+		update.Name = &b.name
+	}
+	if b.description != "" {
+		update.Description = &b.description
+	}
+	if b.status != "" {
+		status, err := parseStatus(b.status)
+		if err != nil {
+			return err
+		}
+		update.Status = &status
+	}
+
+	c, err := checkSVC.PatchCheck(context.Background(), id, update)
+	if err != nil {
+		return fmt.Errorf("failed to update check: %v", err)
+	}
+
+	return b.printChecks(checkPrintOpt{check: c})
+}
+
+func (b *cmdCheckBuilder) newCmd(use string, runE func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := b.genericCLIOpts.newCmd(use, runE, true)
+	b.globalFlags.registerFlags(b.viper, cmd)
+	return cmd
+}
+
+func (b *cmdCheckBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(b.viper, cmd, &b.hideHeaders, &b.json)
+}
+
+type checkPrintOpt struct {
+	deleted bool
+	check   *http.Check
+	checks  []*http.Check
+}
+
+func (b *cmdCheckBuilder) printChecks(printOpt checkPrintOpt) error {
+	if b.json {
+		var v interface{} = printOpt.checks
+		if printOpt.checks == nil {
+			v = printOpt.check
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Name", "Type", "Status", "Every", "Organization ID"}
+	if printOpt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if printOpt.check != nil {
+		printOpt.checks = append(printOpt.checks, printOpt.check)
+	}
+
+	for _, c := range printOpt.checks {
+		m := map[string]interface{}{
+			"ID":              c.ID.String(),
+			"Name":            c.Name,
+			"Type":            c.Type,
+			"Status":          c.Status,
+			"Every":           c.Every,
+			"Organization ID": c.OrgID.String(),
+		}
+		if printOpt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+// parseStatus converts the status flag shared by the check and notification
+// commands into an influxdb.Status.
+func parseStatus(s string) (influxdb.Status, error) {
+	status := influxdb.Status(s)
+	if err := status.Valid(); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// readDefinition reads a JSON resource definition from file, falling back
+// to STDIN when no file is provided.
+func readDefinition(in io.Reader, file, kind string) ([]byte, error) {
+	if file != "" {
+		return ioutil.ReadFile(file)
+	}
+
+	stdIn, err := inStdIn(in)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Err:  fmt.Errorf("a %s definition must be provided", kind),
+		}
+	}
+	defer stdIn.Close()
+
+	return ioutil.ReadAll(stdIn)
+}
+
+func newCheckSVCs() (checkService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgSvc := &tenant.OrgClientService{Client: httpClient}
+
+	return &http.CheckService{Client: httpClient}, orgSvc, nil
+}
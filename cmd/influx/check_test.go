@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCheckService struct {
+	FindCheckByIDFn func(ctx context.Context, id influxdb.ID) (*http.Check, error)
+	FindChecksFn    func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*http.Check, int, error)
+	CreateCheckFn   func(ctx context.Context, c *http.Check) (*http.Check, error)
+	PatchCheckFn    func(ctx context.Context, id influxdb.ID, u influxdb.CheckUpdate) (*http.Check, error)
+	DeleteCheckFn   func(ctx context.Context, id influxdb.ID) error
+}
+
+func (s *fakeCheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (*http.Check, error) {
+	return s.FindCheckByIDFn(ctx, id)
+}
+
+func (s *fakeCheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*http.Check, int, error) {
+	return s.FindChecksFn(ctx, filter, opt...)
+}
+
+func (s *fakeCheckService) CreateCheck(ctx context.Context, c *http.Check) (*http.Check, error) {
+	return s.CreateCheckFn(ctx, c)
+}
+
+func (s *fakeCheckService) PatchCheck(ctx context.Context, id influxdb.ID, u influxdb.CheckUpdate) (*http.Check, error) {
+	return s.PatchCheckFn(ctx, id, u)
+}
+
+func (s *fakeCheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteCheckFn(ctx, id)
+}
+
+func TestCmdCheck(t *testing.T) {
+	orgID := influxdb.ID(9000)
+
+	fakeSVCFn := func(svc checkService) checkSVCsFn {
+		return func() (checkService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		defer removeTempFiles()
+
+		definition := func(orgID influxdb.ID) string {
+			org := ""
+			if orgID.Valid() {
+				org = `"orgID": "` + orgID.String() + `", `
+			}
+			return `{"name": "c1", ` + org + `"type": "deadman", "every": "1m", "status": "active"}`
+		}
+
+		tests := []struct {
+			name          string
+			flags         []string
+			stdIn         string
+			expectedOrgID influxdb.ID
+		}{
+			{
+				name:          "from file",
+				flags:         []string{"--org=org name", "--file=" + createTempFile("json", []byte(definition(0)))},
+				expectedOrgID: orgID,
+			},
+			{
+				name:          "from STDIN",
+				flags:         []string{"--org=org name"},
+				stdIn:         definition(0),
+				expectedOrgID: orgID,
+			},
+			{
+				name:          "org of definition",
+				flags:         []string{"-f=" + createTempFile("json", []byte(definition(3)))},
+				expectedOrgID: 3,
+			},
+			{
+				name:          "org flag overrides definition",
+				flags:         []string{"--org-id=" + influxdb.ID(4).String(), "-f=" + createTempFile("json", []byte(definition(3)))},
+				expectedOrgID: 4,
+			},
+		}
+
+		cmdFn := func(expectedOrgID influxdb.ID) func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := &fakeCheckService{
+				CreateCheckFn: func(ctx context.Context, c *http.Check) (*http.Check, error) {
+					if c.Name != "c1" || c.Type != "deadman" || c.Every != "1m" {
+						return nil, fmt.Errorf("unexpected check: %+v", *c)
+					}
+					if c.OrgID != expectedOrgID {
+						return nil, fmt.Errorf("unexpected org id:\n\twant= %s\n\tgot=  %s", expectedOrgID, c.OrgID)
+					}
+					c.ID = 1
+					return c, nil
+				},
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdCheckBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				var stdIn io.Reader = new(bytes.Buffer)
+				if tt.stdIn != "" {
+					defer withStdIn(t, tt.stdIn)()
+					stdIn = os.Stdin
+				}
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(stdIn),
+					out(outBuf),
+				)
+				cmd := builder.cmd(cmdFn(tt.expectedOrgID))
+				cmd.SetArgs(append([]string{"check", "create", "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, [][]string{
+					{influxdb.ID(1).String(), "c1", "deadman", "active", "1m", tt.expectedOrgID.String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("create without definition", func(t *testing.T) {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdCheckBuilder(fakeSVCFn(&fakeCheckService{}), g, opt).cmd()
+		})
+		cmd.SetArgs([]string{"check", "create", "--org-id=" + orgID.String()})
+
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		var deleted influxdb.ID
+		svc := &fakeCheckService{
+			FindCheckByIDFn: func(ctx context.Context, id influxdb.ID) (*http.Check, error) {
+				return &http.Check{ID: id, Name: "c1", Type: "threshold", Status: influxdb.Active, Every: "5m", OrgID: orgID}, nil
+			},
+			DeleteCheckFn: func(ctx context.Context, id influxdb.ID) error {
+				deleted = id
+				return nil
+			},
+		}
+
+		outBuf := new(bytes.Buffer)
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(outBuf),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdCheckBuilder(fakeSVCFn(svc), g, opt).cmd()
+		})
+		cmd.SetArgs([]string{"check", "delete", "--id=" + influxdb.ID(1).String()})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, influxdb.ID(1), deleted)
+		assert.Equal(t, [][]string{
+			{"ID", "Name", "Type", "Status", "Every", "Organization", "ID", "Deleted"},
+			{influxdb.ID(1).String(), "c1", "threshold", "active", "5m", orgID.String(), "true"},
+		}, outputFields(outBuf))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		type called struct {
+			id    influxdb.ID
+			orgID influxdb.ID
+			name  string
+		}
+
+		tests := []struct {
+			name     string
+			expected called
+			flags    []string
+			command  string
+		}{
+			{
+				name:     "org id",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+			{
+				name:     "org and name",
+				flags:    []string{"--org=rg", "--name=c1"},
+				expected: called{orgID: orgID, name: "c1"},
+			},
+			{
+				name:     "id",
+				flags:    []string{"--id=" + influxdb.ID(2).String()},
+				expected: called{id: 2},
+			},
+			{
+				name:     "ls alias",
+				command:  "ls",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+		}
+
+		cmdFn := func() (func(*globalFlags, genericCLIOpts) *cobra.Command, *called) {
+			calls := new(called)
+
+			check := &http.Check{ID: 2, Name: "c1", Type: "deadman", Status: influxdb.Inactive, Every: "1m", OrgID: 3}
+			svc := &fakeCheckService{
+				FindCheckByIDFn: func(ctx context.Context, id influxdb.ID) (*http.Check, error) {
+					calls.id = id
+					return check, nil
+				},
+				FindChecksFn: func(ctx context.Context, f influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*http.Check, int, error) {
+					if f.OrgID != nil {
+						calls.orgID = *f.OrgID
+					}
+					if f.Name != nil {
+						calls.name = *f.Name
+					}
+					return []*http.Check{check}, 1, nil
+				},
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdCheckBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}, calls
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(outBuf),
+				)
+				nestedCmdFn, calls := cmdFn()
+				cmd := builder.cmd(nestedCmdFn)
+
+				if tt.command == "" {
+					tt.command = "list"
+				}
+
+				cmd.SetArgs(append([]string{"check", tt.command, "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, *calls)
+				assert.Equal(t, [][]string{
+					{influxdb.ID(2).String(), "c1", "deadman", "inactive", "1m", influxdb.ID(3).String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		status := func(s influxdb.Status) *influxdb.Status { return &s }
+		name := "new name"
+
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.CheckUpdate
+			wantErr  bool
+		}{
+			{
+				name:     "name",
+				flags:    []string{"--name=" + name},
+				expected: influxdb.CheckUpdate{Name: &name},
+			},
+			{
+				name:     "active",
+				flags:    []string{"--status=active"},
+				expected: influxdb.CheckUpdate{Status: status(influxdb.Active)},
+			},
+			{
+				name:     "inactive short",
+				flags:    []string{"-s=inactive"},
+				expected: influxdb.CheckUpdate{Status: status(influxdb.Inactive)},
+			},
+			{
+				name:    "invalid status",
+				flags:   []string{"--status=paused"},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				var got *influxdb.CheckUpdate
+				svc := &fakeCheckService{
+					PatchCheckFn: func(ctx context.Context, id influxdb.ID, u influxdb.CheckUpdate) (*http.Check, error) {
+						if id != 1 {
+							return nil, fmt.Errorf("unexpected id:\n\twant= %s\n\tgot=  %s", influxdb.ID(1), id)
+						}
+						got = &u
+						return &http.Check{ID: id, OrgID: orgID}, nil
+					},
+				}
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+					return newCmdCheckBuilder(fakeSVCFn(svc), g, opt).cmd()
+				})
+				cmd.SetArgs(append([]string{"check", "update", "--id=" + influxdb.ID(1).String()}, tt.flags...))
+
+				if tt.wantErr {
+					require.Error(t, cmd.Execute())
+					assert.Nil(t, got)
+					return
+				}
+				require.NoError(t, cmd.Execute())
+				require.NotNil(t, got)
+				assert.Equal(t, tt.expected, *got)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+}
+
+// withStdIn replaces STDIN with a file holding contents until the returned
+// func is called, since definitions are only read from a piped STDIN.
+func withStdIn(t *testing.T, contents string) func() {
+	t.Helper()
+
+	f, err := os.Open(createTempFile("stdin", []byte(contents)))
+	require.NoError(t, err)
+
+	stdIn := os.Stdin
+	os.Stdin = f
+	return func() {
+		os.Stdin = stdIn
+		f.Close()
+	}
+}
+
+// outputFields splits each line of the table printed by a command into its
+// fields.
+func outputFields(buf *bytes.Buffer) [][]string {
+	var fields [][]string
+	for _, line := range readLines(buf) {
+		fields = append(fields, strings.Fields(line))
+	}
+	return fields
+}
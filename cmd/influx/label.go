@@ -0,0 +1,484 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"github.com/spf13/cobra"
+)
+
+type labelSVCsFn func() (influxdb.LabelService, influxdb.OrganizationService, error)
+
+func cmdLabel(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdLabelBuilder(newLabelSVCs, f, opt)
+	return builder.cmd()
+}
+
+type cmdLabelBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn labelSVCsFn
+
+	id           string
+	hideHeaders  bool
+	json         bool
+	name         string
+	color        string
+	description  string
+	org          organization
+	resourceID   string
+	resourceType string
+}
+
+func newCmdLabelBuilder(svcsFn labelSVCsFn, f *globalFlags, opts genericCLIOpts) *cmdLabelBuilder {
+	return &cmdLabelBuilder{
+		globalFlags:    f,
+		genericCLIOpts: opts,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdLabelBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("label", nil)
+	cmd.Short = "Label management commands"
+	cmd.TraverseChildren = true
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdAttach(),
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdDetach(),
+		b.cmdList(),
+		b.cmdUpdate(),
+	)
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Create label"
+
+	opts := flagOpts{
+		{
+			DestP:    &b.name,
+			Flag:     "name",
+			Short:    'n',
+			EnvVar:   "LABEL_NAME",
+			Desc:     "New label name",
+			Required: true,
+		},
+	}
+	opts.mustRegister(b.viper, cmd)
+
+	cmd.Flags().StringVar(&b.color, "color", "", "Hex color of the label, e.g. #326BBA")
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "Description of the label")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+
+	labelSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	l := &influxdb.Label{
+		Name:       b.name,
+		Properties: b.properties(),
+	}
+	l.OrgID, err = b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	if err := labelSVC.CreateLabel(context.Background(), l); err != nil {
+		return fmt.Errorf("failed to create label: %v", err)
+	}
+
+	return b.printLabels(labelPrintOpt{label: l})
+}
+
+func (b *cmdLabelBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete label"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The label ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	labelSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode label id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	l, err := labelSVC.FindLabelByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find label with id %q: %v", id, err)
+	}
+	if err := labelSVC.DeleteLabel(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete label with id %q: %v", id, err)
+	}
+
+	return b.printLabels(labelPrintOpt{
+		deleted: true,
+		label:   l,
+	})
+}
+
+func (b *cmdLabelBuilder) cmdList() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdListRunEFn)
+	cmd.Short = "List labels"
+	cmd.Long = `
+	List labels of an organization, or the labels attached to a single resource.
+
+	Examples:
+		# list all labels of an organization
+		influx label list --org $ORG_NAME
+
+		# list labels attached to a bucket
+		influx label list --resource-type buckets --resource-id $BUCKET_ID
+`
+	cmd.Aliases = []string{"find", "ls"}
+
+	opts := flagOpts{
+		{
+			DestP:  &b.name,
+			Flag:   "name",
+			Short:  'n',
+			EnvVar: "LABEL_NAME",
+			Desc:   "The label name",
+		},
+	}
+	opts.mustRegister(b.viper, cmd)
+
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The label ID")
+	b.registerResourceFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdListRunEFn(cmd *cobra.Command, args []string) error {
+	labelSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode label id %q: %v", b.id, err)
+		}
+		l, err := labelSVC.FindLabelByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve label: %v", err)
+		}
+		return b.printLabels(labelPrintOpt{labels: []*influxdb.Label{l}})
+	}
+
+	if b.resourceID != "" || b.resourceType != "" {
+		mapping, err := b.labelMapping(influxdb.ID(0))
+		if err != nil {
+			return err
+		}
+		labels, err := labelSVC.FindResourceLabels(ctx, influxdb.LabelMappingFilter{
+			ResourceID:   mapping.ResourceID,
+			ResourceType: mapping.ResourceType,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve labels: %v", err)
+		}
+		return b.printLabels(labelPrintOpt{labels: labels})
+	}
+
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	labels, err := labelSVC.FindLabels(ctx, influxdb.LabelFilter{
+		Name:  b.name,
+		OrgID: &orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve labels: %v", err)
+	}
+
+	return b.printLabels(labelPrintOpt{labels: labels})
+}
+
+func (b *cmdLabelBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn)
+	cmd.Short = "Update label"
+
+	opts := flagOpts{
+		{
+			DestP:  &b.name,
+			Flag:   "name",
+			Short:  'n',
+			EnvVar: "LABEL_NAME",
+			Desc:   "New label name",
+		},
+	}
+	opts.mustRegister(b.viper, cmd)
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The label ID (required)")
+	cmd.Flags().StringVar(&b.color, "color", "", "New hex color of the label")
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "New description of the label")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	labelSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode label id %q: %v", b.id, err)
+	}
+
+	update := influxdb.LabelUpdate{
+		Name:       b.name,
+		Properties: b.properties(),
+	}
+
+	l, err := labelSVC.UpdateLabel(context.Background(), id, update)
+	if err != nil {
+		return fmt.Errorf("failed to update label: %v", err)
+	}
+
+	return b.printLabels(labelPrintOpt{label: l})
+}
+
+func (b *cmdLabelBuilder) cmdAttach() *cobra.Command {
+	cmd := b.newCmd("attach", b.cmdAttachRunEFn)
+	cmd.Short = "Attach a label to a resource"
+	cmd.Long = `
+	Attach a label to a resource such as a bucket, check or notification rule.
+
+	Examples:
+		# attach a label to a check
+		influx label attach --id $LABEL_ID --resource-type checks --resource-id $CHECK_ID
+`
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The label ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerResourceFlags(cmd)
+	cmd.MarkFlagRequired("resource-type")
+	cmd.MarkFlagRequired("resource-id")
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdAttachRunEFn(cmd *cobra.Command, args []string) error {
+	return b.mapLabel(false)
+}
+
+func (b *cmdLabelBuilder) cmdDetach() *cobra.Command {
+	cmd := b.newCmd("detach", b.cmdDetachRunEFn)
+	cmd.Short = "Detach a label from a resource"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The label ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerResourceFlags(cmd)
+	cmd.MarkFlagRequired("resource-type")
+	cmd.MarkFlagRequired("resource-id")
+
+	return cmd
+}
+
+func (b *cmdLabelBuilder) cmdDetachRunEFn(cmd *cobra.Command, args []string) error {
+	return b.mapLabel(true)
+}
+
+func (b *cmdLabelBuilder) mapLabel(detach bool) error {
+	labelSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode label id %q: %v", b.id, err)
+	}
+
+	mapping, err := b.labelMapping(id)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	l, err := labelSVC.FindLabelByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find label with id %q: %v", id, err)
+	}
+
+	if detach {
+		if err := labelSVC.DeleteLabelMapping(ctx, mapping); err != nil {
+			return fmt.Errorf("failed to detach label: %v", err)
+		}
+	} else {
+		if err := labelSVC.CreateLabelMapping(ctx, mapping); err != nil {
+			return fmt.Errorf("failed to attach label: %v", err)
+		}
+	}
+
+	return b.printLabels(labelPrintOpt{
+		label:    l,
+		mapping:  mapping,
+		detached: detach,
+	})
+}
+
+func (b *cmdLabelBuilder) labelMapping(labelID influxdb.ID) (*influxdb.LabelMapping, error) {
+	resourceType := influxdb.ResourceType(b.resourceType)
+	if err := resourceType.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid resource type %q: %v", b.resourceType, err)
+	}
+
+	var resourceID influxdb.ID
+	if err := resourceID.DecodeFromString(b.resourceID); err != nil {
+		return nil, fmt.Errorf("failed to decode resource id %q: %v", b.resourceID, err)
+	}
+
+	return &influxdb.LabelMapping{
+		LabelID:      labelID,
+		ResourceID:   resourceID,
+		ResourceType: resourceType,
+	}, nil
+}
+
+func (b *cmdLabelBuilder) properties() map[string]string {
+	props := make(map[string]string)
+	if b.color != "" {
+		props["color"] = b.color
+	}
+	if b.description != "" {
+		props["description"] = b.description
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
+
+func (b *cmdLabelBuilder) newCmd(use string, runE func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := b.genericCLIOpts.newCmd(use, runE, true)
+	b.globalFlags.registerFlags(b.viper, cmd)
+	return cmd
+}
+
+func (b *cmdLabelBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(b.viper, cmd, &b.hideHeaders, &b.json)
+}
+
+func (b *cmdLabelBuilder) registerResourceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&b.resourceType, "resource-type", "", "The type of the resource, e.g. buckets, checks, notificationEndpoints")
+	cmd.Flags().StringVar(&b.resourceID, "resource-id", "", "The ID of the resource")
+}
+
+type labelPrintOpt struct {
+	deleted  bool
+	detached bool
+	mapping  *influxdb.LabelMapping
+	label    *influxdb.Label
+	labels   []*influxdb.Label
+}
+
+func (b *cmdLabelBuilder) printLabels(printOpt labelPrintOpt) error {
+	if b.json {
+		var v interface{} = printOpt.labels
+		if printOpt.labels == nil {
+			v = printOpt.label
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Name", "Color", "Description", "Organization ID"}
+	if printOpt.mapping != nil {
+		headers = append(headers, "Resource Type", "Resource ID")
+		if printOpt.detached {
+			headers = append(headers, "Detached")
+		}
+	}
+	if printOpt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if printOpt.label != nil {
+		printOpt.labels = append(printOpt.labels, printOpt.label)
+	}
+
+	for _, l := range printOpt.labels {
+		m := map[string]interface{}{
+			"ID":              l.ID.String(),
+			"Name":            l.Name,
+			"Color":           l.Properties["color"],
+			"Description":     l.Properties["description"],
+			"Organization ID": l.OrgID.String(),
+		}
+		if printOpt.mapping != nil {
+			m["Resource Type"] = printOpt.mapping.ResourceType
+			m["Resource ID"] = printOpt.mapping.ResourceID.String()
+			if printOpt.detached {
+				m["Detached"] = true
+			}
+		}
+		if printOpt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+func newLabelSVCs() (influxdb.LabelService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgSvc := &tenant.OrgClientService{Client: httpClient}
+
+	return &http.LabelService{Client: httpClient}, orgSvc, nil
+}
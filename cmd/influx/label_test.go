@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdLabel(t *testing.T) {
+	orgID := influxdb.ID(9000)
+
+	fakeSVCFn := func(svc influxdb.LabelService) labelSVCsFn {
+		return func() (influxdb.LabelService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			expectedLabel influxdb.Label
+			flags         []string
+			envVars       map[string]string
+		}{
+			{
+				name:  "basic just name",
+				flags: []string{"--name=new name", "--org=org name"},
+				expectedLabel: influxdb.Label{
+					Name:  "new name",
+					OrgID: orgID,
+				},
+			},
+			{
+				name: "with color and description",
+				flags: []string{
+					"--name=new name",
+					"--color=#326BBA",
+					"--description=desc",
+					"--org=org name",
+				},
+				expectedLabel: influxdb.Label{
+					Name:  "new name",
+					OrgID: orgID,
+					Properties: map[string]string{
+						"color":       "#326BBA",
+						"description": "desc",
+					},
+				},
+			},
+			{
+				name: "shorts",
+				flags: []string{
+					"-n=new name",
+					"--color=#326BBA",
+					"-o=org name",
+				},
+				expectedLabel: influxdb.Label{
+					Name:       "new name",
+					OrgID:      orgID,
+					Properties: map[string]string{"color": "#326BBA"},
+				},
+			},
+			{
+				name:    "env vars",
+				flags:   []string{"-o=org name"},
+				envVars: map[string]string{"INFLUX_LABEL_NAME": "new name"},
+				expectedLabel: influxdb.Label{
+					Name:  "new name",
+					OrgID: orgID,
+				},
+			},
+		}
+
+		cmdFn := func(expectedLabel influxdb.Label) func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := mock.NewLabelService()
+			svc.CreateLabelFn = func(ctx context.Context, l *influxdb.Label) error {
+				if !reflect.DeepEqual(expectedLabel, *l) {
+					return fmt.Errorf("unexpected label;\n\twant= %+v\n\tgot=  %+v", expectedLabel, *l)
+				}
+				return nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdLabelBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, tt.envVars)()
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(cmdFn(tt.expectedLabel))
+				cmd.SetArgs(append([]string{"label", "create"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			expectedID influxdb.ID
+			flags      []string
+		}{
+			{
+				name:       "with id",
+				expectedID: influxdb.ID(1),
+				flags:      []string{"--id=" + influxdb.ID(1).String()},
+			},
+			{
+				name:       "shorts",
+				expectedID: influxdb.ID(1),
+				flags:      []string{"-i=" + influxdb.ID(1).String()},
+			},
+		}
+
+		cmdFn := func(expectedID influxdb.ID) func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := mock.NewLabelService()
+			svc.FindLabelByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Label, error) {
+				return &influxdb.Label{ID: id, OrgID: orgID, Name: "l1"}, nil
+			}
+			svc.DeleteLabelFn = func(ctx context.Context, id influxdb.ID) error {
+				if expectedID != id {
+					return fmt.Errorf("unexpected id:\n\twant= %s\n\tgot=  %s", expectedID, id)
+				}
+				return nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdLabelBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(cmdFn(tt.expectedID))
+				cmd.SetArgs(append([]string{"label", "delete"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		type called struct {
+			name       string
+			orgID      influxdb.ID
+			resourceID influxdb.ID
+		}
+
+		tests := []struct {
+			name     string
+			expected called
+			flags    []string
+			command  string
+		}{
+			{
+				name:     "org id",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+			{
+				name:     "org and name",
+				flags:    []string{"--org=rg", "--name=l1"},
+				expected: called{orgID: orgID, name: "l1"},
+			},
+			{
+				name: "resource",
+				flags: []string{
+					"--resource-type=buckets",
+					"--resource-id=" + influxdb.ID(2).String(),
+				},
+				expected: called{resourceID: 2},
+			},
+			{
+				name:     "ls alias",
+				command:  "ls",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+		}
+
+		cmdFn := func() (func(*globalFlags, genericCLIOpts) *cobra.Command, *called) {
+			calls := new(called)
+
+			svc := mock.NewLabelService()
+			svc.FindLabelsFn = func(ctx context.Context, f influxdb.LabelFilter) ([]*influxdb.Label, error) {
+				if f.OrgID != nil {
+					calls.orgID = *f.OrgID
+				}
+				calls.name = f.Name
+				return nil, nil
+			}
+			svc.FindResourceLabelsFn = func(ctx context.Context, f influxdb.LabelMappingFilter) ([]*influxdb.Label, error) {
+				if f.ResourceType != influxdb.BucketsResourceType {
+					return nil, fmt.Errorf("unexpected resource type: %s", f.ResourceType)
+				}
+				calls.resourceID = f.ResourceID
+				return nil, nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdLabelBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}, calls
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				nestedCmdFn, calls := cmdFn()
+				cmd := builder.cmd(nestedCmdFn)
+
+				if tt.command == "" {
+					tt.command = "list"
+				}
+
+				cmd.SetArgs(append([]string{"label", tt.command}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, *calls)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("attach and detach", func(t *testing.T) {
+		labelID, resourceID := influxdb.ID(1), influxdb.ID(2)
+		expected := influxdb.LabelMapping{
+			LabelID:      labelID,
+			ResourceID:   resourceID,
+			ResourceType: influxdb.ChecksResourceType,
+		}
+
+		tests := []struct {
+			command string
+			flags   []string
+		}{
+			{
+				command: "attach",
+				flags: []string{
+					"--id=" + labelID.String(),
+					"--resource-type=checks",
+					"--resource-id=" + resourceID.String(),
+				},
+			},
+			{
+				command: "detach",
+				flags: []string{
+					"-i=" + labelID.String(),
+					"--resource-type=checks",
+					"--resource-id=" + resourceID.String(),
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				var got []influxdb.LabelMapping
+				svc := mock.NewLabelService()
+				svc.FindLabelByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Label, error) {
+					return &influxdb.Label{ID: id, OrgID: orgID, Name: "l1"}, nil
+				}
+				svc.CreateLabelMappingFn = func(ctx context.Context, m *influxdb.LabelMapping) error {
+					got = append(got, *m)
+					return nil
+				}
+				svc.DeleteLabelMappingFn = svc.CreateLabelMappingFn
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+					return newCmdLabelBuilder(fakeSVCFn(svc), g, opt).cmd()
+				})
+				cmd.SetArgs(append([]string{"label", tt.command}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, []influxdb.LabelMapping{expected}, got)
+			}
+
+			t.Run(tt.command, fn)
+		}
+	})
+
+	t.Run("attach with invalid resource type", func(t *testing.T) {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdLabelBuilder(fakeSVCFn(mock.NewLabelService()), g, opt).cmd()
+		})
+		cmd.SetArgs([]string{
+			"label", "attach",
+			"--id=" + influxdb.ID(1).String(),
+			"--resource-type=widgets",
+			"--resource-id=" + influxdb.ID(2).String(),
+		})
+
+		require.Error(t, cmd.Execute())
+	})
+}
@@ -324,10 +324,14 @@ func influxCmd(opts ...genericCLIOptFn) *cobra.Command {
 		cmdAuth,
 		cmdBackup,
 		cmdBucket,
+		cmdCheck,
 		cmdConfig,
 		cmdDashboard,
 		cmdDelete,
 		cmdExport,
+		cmdLabel,
+		cmdNotificationEndpoint,
+		cmdNotificationRule,
 		cmdOrganization,
 		cmdPing,
 		cmdQuery,
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"github.com/spf13/cobra"
+)
+
+type notificationEndpointSVCsFn func() (influxdb.NotificationEndpointService, influxdb.OrganizationService, error)
+
+func cmdNotificationEndpoint(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdNotificationEndpointBuilder(newNotificationEndpointSVCs, f, opt)
+	return builder.cmd()
+}
+
+type cmdNotificationEndpointBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn notificationEndpointSVCsFn
+
+	id          string
+	hideHeaders bool
+	json        bool
+	file        string
+	name        string
+	description string
+	status      string
+	org         organization
+}
+
+func newCmdNotificationEndpointBuilder(svcsFn notificationEndpointSVCsFn, f *globalFlags, opts genericCLIOpts) *cmdNotificationEndpointBuilder {
+	return &cmdNotificationEndpointBuilder{
+		globalFlags:    f,
+		genericCLIOpts: opts,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdNotificationEndpointBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("notification-endpoint", nil)
+	cmd.Short = "Notification endpoint management commands"
+	cmd.TraverseChildren = true
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdList(),
+		b.cmdUpdate(),
+	)
+
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Create notification endpoint"
+	cmd.Long = `
+	Create a notification endpoint from its JSON definition, as accepted by the
+	/api/v2/notificationEndpoints API.
+
+	Examples:
+		# create a notification endpoint from a file
+		influx notification-endpoint create --org $ORG_NAME --file $PATH_TO_ENDPOINT_JSON
+
+		# create a notification endpoint with a definition provided via STDIN
+		cat $PATH_TO_ENDPOINT_JSON | influx notification-endpoint create --org $ORG_NAME
+`
+
+	cmd.Flags().StringVarP(&b.file, "file", "f", "", "Path to the JSON notification endpoint definition")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
+	endpointSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	bb, err := readDefinition(b.in, b.file, "notification endpoint")
+	if err != nil {
+		return err
+	}
+
+	ne, err := endpoint.UnmarshalJSON(bb)
+	if err != nil {
+		return fmt.Errorf("failed to decode notification endpoint definition: %v", err)
+	}
+
+	// an org provided via flags takes precedence over the one in the definition
+	if !ne.GetOrgID().Valid() || b.org.id != "" || b.org.name != "" {
+		if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+			return err
+		}
+		orgID, err := b.org.getID(orgSVC)
+		if err != nil {
+			return err
+		}
+		ne.SetOrgID(orgID)
+	}
+	if ne.GetStatus() == "" {
+		ne.SetStatus(influxdb.Active)
+	}
+
+	if err := endpointSVC.CreateNotificationEndpoint(context.Background(), ne, 0); err != nil {
+		return fmt.Errorf("failed to create notification endpoint: %v", err)
+	}
+
+	return b.printEndpoints(notificationEndpointPrintOpt{endpoint: ne})
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete notification endpoint"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification endpoint ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	endpointSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode notification endpoint id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	ne, err := endpointSVC.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find notification endpoint with id %q: %v", id, err)
+	}
+	if _, _, err := endpointSVC.DeleteNotificationEndpoint(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete notification endpoint with id %q: %v", id, err)
+	}
+
+	return b.printEndpoints(notificationEndpointPrintOpt{
+		deleted:  true,
+		endpoint: ne,
+	})
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdList() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdListRunEFn)
+	cmd.Short = "List notification endpoints"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification endpoint ID")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdListRunEFn(cmd *cobra.Command, args []string) error {
+	endpointSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode notification endpoint id %q: %v", b.id, err)
+		}
+		ne, err := endpointSVC.FindNotificationEndpointByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve notification endpoint: %v", err)
+		}
+		return b.printEndpoints(notificationEndpointPrintOpt{
+			endpoints: []influxdb.NotificationEndpoint{ne},
+		})
+	}
+
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	endpoints, _, err := endpointSVC.FindNotificationEndpoints(ctx, influxdb.NotificationEndpointFilter{
+		OrgID: &orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve notification endpoints: %v", err)
+	}
+
+	return b.printEndpoints(notificationEndpointPrintOpt{endpoints: endpoints})
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn)
+	cmd.Short = "Update notification endpoint"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification endpoint ID (required)")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "New notification endpoint name")
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "New notification endpoint description")
+	cmd.Flags().StringVarP(&b.status, "status", "s", "", "New notification endpoint status, active or inactive")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	endpointSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode notification endpoint id %q: %v", b.id, err)
+	}
+
+	var update influxdb.NotificationEndpointUpdate
+	if b.name != "" {
+		update.Name = &b.name
+	}
+	if b.description != "" {
+		update.Description = &b.description
+	}
+	if b.status != "" {
+		status, err := parseStatus(b.status)
+		if err != nil {
+			return err
+		}
+		update.Status = &status
+	}
+
+	ne, err := endpointSVC.PatchNotificationEndpoint(context.Background(), id, update)
+	if err != nil {
+		return fmt.Errorf("failed to update notification endpoint: %v", err)
+	}
+
+	return b.printEndpoints(notificationEndpointPrintOpt{endpoint: ne})
+}
+
+func (b *cmdNotificationEndpointBuilder) newCmd(use string, runE func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := b.genericCLIOpts.newCmd(use, runE, true)
+	b.globalFlags.registerFlags(b.viper, cmd)
+	return cmd
+}
+
+func (b *cmdNotificationEndpointBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(b.viper, cmd, &b.hideHeaders, &b.json)
+}
+
+type notificationEndpointPrintOpt struct {
+	deleted   bool
+	endpoint  influxdb.NotificationEndpoint
+	endpoints []influxdb.NotificationEndpoint
+}
+
+func (b *cmdNotificationEndpointBuilder) printEndpoints(printOpt notificationEndpointPrintOpt) error {
+	if b.json {
+		var v interface{} = printOpt.endpoints
+		if printOpt.endpoints == nil {
+			v = printOpt.endpoint
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Name", "Type", "Status", "Organization ID"}
+	if printOpt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if printOpt.endpoint != nil {
+		printOpt.endpoints = append(printOpt.endpoints, printOpt.endpoint)
+	}
+
+	for _, ne := range printOpt.endpoints {
+		m := map[string]interface{}{
+			"ID":              ne.GetID().String(),
+			"Name":            ne.GetName(),
+			"Type":            ne.Type(),
+			"Status":          ne.GetStatus(),
+			"Organization ID": ne.GetOrgID().String(),
+		}
+		if printOpt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+func newNotificationEndpointSVCs() (influxdb.NotificationEndpointService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgSvc := &tenant.OrgClientService{Client: httpClient}
+
+	return http.NewNotificationEndpointService(httpClient), orgSvc, nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdNotificationEndpoint(t *testing.T) {
+	orgID := influxdb.ID(9000)
+
+	fakeSVCFn := func(svc influxdb.NotificationEndpointService) notificationEndpointSVCsFn {
+		return func() (influxdb.NotificationEndpointService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	newEndpoint := func(id, orgID influxdb.ID, status influxdb.Status) influxdb.NotificationEndpoint {
+		return &endpoint.Slack{
+			Base: endpoint.Base{
+				ID:     &id,
+				Name:   "e1",
+				OrgID:  &orgID,
+				Status: status,
+			},
+			URL: "https://hooks.slack.com/services/x",
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		defer removeTempFiles()
+
+		definition := func(orgID influxdb.ID, status influxdb.Status) string {
+			org := ""
+			if orgID.Valid() {
+				org = `"orgID": "` + orgID.String() + `", `
+			}
+			return `{"name": "e1", ` + org + `"type": "slack", "status": "` + string(status) + `", "url": "https://hooks.slack.com/services/x"}`
+		}
+
+		tests := []struct {
+			name           string
+			flags          []string
+			stdIn          string
+			expectedOrgID  influxdb.ID
+			expectedStatus influxdb.Status
+		}{
+			{
+				name:           "from file",
+				flags:          []string{"--org=org name", "--file=" + createTempFile("json", []byte(definition(0, influxdb.Inactive)))},
+				expectedOrgID:  orgID,
+				expectedStatus: influxdb.Inactive,
+			},
+			{
+				name:           "from STDIN",
+				flags:          []string{"--org=org name"},
+				stdIn:          definition(0, influxdb.Inactive),
+				expectedOrgID:  orgID,
+				expectedStatus: influxdb.Inactive,
+			},
+			{
+				name:           "org of definition and default status",
+				flags:          []string{"-f=" + createTempFile("json", []byte(definition(3, "")))},
+				expectedOrgID:  3,
+				expectedStatus: influxdb.Active,
+			},
+			{
+				name:           "org flag overrides definition",
+				flags:          []string{"--org-id=" + influxdb.ID(4).String(), "-f=" + createTempFile("json", []byte(definition(3, influxdb.Active)))},
+				expectedOrgID:  4,
+				expectedStatus: influxdb.Active,
+			},
+		}
+
+		cmdFn := func(expectedOrgID influxdb.ID, expectedStatus influxdb.Status) func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := mock.NewNotificationEndpointService()
+			svc.CreateNotificationEndpointF = func(ctx context.Context, ne influxdb.NotificationEndpoint, userID influxdb.ID) error {
+				slack, ok := ne.(*endpoint.Slack)
+				if !ok || slack.Name != "e1" || slack.URL != "https://hooks.slack.com/services/x" {
+					return fmt.Errorf("unexpected notification endpoint: %+v", ne)
+				}
+				if got := ne.GetOrgID(); got != expectedOrgID {
+					return fmt.Errorf("unexpected org id:\n\twant= %s\n\tgot=  %s", expectedOrgID, got)
+				}
+				if got := ne.GetStatus(); got != expectedStatus {
+					return fmt.Errorf("unexpected status:\n\twant= %s\n\tgot=  %s", expectedStatus, got)
+				}
+				ne.SetID(1)
+				return nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdNotificationEndpointBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				var stdIn io.Reader = new(bytes.Buffer)
+				if tt.stdIn != "" {
+					defer withStdIn(t, tt.stdIn)()
+					stdIn = os.Stdin
+				}
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(stdIn),
+					out(outBuf),
+				)
+				cmd := builder.cmd(cmdFn(tt.expectedOrgID, tt.expectedStatus))
+				cmd.SetArgs(append([]string{"notification-endpoint", "create", "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, [][]string{
+					{influxdb.ID(1).String(), "e1", "slack", string(tt.expectedStatus), tt.expectedOrgID.String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		var deleted influxdb.ID
+		svc := mock.NewNotificationEndpointService()
+		svc.FindNotificationEndpointByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+			return newEndpoint(id, orgID, influxdb.Active), nil
+		}
+		svc.DeleteNotificationEndpointF = func(ctx context.Context, id influxdb.ID) ([]influxdb.SecretField, influxdb.ID, error) {
+			deleted = id
+			return nil, orgID, nil
+		}
+
+		outBuf := new(bytes.Buffer)
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(outBuf),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdNotificationEndpointBuilder(fakeSVCFn(svc), g, opt).cmd()
+		})
+		cmd.SetArgs([]string{"notification-endpoint", "delete", "--id=" + influxdb.ID(1).String()})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, influxdb.ID(1), deleted)
+		assert.Equal(t, [][]string{
+			{"ID", "Name", "Type", "Status", "Organization", "ID", "Deleted"},
+			{influxdb.ID(1).String(), "e1", "slack", "active", orgID.String(), "true"},
+		}, outputFields(outBuf))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		type called struct {
+			id    influxdb.ID
+			orgID influxdb.ID
+		}
+
+		tests := []struct {
+			name     string
+			expected called
+			flags    []string
+			command  string
+		}{
+			{
+				name:     "org id",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+			{
+				name:     "org",
+				flags:    []string{"--org=rg"},
+				expected: called{orgID: orgID},
+			},
+			{
+				name:     "id",
+				flags:    []string{"--id=" + influxdb.ID(2).String()},
+				expected: called{id: 2},
+			},
+			{
+				name:     "ls alias",
+				command:  "ls",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+		}
+
+		cmdFn := func() (func(*globalFlags, genericCLIOpts) *cobra.Command, *called) {
+			calls := new(called)
+
+			ne := newEndpoint(2, 3, influxdb.Inactive)
+			svc := mock.NewNotificationEndpointService()
+			svc.FindNotificationEndpointByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+				calls.id = id
+				return ne, nil
+			}
+			svc.FindNotificationEndpointsF = func(ctx context.Context, f influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+				if f.OrgID != nil {
+					calls.orgID = *f.OrgID
+				}
+				return []influxdb.NotificationEndpoint{ne}, 1, nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdNotificationEndpointBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}, calls
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(outBuf),
+				)
+				nestedCmdFn, calls := cmdFn()
+				cmd := builder.cmd(nestedCmdFn)
+
+				if tt.command == "" {
+					tt.command = "list"
+				}
+
+				cmd.SetArgs(append([]string{"notification-endpoint", tt.command, "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, *calls)
+				assert.Equal(t, [][]string{
+					{influxdb.ID(2).String(), "e1", "slack", "inactive", influxdb.ID(3).String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		status := func(s influxdb.Status) *influxdb.Status { return &s }
+		description := "new description"
+
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.NotificationEndpointUpdate
+			wantErr  bool
+		}{
+			{
+				name:     "description",
+				flags:    []string{"--description=" + description},
+				expected: influxdb.NotificationEndpointUpdate{Description: &description},
+			},
+			{
+				name:     "active",
+				flags:    []string{"--status=active"},
+				expected: influxdb.NotificationEndpointUpdate{Status: status(influxdb.Active)},
+			},
+			{
+				name:     "inactive short",
+				flags:    []string{"-s=inactive"},
+				expected: influxdb.NotificationEndpointUpdate{Status: status(influxdb.Inactive)},
+			},
+			{
+				name:    "invalid status",
+				flags:   []string{"--status=paused"},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				var got *influxdb.NotificationEndpointUpdate
+				svc := mock.NewNotificationEndpointService()
+				svc.PatchNotificationEndpointF = func(ctx context.Context, id influxdb.ID, u influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+					if id != 1 {
+						return nil, fmt.Errorf("unexpected id:\n\twant= %s\n\tgot=  %s", influxdb.ID(1), id)
+					}
+					got = &u
+					return newEndpoint(id, orgID, influxdb.Active), nil
+				}
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+					return newCmdNotificationEndpointBuilder(fakeSVCFn(svc), g, opt).cmd()
+				})
+				cmd.SetArgs(append([]string{"notification-endpoint", "update", "--id=" + influxdb.ID(1).String()}, tt.flags...))
+
+				if tt.wantErr {
+					require.Error(t, cmd.Execute())
+					assert.Nil(t, got)
+					return
+				}
+				require.NoError(t, cmd.Execute())
+				require.NotNil(t, got)
+				assert.Equal(t, tt.expected, *got)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+}
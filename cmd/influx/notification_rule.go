@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"github.com/spf13/cobra"
+)
+
+type notificationRuleSVCsFn func() (influxdb.NotificationRuleStore, influxdb.OrganizationService, error)
+
+func cmdNotificationRule(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdNotificationRuleBuilder(newNotificationRuleSVCs, f, opt)
+	return builder.cmd()
+}
+
+type cmdNotificationRuleBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn notificationRuleSVCsFn
+
+	id          string
+	hideHeaders bool
+	json        bool
+	file        string
+	name        string
+	description string
+	status      string
+	org         organization
+}
+
+func newCmdNotificationRuleBuilder(svcsFn notificationRuleSVCsFn, f *globalFlags, opts genericCLIOpts) *cmdNotificationRuleBuilder {
+	return &cmdNotificationRuleBuilder{
+		globalFlags:    f,
+		genericCLIOpts: opts,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdNotificationRuleBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("notification-rule", nil)
+	cmd.Short = "Notification rule management commands"
+	cmd.TraverseChildren = true
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdList(),
+		b.cmdUpdate(),
+	)
+
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Create notification rule"
+	cmd.Long = `
+	Create a notification rule from its JSON definition, as accepted by the
+	/api/v2/notificationRules API.
+
+	Examples:
+		# create a notification rule from a file
+		influx notification-rule create --org $ORG_NAME --file $PATH_TO_RULE_JSON
+
+		# create a notification rule with a definition provided via STDIN
+		cat $PATH_TO_RULE_JSON | influx notification-rule create --org $ORG_NAME
+`
+
+	cmd.Flags().StringVarP(&b.file, "file", "f", "", "Path to the JSON notification rule definition")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
+	ruleSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	bb, err := readDefinition(b.in, b.file, "notification rule")
+	if err != nil {
+		return err
+	}
+
+	nr, err := rule.UnmarshalJSON(bb)
+	if err != nil {
+		return fmt.Errorf("failed to decode notification rule definition: %v", err)
+	}
+
+	// the status belongs to the rule's underlying task and is not part of the
+	// decoded rule, so it is read separately.
+	var raw struct {
+		Status influxdb.Status `json:"status"`
+	}
+	if err := json.Unmarshal(bb, &raw); err != nil {
+		return fmt.Errorf("failed to decode notification rule definition: %v", err)
+	}
+	if raw.Status == "" {
+		raw.Status = influxdb.Active
+	}
+
+	// an org provided via flags takes precedence over the one in the definition
+	if !nr.GetOrgID().Valid() || b.org.id != "" || b.org.name != "" {
+		if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+			return err
+		}
+		orgID, err := b.org.getID(orgSVC)
+		if err != nil {
+			return err
+		}
+		nr.SetOrgID(orgID)
+	}
+
+	create := influxdb.NotificationRuleCreate{
+		NotificationRule: nr,
+		Status:           raw.Status,
+	}
+	if err := ruleSVC.CreateNotificationRule(context.Background(), create, 0); err != nil {
+		return fmt.Errorf("failed to create notification rule: %v", err)
+	}
+
+	return b.printRules(notificationRulePrintOpt{rule: nr})
+}
+
+func (b *cmdNotificationRuleBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete notification rule"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification rule ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	ruleSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode notification rule id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	nr, err := ruleSVC.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find notification rule with id %q: %v", id, err)
+	}
+	if err := ruleSVC.DeleteNotificationRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete notification rule with id %q: %v", id, err)
+	}
+
+	return b.printRules(notificationRulePrintOpt{
+		deleted: true,
+		rule:    nr,
+	})
+}
+
+func (b *cmdNotificationRuleBuilder) cmdList() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdListRunEFn)
+	cmd.Short = "List notification rules"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification rule ID")
+	b.org.register(b.viper, cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) cmdListRunEFn(cmd *cobra.Command, args []string) error {
+	ruleSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode notification rule id %q: %v", b.id, err)
+		}
+		nr, err := ruleSVC.FindNotificationRuleByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve notification rule: %v", err)
+		}
+		return b.printRules(notificationRulePrintOpt{
+			rules: []influxdb.NotificationRule{nr},
+		})
+	}
+
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	rules, _, err := ruleSVC.FindNotificationRules(ctx, influxdb.NotificationRuleFilter{
+		OrgID: &orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve notification rules: %v", err)
+	}
+
+	return b.printRules(notificationRulePrintOpt{rules: rules})
+}
+
+func (b *cmdNotificationRuleBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn)
+	cmd.Short = "Update notification rule"
+
+	b.registerPrintFlags(cmd)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification rule ID (required)")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "New notification rule name")
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "New notification rule description")
+	cmd.Flags().StringVarP(&b.status, "status", "s", "", "New notification rule status, active or inactive")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	ruleSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode notification rule id %q: %v", b.id, err)
+	}
+
+	var update influxdb.NotificationRuleUpdate
+	if b.name != "" {
+		update.Name = &b.name
+	}
+	if b.description != "" {
+		update.Description = &b.description
+	}
+	if b.status != "" {
+		status, err := parseStatus(b.status)
+		if err != nil {
+			return err
+		}
+		update.Status = &status
+	}
+
+	nr, err := ruleSVC.PatchNotificationRule(context.Background(), id, update)
+	if err != nil {
+		return fmt.Errorf("failed to update notification rule: %v", err)
+	}
+
+	return b.printRules(notificationRulePrintOpt{rule: nr})
+}
+
+func (b *cmdNotificationRuleBuilder) newCmd(use string, runE func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := b.genericCLIOpts.newCmd(use, runE, true)
+	b.globalFlags.registerFlags(b.viper, cmd)
+	return cmd
+}
+
+func (b *cmdNotificationRuleBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(b.viper, cmd, &b.hideHeaders, &b.json)
+}
+
+type notificationRulePrintOpt struct {
+	deleted bool
+	rule    influxdb.NotificationRule
+	rules   []influxdb.NotificationRule
+}
+
+func (b *cmdNotificationRuleBuilder) printRules(printOpt notificationRulePrintOpt) error {
+	if b.json {
+		var v interface{} = printOpt.rules
+		if printOpt.rules == nil {
+			v = printOpt.rule
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Name", "Type", "Endpoint ID", "Task ID", "Organization ID"}
+	if printOpt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if printOpt.rule != nil {
+		printOpt.rules = append(printOpt.rules, printOpt.rule)
+	}
+
+	for _, nr := range printOpt.rules {
+		m := map[string]interface{}{
+			"ID":              nr.GetID().String(),
+			"Name":            nr.GetName(),
+			"Type":            nr.Type(),
+			"Endpoint ID":     nr.GetEndpointID().String(),
+			"Task ID":         nr.GetTaskID().String(),
+			"Organization ID": nr.GetOrgID().String(),
+		}
+		if printOpt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+func newNotificationRuleSVCs() (influxdb.NotificationRuleStore, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgSvc := &tenant.OrgClientService{Client: httpClient}
+
+	return http.NewNotificationRuleService(httpClient), orgSvc, nil
+}
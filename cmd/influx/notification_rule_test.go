@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdNotificationRule(t *testing.T) {
+	orgID := influxdb.ID(9000)
+	endpointID := influxdb.ID(5)
+
+	fakeSVCFn := func(svc influxdb.NotificationRuleStore) notificationRuleSVCsFn {
+		return func() (influxdb.NotificationRuleStore, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	newRule := func(id, orgID influxdb.ID) influxdb.NotificationRule {
+		return &rule.Slack{
+			Base: rule.Base{
+				ID:         id,
+				Name:       "r1",
+				EndpointID: endpointID,
+				OrgID:      orgID,
+				TaskID:     6,
+			},
+			MessageTemplate: "msg",
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		defer removeTempFiles()
+
+		definition := func(orgID influxdb.ID, status influxdb.Status) string {
+			org := ""
+			if orgID.Valid() {
+				org = `"orgID": "` + orgID.String() + `", `
+			}
+			return `{"name": "r1", ` + org + `"type": "slack", "status": "` + string(status) + `", "endpointID": "` + endpointID.String() + `", "every": "1h", "messageTemplate": "msg"}`
+		}
+
+		tests := []struct {
+			name           string
+			flags          []string
+			stdIn          string
+			expectedOrgID  influxdb.ID
+			expectedStatus influxdb.Status
+		}{
+			{
+				name:           "from file",
+				flags:          []string{"--org=org name", "--file=" + createTempFile("json", []byte(definition(0, influxdb.Inactive)))},
+				expectedOrgID:  orgID,
+				expectedStatus: influxdb.Inactive,
+			},
+			{
+				name:           "from STDIN",
+				flags:          []string{"--org=org name"},
+				stdIn:          definition(0, influxdb.Inactive),
+				expectedOrgID:  orgID,
+				expectedStatus: influxdb.Inactive,
+			},
+			{
+				name:           "org of definition and default status",
+				flags:          []string{"-f=" + createTempFile("json", []byte(definition(3, "")))},
+				expectedOrgID:  3,
+				expectedStatus: influxdb.Active,
+			},
+			{
+				name:           "org flag overrides definition",
+				flags:          []string{"--org-id=" + influxdb.ID(4).String(), "-f=" + createTempFile("json", []byte(definition(3, influxdb.Active)))},
+				expectedOrgID:  4,
+				expectedStatus: influxdb.Active,
+			},
+		}
+
+		cmdFn := func(expectedOrgID influxdb.ID, expectedStatus influxdb.Status) func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := mock.NewNotificationRuleStore()
+			svc.CreateNotificationRuleF = func(ctx context.Context, nr influxdb.NotificationRuleCreate, userID influxdb.ID) error {
+				slack, ok := nr.NotificationRule.(*rule.Slack)
+				if !ok || slack.Name != "r1" || slack.EndpointID != endpointID || slack.MessageTemplate != "msg" {
+					return fmt.Errorf("unexpected notification rule: %+v", nr.NotificationRule)
+				}
+				if got := nr.GetOrgID(); got != expectedOrgID {
+					return fmt.Errorf("unexpected org id:\n\twant= %s\n\tgot=  %s", expectedOrgID, got)
+				}
+				if nr.Status != expectedStatus {
+					return fmt.Errorf("unexpected status:\n\twant= %s\n\tgot=  %s", expectedStatus, nr.Status)
+				}
+				nr.SetID(1)
+				nr.SetTaskID(6)
+				return nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdNotificationRuleBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				var stdIn io.Reader = new(bytes.Buffer)
+				if tt.stdIn != "" {
+					defer withStdIn(t, tt.stdIn)()
+					stdIn = os.Stdin
+				}
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(stdIn),
+					out(outBuf),
+				)
+				cmd := builder.cmd(cmdFn(tt.expectedOrgID, tt.expectedStatus))
+				cmd.SetArgs(append([]string{"notification-rule", "create", "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, [][]string{
+					{influxdb.ID(1).String(), "r1", "slack", endpointID.String(), influxdb.ID(6).String(), tt.expectedOrgID.String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		var deleted influxdb.ID
+		svc := mock.NewNotificationRuleStore()
+		svc.FindNotificationRuleByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+			return newRule(id, orgID), nil
+		}
+		svc.DeleteNotificationRuleF = func(ctx context.Context, id influxdb.ID) error {
+			deleted = id
+			return nil
+		}
+
+		outBuf := new(bytes.Buffer)
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(outBuf),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdNotificationRuleBuilder(fakeSVCFn(svc), g, opt).cmd()
+		})
+		cmd.SetArgs([]string{"notification-rule", "delete", "--id=" + influxdb.ID(1).String()})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, influxdb.ID(1), deleted)
+		assert.Equal(t, [][]string{
+			{"ID", "Name", "Type", "Endpoint", "ID", "Task", "ID", "Organization", "ID", "Deleted"},
+			{influxdb.ID(1).String(), "r1", "slack", endpointID.String(), influxdb.ID(6).String(), orgID.String(), "true"},
+		}, outputFields(outBuf))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		type called struct {
+			id    influxdb.ID
+			orgID influxdb.ID
+		}
+
+		tests := []struct {
+			name     string
+			expected called
+			flags    []string
+			command  string
+		}{
+			{
+				name:     "org id",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+			{
+				name:     "org",
+				flags:    []string{"--org=rg"},
+				expected: called{orgID: orgID},
+			},
+			{
+				name:     "id",
+				flags:    []string{"--id=" + influxdb.ID(2).String()},
+				expected: called{id: 2},
+			},
+			{
+				name:     "ls alias",
+				command:  "ls",
+				flags:    []string{"--org-id=" + influxdb.ID(3).String()},
+				expected: called{orgID: 3},
+			},
+		}
+
+		cmdFn := func() (func(*globalFlags, genericCLIOpts) *cobra.Command, *called) {
+			calls := new(called)
+
+			nr := newRule(2, 3)
+			svc := mock.NewNotificationRuleStore()
+			svc.FindNotificationRuleByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+				calls.id = id
+				return nr, nil
+			}
+			svc.FindNotificationRulesF = func(ctx context.Context, f influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error) {
+				if f.OrgID != nil {
+					calls.orgID = *f.OrgID
+				}
+				return []influxdb.NotificationRule{nr}, 1, nil
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				return newCmdNotificationRuleBuilder(fakeSVCFn(svc), g, opt).cmd()
+			}, calls
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				outBuf := new(bytes.Buffer)
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(outBuf),
+				)
+				nestedCmdFn, calls := cmdFn()
+				cmd := builder.cmd(nestedCmdFn)
+
+				if tt.command == "" {
+					tt.command = "list"
+				}
+
+				cmd.SetArgs(append([]string{"notification-rule", tt.command, "--hide-headers"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, *calls)
+				assert.Equal(t, [][]string{
+					{influxdb.ID(2).String(), "r1", "slack", endpointID.String(), influxdb.ID(6).String(), influxdb.ID(3).String()},
+				}, outputFields(outBuf))
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		status := func(s influxdb.Status) *influxdb.Status { return &s }
+		name := "new name"
+
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.NotificationRuleUpdate
+			wantErr  bool
+		}{
+			{
+				name:     "name",
+				flags:    []string{"-n=" + name},
+				expected: influxdb.NotificationRuleUpdate{Name: &name},
+			},
+			{
+				name:     "active",
+				flags:    []string{"--status=active"},
+				expected: influxdb.NotificationRuleUpdate{Status: status(influxdb.Active)},
+			},
+			{
+				name:     "inactive short",
+				flags:    []string{"-s=inactive"},
+				expected: influxdb.NotificationRuleUpdate{Status: status(influxdb.Inactive)},
+			},
+			{
+				name:    "invalid status",
+				flags:   []string{"--status=paused"},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				var got *influxdb.NotificationRuleUpdate
+				svc := mock.NewNotificationRuleStore()
+				svc.PatchNotificationRuleF = func(ctx context.Context, id influxdb.ID, u influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+					if id != 1 {
+						return nil, fmt.Errorf("unexpected id:\n\twant= %s\n\tgot=  %s", influxdb.ID(1), id)
+					}
+					got = &u
+					return newRule(id, orgID), nil
+				}
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+					return newCmdNotificationRuleBuilder(fakeSVCFn(svc), g, opt).cmd()
+				})
+				cmd.SetArgs(append([]string{"notification-rule", "update", "--id=" + influxdb.ID(1).String()}, tt.flags...))
+
+				if tt.wantErr {
+					require.Error(t, cmd.Execute())
+					assert.Nil(t, got)
+					return
+				}
+				require.NoError(t, cmd.Execute())
+				require.NotNil(t, got)
+				assert.Equal(t, tt.expected, *got)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+}
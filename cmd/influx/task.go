@@ -22,6 +22,15 @@ func newTaskSVCs() (influxdb.TaskService, influxdb.OrganizationService, error) {
 	return &http.TaskService{Client: httpClient}, &tenant.OrgClientService{Client: httpClient}, nil
 }
 
+func newBackfillSVC() (influxdb.BackfillService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.BackfillService{Client: httpClient}, nil
+}
+
 func cmdTask(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 	builder := newCmdTaskBuilder(newTaskSVCs, f, opt)
 	return builder.cmd()
@@ -31,7 +40,8 @@ type cmdTaskBuilder struct {
 	opts        genericCLIOpts
 	globalFlags *globalFlags
 
-	svcFn taskSVCsFn
+	svcFn         taskSVCsFn
+	backfillSVCFn func() (influxdb.BackfillService, error)
 
 	taskID         string
 	runID          string
@@ -42,14 +52,16 @@ type cmdTaskBuilder struct {
 	taskRerunFailedFlags taskRerunFailedFlags
 	taskUpdateFlags      taskUpdateFlags
 	taskRunFindFlags     taskRunFindFlags
+	taskBackfillFlags    taskBackfillFlags
 	org                  organization
 }
 
 func newCmdTaskBuilder(svcsFn taskSVCsFn, f *globalFlags, opts genericCLIOpts) *cmdTaskBuilder {
 	return &cmdTaskBuilder{
-		globalFlags: f,
-		opts:        opts,
-		svcFn:       svcsFn,
+		globalFlags:   f,
+		opts:          opts,
+		svcFn:         svcsFn,
+		backfillSVCFn: newBackfillSVC,
 	}
 }
 
@@ -61,6 +73,7 @@ func (b *cmdTaskBuilder) cmd() *cobra.Command {
 	cmd.AddCommand(
 		b.taskLogCmd(),
 		b.taskRunCmd(),
+		b.taskBackfillCmd(),
 		b.taskCreateCmd(),
 		b.taskDeleteCmd(),
 		b.taskFindCmd(),
@@ -721,3 +734,192 @@ func (b *cmdTaskBuilder) runRetryF(*cobra.Command, []string) error {
 
 	return nil
 }
+
+func (b *cmdTaskBuilder) taskBackfillCmd() *cobra.Command {
+	cmd := b.opts.newCmd("backfill", nil, false)
+	cmd.Run = seeHelp
+	cmd.Short = "Run a task for every time it was scheduled in a time range"
+	cmd.AddCommand(
+		b.taskBackfillCreateCmd(),
+		b.taskBackfillFindCmd(),
+		b.taskBackfillCancelCmd(),
+	)
+
+	return cmd
+}
+
+type taskBackfillFlags struct {
+	backfillID string
+	start      string
+	end        string
+}
+
+func (b *cmdTaskBuilder) taskBackfillCreateCmd() *cobra.Command {
+	cmd := b.opts.newCmd("create", b.taskBackfillCreateF, true)
+	cmd.Short = "Start a backfill of a task"
+	cmd.Long = `Start a run of the task for every time its schedule triggered from the
+start time (inclusive) until the end time (exclusive). Times are RFC3339.`
+
+	b.globalFlags.registerFlags(b.opts.viper, cmd)
+	registerPrintOptions(b.opts.viper, cmd, &b.taskPrintFlags.hideHeaders, &b.taskPrintFlags.json)
+	cmd.Flags().StringVarP(&b.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&b.taskBackfillFlags.start, "start", "", "", "start of the time range, RFC3339 (required)")
+	cmd.Flags().StringVarP(&b.taskBackfillFlags.end, "end", "", "", "end of the time range, RFC3339 (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("end")
+
+	return cmd
+}
+
+func (b *cmdTaskBuilder) taskBackfillCreateF(*cobra.Command, []string) error {
+	svc, err := b.backfillSVCFn()
+	if err != nil {
+		return err
+	}
+
+	taskID, err := influxdb.IDFromString(b.taskID)
+	if err != nil {
+		return err
+	}
+	start, err := time.Parse(time.RFC3339, b.taskBackfillFlags.start)
+	if err != nil {
+		return fmt.Errorf("invalid start time: %v", err)
+	}
+	end, err := time.Parse(time.RFC3339, b.taskBackfillFlags.end)
+	if err != nil {
+		return fmt.Errorf("invalid end time: %v", err)
+	}
+
+	backfill, err := svc.CreateBackfill(context.Background(), *taskID, start, end)
+	if err != nil {
+		return err
+	}
+
+	return b.printBackfills(backfill)
+}
+
+func (b *cmdTaskBuilder) taskBackfillFindCmd() *cobra.Command {
+	cmd := b.opts.newCmd("list", b.taskBackfillFindF, true)
+	cmd.Short = "List backfills for a task"
+	cmd.Aliases = []string{"find", "ls"}
+
+	b.globalFlags.registerFlags(b.opts.viper, cmd)
+	registerPrintOptions(b.opts.viper, cmd, &b.taskPrintFlags.hideHeaders, &b.taskPrintFlags.json)
+	cmd.Flags().StringVarP(&b.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&b.taskBackfillFlags.backfillID, "backfill-id", "", "", "backfill id")
+	cmd.MarkFlagRequired("task-id")
+
+	return cmd
+}
+
+func (b *cmdTaskBuilder) taskBackfillFindF(*cobra.Command, []string) error {
+	svc, err := b.backfillSVCFn()
+	if err != nil {
+		return err
+	}
+
+	taskID, err := influxdb.IDFromString(b.taskID)
+	if err != nil {
+		return err
+	}
+
+	var backfills []*influxdb.Backfill
+	if b.taskBackfillFlags.backfillID != "" {
+		id, err := influxdb.IDFromString(b.taskBackfillFlags.backfillID)
+		if err != nil {
+			return err
+		}
+		backfill, err := svc.FindBackfillByID(context.Background(), *taskID, *id)
+		if err != nil {
+			return err
+		}
+		backfills = append(backfills, backfill)
+	} else {
+		backfills, err = svc.FindBackfills(context.Background(), *taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.printBackfills(backfills...)
+}
+
+func (b *cmdTaskBuilder) taskBackfillCancelCmd() *cobra.Command {
+	cmd := b.opts.newCmd("cancel", b.taskBackfillCancelF, true)
+	cmd.Short = "Cancel a backfill"
+
+	b.globalFlags.registerFlags(b.opts.viper, cmd)
+	cmd.Flags().StringVarP(&b.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&b.taskBackfillFlags.backfillID, "backfill-id", "", "", "backfill id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("backfill-id")
+
+	return cmd
+}
+
+func (b *cmdTaskBuilder) taskBackfillCancelF(*cobra.Command, []string) error {
+	svc, err := b.backfillSVCFn()
+	if err != nil {
+		return err
+	}
+
+	var taskID, id influxdb.ID
+	if err := taskID.DecodeFromString(b.taskID); err != nil {
+		return err
+	}
+	if err := id.DecodeFromString(b.taskBackfillFlags.backfillID); err != nil {
+		return err
+	}
+
+	if err := svc.CancelBackfill(context.Background(), taskID, id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backfill %s of task %s canceled.\n", id, taskID)
+
+	return nil
+}
+
+func (b *cmdTaskBuilder) printBackfills(backfills ...*influxdb.Backfill) error {
+	if b.taskPrintFlags.json {
+		if backfills == nil {
+			// guarantee we never return a null value from CLI
+			backfills = make([]*influxdb.Backfill, 0)
+		}
+		return b.opts.writeJSON(backfills)
+	}
+
+	tabW := b.opts.newTabWriter()
+	defer tabW.Flush()
+
+	tabW.HideHeaders(b.taskPrintFlags.hideHeaders)
+
+	tabW.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Status",
+		"Start",
+		"End",
+		"TotalRuns",
+		"CompletedRuns",
+		"FailedRuns",
+		"LastError",
+	)
+
+	for _, bf := range backfills {
+		tabW.Write(map[string]interface{}{
+			"ID":            bf.ID,
+			"TaskID":        bf.TaskID,
+			"Status":        bf.Status,
+			"Start":         bf.Start.Format(time.RFC3339),
+			"End":           bf.End.Format(time.RFC3339),
+			"TotalRuns":     bf.TotalRuns,
+			"CompletedRuns": bf.CompletedRuns,
+			"FailedRuns":    bf.FailedRuns,
+			"LastError":     bf.LastError,
+		})
+	}
+
+	return nil
+}
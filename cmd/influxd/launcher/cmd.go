@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/bolt"
 	"github.com/influxdata/influxdb/v2/fluxinit"
 	"github.com/influxdata/influxdb/v2/internal/fs"
@@ -129,9 +130,16 @@ type InfluxdOpts struct {
 	SessionLength        int // in minutes
 	SessionRenewDisabled bool
 
+	HttpWriteStreamBatchBytes int
+
 	NatsPort            int
 	NatsMaxPayloadBytes int
 
+	ScraperFileSDFiles           []string
+	ScraperFileSDOrgID           influxdb.ID
+	ScraperFileSDBucketID        influxdb.ID
+	ScraperFileSDRefreshInterval time.Duration
+
 	NoTasks      bool
 	FeatureFlags map[string]string
 
@@ -141,6 +149,12 @@ type InfluxdOpts struct {
 	MemoryBytesQuotaPerQuery        int64
 	MaxMemoryBytes                  int64
 	QueueSize                       int32
+	TaskConcurrencyQuota            int32
+	BackgroundConcurrencyQuota      int32
+	OrgConcurrencyQuota             int32
+	OrgMemoryBytesQuota             int64
+	QueryLogEnabled                 bool
+	QueryLogSlowThreshold           time.Duration
 	CoordinatorConfig               coordinator.Config
 
 	// Storage options.
@@ -179,6 +193,8 @@ func newOpts(viper *viper.Viper) *InfluxdOpts {
 		NatsPort:            nats.RandomPort,
 		NatsMaxPayloadBytes: natsserver.MAX_PAYLOAD_SIZE,
 
+		ScraperFileSDRefreshInterval: 10 * time.Second,
+
 		NoTasks: false,
 
 		ConcurrencyQuota:                10,
@@ -272,6 +288,12 @@ func (o *InfluxdOpts) bindCliOpts() []cli.Opt {
 			Default: o.SessionRenewDisabled,
 			Desc:    "disables automatically extending session ttl on request",
 		},
+		{
+			DestP:   &o.HttpWriteStreamBatchBytes,
+			Flag:    "http-write-stream-batch-bytes",
+			Default: o.HttpWriteStreamBatchBytes,
+			Desc:    "stream write request bodies, writing points in sub-batches of this many bytes instead of buffering the whole body; bodies which may be rejected as a whole by the size limit or a parse error are written once fully read; 0 disables streaming",
+		},
 		{
 			DestP: &o.VaultConfig.Address,
 			Flag:  "vault-addr",
@@ -380,6 +402,42 @@ func (o *InfluxdOpts) bindCliOpts() []cli.Opt {
 			Default: o.QueueSize,
 			Desc:    "the number of queries that are allowed to be awaiting execution before new queries are rejected",
 		},
+		{
+			DestP:   &o.TaskConcurrencyQuota,
+			Flag:    "query-task-concurrency",
+			Default: o.TaskConcurrencyQuota,
+			Desc:    "the number of task queries that are allowed to execute concurrently. Task queries only start when no interactive query is waiting. If this is unset, then task queries may use all of query-concurrency",
+		},
+		{
+			DestP:   &o.BackgroundConcurrencyQuota,
+			Flag:    "query-background-concurrency",
+			Default: o.BackgroundConcurrencyQuota,
+			Desc:    "the number of background queries that are allowed to execute concurrently. Background queries only start when no interactive or task query is waiting. If this is unset, then background queries may use all of query-concurrency",
+		},
+		{
+			DestP:   &o.OrgConcurrencyQuota,
+			Flag:    "query-org-concurrency",
+			Default: o.OrgConcurrencyQuota,
+			Desc:    "the number of queries of a single organization that are allowed to execute concurrently. If this is unset, then there is no limit per organization",
+		},
+		{
+			DestP:   &o.OrgMemoryBytesQuota,
+			Flag:    "query-org-memory-bytes",
+			Default: o.OrgMemoryBytesQuota,
+			Desc:    "maximum number of bytes the queries of a single organization are allowed to use at any given time. This must be greater or equal to query-initial-memory-bytes. If this is unset, then there is no limit per organization",
+		},
+		{
+			DestP:   &o.QueryLogEnabled,
+			Flag:    "query-log-enabled",
+			Default: o.QueryLogEnabled,
+			Desc:    "log the queries of each organization into its _monitoring bucket",
+		},
+		{
+			DestP:   &o.QueryLogSlowThreshold,
+			Flag:    "query-log-slow-threshold",
+			Default: o.QueryLogSlowThreshold,
+			Desc:    "the total duration from which queries are written to the query log. Failed queries are always logged. If this is unset, then every query is logged",
+		},
 		{
 			DestP: &o.FeatureFlags,
 			Flag:  "feature-flags",
@@ -423,6 +481,18 @@ func (o *InfluxdOpts) bindCliOpts() []cli.Opt {
 			Desc:  "The rate limit in bytes per second that we will allow TSM compactions to write to disk.",
 		},
 		// limits
+		{
+			DestP:   &o.StorageConfig.Data.MaxSeriesPerBucket,
+			Flag:    "storage-max-series-per-bucket",
+			Default: o.StorageConfig.Data.MaxSeriesPerBucket,
+			Desc:    "The maximum number of series a bucket can have. Points that would create series past it are dropped from writes. A value of 0 disables the limit.",
+		},
+		{
+			DestP:   &o.StorageConfig.Data.MaxValuesPerTag,
+			Flag:    "storage-max-values-per-tag",
+			Default: o.StorageConfig.Data.MaxValuesPerTag,
+			Desc:    "The maximum number of values a tag can have within a measurement of a shard. Points that would add values past it are dropped from writes. A value of 0 disables the limit.",
+		},
 		{
 			DestP: &o.StorageConfig.Data.MaxConcurrentCompactions,
 			Flag:  "storage-max-concurrent-compactions",
@@ -494,5 +564,26 @@ func (o *InfluxdOpts) bindCliOpts() []cli.Opt {
 			Desc:    "The maximum number of bytes allowed in a NATS message payload.",
 			Default: o.NatsMaxPayloadBytes,
 		},
+		{
+			DestP: &o.ScraperFileSDFiles,
+			Flag:  "scraper-file-sd-files",
+			Desc:  "files in the Prometheus file_sd format (JSON or YAML) to discover scraper targets from; the last path element may be a glob",
+		},
+		{
+			DestP: &o.ScraperFileSDOrgID,
+			Flag:  "scraper-file-sd-org-id",
+			Desc:  "ID of the organization the metrics of discovered scraper targets are written to",
+		},
+		{
+			DestP: &o.ScraperFileSDBucketID,
+			Flag:  "scraper-file-sd-bucket-id",
+			Desc:  "ID of the bucket the metrics of discovered scraper targets are written to",
+		},
+		{
+			DestP:   &o.ScraperFileSDRefreshInterval,
+			Flag:    "scraper-file-sd-refresh-interval",
+			Default: o.ScraperFileSDRefreshInterval,
+			Desc:    "time between two checks of the scraper discovery files for changes",
+		},
 	}
 }
@@ -31,6 +31,9 @@ type Engine interface {
 
 	SeriesCardinality(orgID, bucketID influxdb.ID) int64
 
+	// Statistics returns the internal statistics of the engine.
+	Statistics(tags map[string]string) []models.Statistic
+
 	TSDBStore() storage.TSDBStore
 	MetaClient() storage.MetaClient
 
@@ -118,6 +121,11 @@ func (t *TemporaryEngine) SeriesCardinality(orgID, bucketID influxdb.ID) int64 {
 	return t.engine.SeriesCardinality(orgID, bucketID)
 }
 
+// Statistics returns the internal statistics of the storage engine.
+func (t *TemporaryEngine) Statistics(tags map[string]string) []models.Statistic {
+	return t.engine.Statistics(tags)
+}
+
 // DeleteBucketRangePredicate will delete a bucket from the range and predicate.
 func (t *TemporaryEngine) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
 	return t.engine.DeleteBucketRangePredicate(ctx, orgID, bucketID, min, max, pred)
@@ -50,6 +50,7 @@ import (
 	"github.com/influxdata/influxdb/v2/query"
 	"github.com/influxdata/influxdb/v2/query/control"
 	"github.com/influxdata/influxdb/v2/query/fluxlang"
+	"github.com/influxdata/influxdb/v2/query/querylog"
 	"github.com/influxdata/influxdb/v2/query/stdlib/influxdata/influxdb"
 	"github.com/influxdata/influxdb/v2/secret"
 	"github.com/influxdata/influxdb/v2/session"
@@ -59,6 +60,7 @@ import (
 	storageflux "github.com/influxdata/influxdb/v2/storage/flux"
 	"github.com/influxdata/influxdb/v2/storage/readservice"
 	taskbackend "github.com/influxdata/influxdb/v2/task/backend"
+	"github.com/influxdata/influxdb/v2/task/backend/backfill"
 	"github.com/influxdata/influxdb/v2/task/backend/coordinator"
 	"github.com/influxdata/influxdb/v2/task/backend/executor"
 	"github.com/influxdata/influxdb/v2/task/backend/middleware"
@@ -70,6 +72,7 @@ import (
 	_ "github.com/influxdata/influxdb/v2/tsdb/index/tsi1"  // needed for tsi1
 	authv1 "github.com/influxdata/influxdb/v2/v1/authorization"
 	iqlcoordinator "github.com/influxdata/influxdb/v2/v1/coordinator"
+	"github.com/influxdata/influxdb/v2/v1/monitor"
 	"github.com/influxdata/influxdb/v2/v1/services/meta"
 	storage2 "github.com/influxdata/influxdb/v2/v1/services/storage"
 	"github.com/influxdata/influxdb/v2/vault"
@@ -109,6 +112,9 @@ type Launcher struct {
 	// InfluxQL query engine
 	queryController *control.Controller
 
+	// internal statistics and diagnostics for SHOW STATS and SHOW DIAGNOSTICS
+	monitor *monitor.Monitor
+
 	httpPort   int
 	httpServer *nethttp.Server
 
@@ -118,6 +124,7 @@ type Launcher struct {
 	scheduler          stoppingScheduler
 	executor           *executor.Executor
 	taskControlService taskbackend.TaskControlService
+	backfillService    *backfill.Service
 
 	jaegerTracerCloser io.Closer
 	log                *zap.Logger
@@ -171,6 +178,7 @@ func (m *Launcher) Shutdown(ctx context.Context) error {
 	m.log.Info("Stopping", zap.String("service", "task"))
 
 	m.scheduler.Stop()
+	m.backfillService.Close()
 
 	m.log.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
@@ -187,6 +195,12 @@ func (m *Launcher) Shutdown(ctx context.Context) error {
 		errs = append(errs, err.Error())
 	}
 
+	m.log.Info("Stopping", zap.String("service", "monitor"))
+	if err := m.monitor.Close(); err != nil {
+		m.log.Error("Failed closing monitor", zap.Error(err))
+		errs = append(errs, err.Error())
+	}
+
 	m.log.Info("Stopping", zap.String("service", "storage-engine"))
 	if err := m.engine.Close(); err != nil {
 		m.log.Error("Failed to close engine", zap.Error(err))
@@ -364,6 +378,8 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		return err
 	}
 
+	scraperTargetSvc = gather.NewTargetService(scraperTargetSvc, secretSvc)
+
 	chronografSvc, err := server.NewServiceV2(ctx, m.boltClient.DB())
 	if err != nil {
 		m.log.Error("Failed creating chronograf service", zap.Error(err))
@@ -411,8 +427,9 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		restoreService platform.RestoreService = m.engine
 	)
 
+	readStore := storage2.NewStore(m.engine.TSDBStore(), m.engine.MetaClient())
 	deps, err := influxdb.NewDependencies(
-		storageflux.NewReader(storage2.NewStore(m.engine.TSDBStore(), m.engine.MetaClient())),
+		storageflux.NewReader(readStore),
 		m.engine,
 		authorizer.NewBucketService(ts.BucketService),
 		authorizer.NewOrgService(ts.OrganizationService),
@@ -435,6 +452,10 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		MemoryBytesQuotaPerQuery:        opts.MemoryBytesQuotaPerQuery,
 		MaxMemoryBytes:                  opts.MaxMemoryBytes,
 		QueueSize:                       opts.QueueSize,
+		TaskConcurrencyQuota:            opts.TaskConcurrencyQuota,
+		BackgroundConcurrencyQuota:      opts.BackgroundConcurrencyQuota,
+		OrgConcurrencyQuota:             opts.OrgConcurrencyQuota,
+		OrgMemoryBytesQuota:             opts.OrgMemoryBytesQuota,
 		Logger:                          m.log.With(zap.String("service", "storage-reads")),
 		ExecutorDependencies:            dependencyList,
 	})
@@ -446,6 +467,18 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 	m.reg.MustRegister(m.queryController.PrometheusCollectors()...)
 
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
+	if opts.QueryLogEnabled {
+		storageQueryService = query.NewLoggingProxyQueryService(
+			m.log.With(zap.String("service", "query-log")),
+			&querylog.BucketLogger{
+				PointsWriter:       pointsWriter,
+				BucketFinder:       ts.BucketService,
+				BucketName:         platform.MonitoringSystemBucketName,
+				SlowQueryThreshold: opts.QueryLogSlowThreshold,
+			},
+			storageQueryService,
+		)
+	}
 	var taskSvc platform.TaskService
 	{
 		// create the task stack
@@ -465,6 +498,7 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 			combinedTaskService,
 			combinedTaskService,
 			executor.WithFlagger(m.flagger),
+			executor.WithFluxLanguageService(fluxlang.DefaultService),
 		)
 		m.executor = executor
 		m.reg.MustRegister(executorMetrics.PrometheusCollectors()...)
@@ -500,9 +534,15 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 			coordLogger,
 			sch,
 			executor)
+		// run the tasks downstream of a task once its runs succeed
+		executor.SetRunFinishedFunc(taskCoord.RunFinished)
 
 		taskSvc = middleware.New(combinedTaskService, taskCoord)
 		m.taskControlService = combinedTaskService
+		m.backfillService = backfill.NewService(
+			m.log.With(zap.String("service", "task-backfill")),
+			taskSvc,
+			fluxlang.DefaultService)
 		if err := taskbackend.TaskNotifyCoordinatorOfExisting(
 			ctx,
 			taskSvc,
@@ -519,6 +559,9 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 
 	dbrpSvc := dbrp.NewAuthorizedService(dbrp.NewService(ctx, authorizer.NewBucketService(ts.BucketService), m.kvStore))
 
+	ts.BucketService = storage.NewBucketService(m.log, ts.BucketService, m.engine)
+	ts.BucketService = dbrp.NewBucketService(m.log, ts.BucketService, dbrpSvc)
+
 	cm := iqlcontrol.NewControllerMetrics([]string{})
 	m.reg.MustRegister(cm.PrometheusCollectors()...)
 
@@ -533,12 +576,27 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		zap.Int("max_select_series", opts.CoordinatorConfig.MaxSelectSeriesN),
 		zap.Int("max_select_buckets", opts.CoordinatorConfig.MaxSelectBucketsN))
 
+	m.monitor = monitor.New(m.engine, monitor.Config{})
+	m.monitor.Version = info.Version
+	m.monitor.Commit = info.Commit
+	m.monitor.BuildTime = info.Date
+	m.monitor.WithLogger(m.log)
+	m.monitor.RegisterDiagnosticsClient("coordinator", opts.CoordinatorConfig)
+	if err := m.monitor.Open(); err != nil {
+		m.log.Error("Failed to open monitor", zap.Error(err))
+		return err
+	}
+
 	qe := iqlquery.NewExecutor(m.log, cm)
 	se := &iqlcoordinator.StatementExecutor{
 		MetaClient:        metaClient,
 		TSDBStore:         m.engine.TSDBStore(),
 		ShardMapper:       mapper,
 		DBRP:              dbrpSvc,
+		QueryTracker:      qe.Tracker,
+		Monitor:           m.monitor,
+		BucketService:     authorizer.NewBucketService(ts.BucketService),
+		TaskService:       authorizer.NewTaskService(m.log.With(zap.String("service", "influxql-continuous-queries")), taskSvc),
 		MaxSelectPointN:   opts.CoordinatorConfig.MaxSelectPointN,
 		MaxSelectSeriesN:  opts.CoordinatorConfig.MaxSelectSeriesN,
 		MaxSelectBucketsN: opts.CoordinatorConfig.MaxSelectBucketsN,
@@ -601,12 +659,34 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 	}
 
 	subscriber.Subscribe(gather.MetricsSubject, "metrics", gather.NewRecorderHandler(m.log, gather.PointWriter{Writer: pointsWriter}))
-	scraperScheduler, err := gather.NewScheduler(m.log, 10, scraperTargetSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
+	scraperScheduler, err := gather.NewScheduler(m.log, 10, scraperTargetSvc, secretSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
 	if err != nil {
 		m.log.Error("Failed to create scraper subscriber", zap.Error(err))
 		return err
 	}
 
+	if len(opts.ScraperFileSDFiles) > 0 {
+		if !opts.ScraperFileSDOrgID.Valid() || !opts.ScraperFileSDBucketID.Valid() {
+			err := errors.New("scraper file discovery requires a valid org ID and bucket ID")
+			m.log.Error("Failed to configure scraper file discovery", zap.Error(err))
+			return err
+		}
+		discovery := gather.NewFileDiscovery(
+			m.log.With(zap.String("service", "scraper-file-sd")),
+			opts.ScraperFileSDFiles,
+			opts.ScraperFileSDOrgID,
+			opts.ScraperFileSDBucketID,
+			opts.ScraperFileSDRefreshInterval,
+		)
+		scraperScheduler.Discoverers = append(scraperScheduler.Discoverers, discovery)
+
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			discovery.Run(ctx)
+		}()
+	}
+
 	m.wg.Add(1)
 	go func(log *zap.Logger) {
 		defer m.wg.Done()
@@ -658,9 +738,6 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		labelSvc = label.NewService(labelsStore)
 	}
 
-	ts.BucketService = storage.NewBucketService(m.log, ts.BucketService, m.engine)
-	ts.BucketService = dbrp.NewBucketService(m.log, ts.BucketService, dbrpSvc)
-
 	onboardingLogger := m.log.With(zap.String("handler", "onboard"))
 	onboardOpts := []tenant.OnboardServiceOptionFn{tenant.WithOnboardingLogger(onboardingLogger)}
 	if opts.TestingAlwaysAllowSetup {
@@ -725,17 +802,19 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 	}
 
 	m.apibackend = &http.APIBackend{
-		AssetsPath:           opts.AssetsPath,
-		HTTPErrorHandler:     kithttp.ErrorHandler(0),
-		Logger:               m.log,
-		SessionRenewDisabled: opts.SessionRenewDisabled,
-		NewBucketService:     source.NewBucketService,
-		NewQueryService:      source.NewQueryService,
+		AssetsPath:            opts.AssetsPath,
+		HTTPErrorHandler:      kithttp.ErrorHandler(0),
+		Logger:                m.log,
+		SessionRenewDisabled:  opts.SessionRenewDisabled,
+		WriteStreamBatchBytes: opts.HttpWriteStreamBatchBytes,
+		NewBucketService:      source.NewBucketService,
+		NewQueryService:       source.NewQueryService,
 		PointsWriter: &storage.LoggingPointsWriter{
 			Underlying:    pointsWriter,
 			BucketFinder:  ts.BucketService,
 			LogBucketName: platform.MonitoringSystemBucketName,
 		},
+		ReadStore:            readStore,
 		DeleteService:        deleteService,
 		BackupService:        backupService,
 		RestoreService:       restoreService,
@@ -761,9 +840,11 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		PasswordsService:                ts.PasswordsService,
 		InfluxQLService:                 storageQueryService,
 		InfluxqldService:                iqlquery.NewProxyExecutor(m.log, qe),
+		InfluxQLQueryTracker:            qe.Tracker,
 		FluxService:                     storageQueryService,
 		FluxLanguageService:             fluxlang.DefaultService,
 		TaskService:                     taskSvc,
+		BackfillService:                 m.backfillService,
 		TelegrafService:                 telegrafSvc,
 		NotificationRuleStore:           notificationRuleSvc,
 		NotificationEndpointService:     notificationEndpointSvc,
@@ -836,6 +917,7 @@ func (m *Launcher) run(ctx context.Context, opts *InfluxdOpts) (err error) {
 		labelSvc = label.NewLabelMetrics(m.reg, labelSvc)
 		labelHandler = label.NewHTTPLabelHandler(m.log, labelSvc)
 	}
+	se.LabelService = labelSvc
 
 	// feature flagging for new authorization service
 	var authHTTPServer *authorization.AuthHandler
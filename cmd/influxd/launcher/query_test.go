@@ -2170,7 +2170,6 @@ from(bucket: v.bucket)
 ,result,table,_time,_value
 ,,0,1970-01-01T00:00:00.00Z,0
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "group none first",
@@ -2207,7 +2206,6 @@ from(bucket: v.bucket)
 ,result,table,_time,_value
 ,,0,1970-01-01T00:00:00.00Z,0
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "group last",
@@ -2244,7 +2242,6 @@ from(bucket: v.bucket)
 ,result,table,_time,_value
 ,,0,1970-01-01T00:00:15.00Z,5
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "group none last",
@@ -2281,7 +2278,6 @@ from(bucket: v.bucket)
 ,result,table,_time,_value
 ,,0,1970-01-01T00:00:15.00Z,5
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "count group none",
@@ -2318,7 +2314,6 @@ from(bucket: v.bucket)
 ,result,table,_value
 ,,0,15
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "count group",
@@ -2356,7 +2351,6 @@ from(bucket: v.bucket)
 ,,0,kk0,8
 ,,1,kk1,7
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "sum group none",
@@ -2393,7 +2387,6 @@ from(bucket: v.bucket)
 ,result,table,_value
 ,,0,67
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "sum group",
@@ -2431,7 +2424,6 @@ from(bucket: v.bucket)
 ,,0,kk0,32
 ,,1,kk1,35
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "min group",
@@ -2469,7 +2461,6 @@ from(bucket: v.bucket)
 ,,0,kk0,0
 ,,1,kk1,1
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 		{
 			name: "max group",
@@ -2507,7 +2498,6 @@ from(bucket: v.bucket)
 ,,0,kk0,9
 ,,1,kk1,8
 `,
-			skip: "https://github.com/influxdata/idpe/issues/8828",
 		},
 	}
 	for _, tc := range testcases {
@@ -2546,6 +2536,97 @@ from(bucket: v.bucket)
 	}
 }
 
+// TestQueryPushDowns_GroupAggregate verifies that group aggregates pushed down
+// to storage produce the same results as the plan evaluated by flux.
+func TestQueryPushDowns_GroupAggregate(t *testing.T) {
+	// Series are interleaved so that the earliest and latest points, and the
+	// minimum and maximum values, do not come from the first or last series
+	// in storage order. Selectors must report the tags of the series the
+	// selected point came from.
+	data := []string{
+		"m0,k=k0,kk=kk0 f=4i,g=1.5 2000000000",
+		"m0,k=k0,kk=kk1 f=7i,g=-2.5 1000000000",
+		"m0,k=k0,kk=kk2 f=1i,g=9.25 3000000000",
+		"m0,k=k1,kk=kk0 f=5i,g=0.5 4000000000",
+		"m0,k=k1,kk=kk1 f=2i,g=3.75 5000000000",
+		"m0,k=k0,kk=kk0 f=6i,g=4.0 6000000000",
+		"m0,k=k0,kk=kk1 f=-3i,g=2.0 7000000000",
+		"m0,k=k1,kk=kk0 f=8i,g=-1.0 8000000000",
+		"m0,k=k1,kk=kk1 f=0i,g=6.5 9000000000",
+		"m0,k=k0,kk=kk2 f=9i,g=1.25 500000000",
+	}
+
+	l := launcher.RunAndSetupNewLauncherOrFail(ctx, t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	l.WritePointsOrFail(t, strings.Join(data, "\n"))
+
+	// execute runs the query and reads its results before returning, so that
+	// queries do not hold on to the controller while the next one runs.
+	execute := func(t *testing.T, query string) flux.ResultIterator {
+		t.Helper()
+		res := l.MustExecuteQuery(query)
+		defer res.Done()
+		results := make([]flux.Result, 0, len(res.Results))
+		for _, r := range res.Results {
+			result := executetest.ConvertResult(r)
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+			results = append(results, result)
+		}
+		return flux.NewSliceResultIterator(results)
+	}
+
+	for _, field := range []string{"f", "g"} {
+		aggs := []string{"count", "sum", "min", "max", "first", "last"}
+		if field == "g" {
+			// count always produces integers, so the float field adds
+			// nothing over the integer one.
+			aggs = aggs[1:]
+		}
+		for _, group := range []string{`group()`, `group(columns: ["k"])`, `group(columns: ["kk"])`} {
+			for _, agg := range aggs {
+				field, group, agg := field, group, agg
+				t.Run(fmt.Sprintf("%s %s %s", field, group, agg), func(t *testing.T) {
+					base := `
+v = {bucket: "` + l.Bucket.Name + `"}
+from(bucket: v.bucket)
+	|> range(start: 0)
+	|> filter(fn: (r) => r._measurement == "m0" and r._field == "` + field + `")
+	|> ` + group + `
+`
+					// The pushed down first and last select by time, whereas
+					// flux selects by row order, so the evaluated plan sorts
+					// the table first.
+					eval := base
+					if agg == "first" || agg == "last" {
+						eval += "\t|> sort(columns: [\"_time\"])\n"
+					}
+					eval = "import \"planner\"\noption planner.disablePhysicalRules = [\"PushDownGroupAggregateRule\"]\n" +
+						eval + "\t|> " + agg + "()\n"
+
+					op := "readGroup(" + agg + ")"
+					reads := l.NumReads(t, op)
+					got := execute(t, base+"\t|> "+agg+"()\n")
+					if want, got := reads+1, l.NumReads(t, op); want != got {
+						t.Fatalf("unexpected %s count -want/+got:\n\t- %d\n\t+ %d", op, want, got)
+					}
+
+					want := execute(t, eval)
+					if want, got := reads+1, l.NumReads(t, op); want != got {
+						t.Fatalf("evaluated plan pushed down the aggregate: %s count -want/+got:\n\t- %d\n\t+ %d", op, want, got)
+					}
+
+					if err := executetest.EqualResultIterators(want, got); err != nil {
+						t.Fatal(err)
+					}
+				})
+			}
+		}
+	}
+}
+
 func TestLauncher_Query_Buckets_MultiplePages(t *testing.T) {
 	l := launcher.RunAndSetupNewLauncherOrFail(ctx, t)
 	defer l.ShutdownOrFail(t, ctx)
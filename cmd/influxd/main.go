@@ -6,6 +6,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"time"
+	_ "time/tzdata" // task time zones, on hosts without a zoneinfo database
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/cmd/influxd/inspect"
@@ -27,6 +27,8 @@ var configMapRules = map[string]string{
 	"data.cache-snapshot-write-cold-duration":              "storage-cache-snapshot-write-cold-duration",
 	"data.compact-full-write-cold-duration":                "storage-compact-full-write-cold-duration",
 	"data.compact-throughput-burst":                        "storage-compact-throughput-burst",
+	"data.max-series-per-database":                         "storage-max-series-per-bucket",
+	"data.max-values-per-tag":                              "storage-max-values-per-tag",
 	"data.max-concurrent-compactions":                      "storage-max-concurrent-compactions",
 	"data.max-index-log-file-size":                         "storage-max-index-log-file-size",
 	"data.series-id-set-cache-size":                        "storage-series-id-set-cache-size",
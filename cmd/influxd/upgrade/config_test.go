@@ -222,6 +222,8 @@ bind-address = "127.0.0.1:8088"
   compact-full-write-cold-duration = "4h0m0s"
   compact-throughput = 50331648
   compact-throughput-burst = 50331648
+  max-series-per-database = 1000000
+  max-values-per-tag = 100000
   max-concurrent-compactions = 0
   max-index-log-file-size = 1048576
   series-id-set-cache-size = 100
@@ -428,6 +430,8 @@ storage-compact-full-write-cold-duration = "4h0m0s"
 storage-compact-throughput-burst = 50331648
 storage-max-concurrent-compactions = 0
 storage-max-index-log-file-size = 1048576
+storage-max-series-per-bucket = 1000000
+storage-max-values-per-tag = 100000
 storage-retention-check-interval = "30m0s"
 storage-series-file-max-concurrent-snapshot-compactions = 0
 storage-series-id-set-cache-size = 100
@@ -0,0 +1,239 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap"
+)
+
+// Discoverer provides scraper targets which are not kept in the
+// scraper target store.
+type Discoverer interface {
+	Targets() []influxdb.ScraperTarget
+}
+
+// Labels of a file_sd target group that configure the scrape URL.
+// Other labels starting with "__" are dropped.
+const (
+	schemeLabel      = "__scheme__"
+	metricsPathLabel = "__metrics_path__"
+	instanceLabel    = "instance"
+)
+
+// fileSDGroup is a target group of a Prometheus file_sd file.
+type fileSDGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+type fileSDState struct {
+	modTime time.Time
+	size    int64
+	targets []influxdb.ScraperTarget
+}
+
+// FileDiscovery discovers scraper targets from files in the Prometheus
+// file_sd format. The files are polled, and reloaded when they change.
+type FileDiscovery struct {
+	// Patterns are the paths of the files to watch. The last element
+	// of a path may be a glob pattern, such as "targets/*.json".
+	Patterns []string
+	// OrgID and BucketID are where the metrics of the discovered
+	// targets are written to.
+	OrgID    influxdb.ID
+	BucketID influxdb.ID
+	// RefreshInterval is the time between two checks of the files.
+	RefreshInterval time.Duration
+
+	log *zap.Logger
+
+	mu    sync.RWMutex
+	files map[string]fileSDState
+}
+
+// NewFileDiscovery creates a new FileDiscovery.
+func NewFileDiscovery(log *zap.Logger, patterns []string, orgID, bucketID influxdb.ID, refresh time.Duration) *FileDiscovery {
+	if refresh == 0 {
+		refresh = 10 * time.Second
+	}
+	return &FileDiscovery{
+		Patterns:        patterns,
+		OrgID:           orgID,
+		BucketID:        bucketID,
+		RefreshInterval: refresh,
+		log:             log,
+		files:           make(map[string]fileSDState),
+	}
+}
+
+var _ Discoverer = (*FileDiscovery)(nil)
+
+// Run loads the files, and reloads them on change until ctx is done.
+func (d *FileDiscovery) Run(ctx context.Context) {
+	d.Refresh()
+
+	ticker := time.NewTicker(d.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Refresh()
+		}
+	}
+}
+
+// Targets returns the currently discovered targets.
+func (d *FileDiscovery) Targets() []influxdb.ScraperTarget {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	paths := make([]string, 0, len(d.files))
+	for path := range d.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var targets []influxdb.ScraperTarget
+	for _, path := range paths {
+		targets = append(targets, d.files[path].targets...)
+	}
+	return targets
+}
+
+// Refresh reloads the files that were added or changed, and forgets the
+// files that were removed. A file that fails to load keeps the targets
+// of its last successful load.
+func (d *FileDiscovery) Refresh() {
+	paths := make(map[string]bool)
+	for _, pattern := range d.Patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			d.log.Error("Invalid file discovery pattern", zap.String("pattern", pattern), zap.Error(err))
+			continue
+		}
+		for _, path := range matches {
+			paths[path] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for path := range d.files {
+		if !paths[path] {
+			d.log.Info("Removing scraper targets of deleted file", zap.String("path", path))
+			delete(d.files, path)
+		}
+	}
+
+	for path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			d.log.Error("Unable to stat file discovery file", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		state, ok := d.files[path]
+		if ok && state.modTime.Equal(fi.ModTime()) && state.size == fi.Size() {
+			continue
+		}
+
+		targets, err := d.load(path)
+		if err != nil {
+			d.log.Error("Unable to load file discovery file", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		d.files[path] = fileSDState{
+			modTime: fi.ModTime(),
+			size:    fi.Size(),
+			targets: targets,
+		}
+		d.log.Info("Loaded scraper targets", zap.String("path", path), zap.Int("targets", len(targets)))
+	}
+}
+
+func (d *FileDiscovery) load(path string) ([]influxdb.ScraperTarget, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []fileSDGroup
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		err = json.Unmarshal(b, &groups)
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(b, &groups)
+	default:
+		return nil, fmt.Errorf("unsupported file extension %q", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []influxdb.ScraperTarget
+	for _, g := range groups {
+		for _, addr := range g.Targets {
+			targets = append(targets, d.newTarget(path, addr, g.Labels))
+		}
+	}
+	return targets, nil
+}
+
+// newTarget creates the scraper target of an address of a target group.
+// The ID of the target is derived from the file and the scrape URL, so it
+// stays the same across reloads.
+func (d *FileDiscovery) newTarget(path, addr string, groupLabels map[string]string) influxdb.ScraperTarget {
+	scheme, metricsPath := "http", "/metrics"
+	labels := map[string]string{instanceLabel: addr}
+	for k, v := range groupLabels {
+		switch {
+		case k == schemeLabel:
+			scheme = v
+		case k == metricsPathLabel:
+			metricsPath = v
+		case strings.HasPrefix(k, "__"):
+		default:
+			labels[k] = v
+		}
+	}
+	if !strings.HasPrefix(metricsPath, "/") {
+		metricsPath = "/" + metricsPath
+	}
+
+	url := scheme + "://" + addr + metricsPath
+	return influxdb.ScraperTarget{
+		ID:       discoveredTargetID(path, url),
+		Name:     addr,
+		Type:     influxdb.PrometheusScraperType,
+		URL:      url,
+		OrgID:    d.OrgID,
+		BucketID: d.BucketID,
+		Labels:   labels,
+	}
+}
+
+func discoveredTargetID(path, url string) influxdb.ID {
+	h := fnv.New64a()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(url))
+	id := influxdb.ID(h.Sum64())
+	if !id.Valid() {
+		id = 1
+	}
+	return id
+}
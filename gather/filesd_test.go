@@ -0,0 +1,97 @@
+package gather
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFileDiscovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filesd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	jsonPath := filepath.Join(dir, "a.json")
+	yamlPath := filepath.Join(dir, "b.yml")
+	writeFile(t, jsonPath, `[
+  {
+    "targets": ["10.0.0.1:9100", "10.0.0.2:9100"],
+    "labels": {"env": "prod", "__scheme__": "https", "__meta_ignored": "x"}
+  }
+]`)
+	writeFile(t, yamlPath, `
+- targets: ["10.0.0.3:8080"]
+  labels:
+    __metrics_path__: /probe
+    job: api
+`)
+
+	d := NewFileDiscovery(zaptest.NewLogger(t), []string{filepath.Join(dir, "*")}, *orgID, *bucketID, time.Second)
+	d.Refresh()
+
+	urls := func() map[string]map[string]string {
+		m := make(map[string]map[string]string)
+		for _, target := range d.Targets() {
+			if target.OrgID != *orgID || target.BucketID != *bucketID {
+				t.Fatalf("unexpected org/bucket of target %s", target.URL)
+			}
+			if target.Type != influxdb.PrometheusScraperType {
+				t.Fatalf("unexpected type of target %s: %s", target.URL, target.Type)
+			}
+			m[target.URL] = target.Labels
+		}
+		return m
+	}
+
+	want := map[string]map[string]string{
+		"https://10.0.0.1:9100/metrics": {"env": "prod", "instance": "10.0.0.1:9100"},
+		"https://10.0.0.2:9100/metrics": {"env": "prod", "instance": "10.0.0.2:9100"},
+		"http://10.0.0.3:8080/probe":    {"job": "api", "instance": "10.0.0.3:8080"},
+	}
+	if diff := cmp.Diff(want, urls()); diff != "" {
+		t.Fatalf("unexpected targets -want/+got:\n%s", diff)
+	}
+
+	ids := make(map[influxdb.ID]bool)
+	for _, target := range d.Targets() {
+		ids[target.ID] = true
+	}
+	if len(ids) != 3 {
+		t.Fatalf("expected unique target IDs, got %v", ids)
+	}
+
+	// A broken file keeps its previous targets.
+	writeFile(t, jsonPath, `[{"targets": [`)
+	d.Refresh()
+	if diff := cmp.Diff(want, urls()); diff != "" {
+		t.Fatalf("unexpected targets after bad reload -want/+got:\n%s", diff)
+	}
+
+	writeFile(t, jsonPath, `[{"targets": ["10.0.0.1:9100"]}]`)
+	if err := os.Remove(yamlPath); err != nil {
+		t.Fatal(err)
+	}
+	d.Refresh()
+
+	want = map[string]map[string]string{
+		"http://10.0.0.1:9100/metrics": {"instance": "10.0.0.1:9100"},
+	}
+	if diff := cmp.Diff(want, urls()); diff != "" {
+		t.Fatalf("unexpected targets after reload -want/+got:\n%s", diff)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 type handler struct {
 	Scraper   Scraper
 	Publisher nats.Publisher
+	Secrets   influxdb.SecretService
 	log       *zap.Logger
 }
 
@@ -29,7 +30,19 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	ctx := context.TODO()
+	if req.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout.Duration)
+		defer cancel()
+	}
+
+	if err := loadSecrets(ctx, h.Secrets, req); err != nil {
+		h.log.Error("Unable to load scraper target secrets", zap.Error(err))
+		return
+	}
+
+	ms, err := h.Scraper.Gather(ctx, *req)
 	if err != nil {
 		h.log.Error("Unable to gather", zap.Error(err))
 		return
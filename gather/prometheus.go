@@ -33,16 +33,17 @@ func newPrometheusScraper() *prometheusScraper {
 
 // Gather parse metrics from a scraper target url.
 func (p *prometheusScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
-	var (
-		resp *http.Response
-	)
+	req, err := newScrapeRequest(ctx, target)
+	if err != nil {
+		return collected, err
+	}
 
+	client := http.DefaultClient
 	if target.AllowInsecure {
-		resp, err = p.insecureHttp.Get(target.URL)
-	} else {
-		resp, err = http.Get(target.URL)
+		client = p.insecureHttp
 	}
 
+	resp, err := client.Do(req)
 	if err != nil {
 		return collected, err
 	}
@@ -51,6 +52,37 @@ func (p *prometheusScraper) Gather(ctx context.Context, target influxdb.ScraperT
 	return p.parse(resp.Body, resp.Header, target)
 }
 
+// newScrapeRequest creates the request to scrape the target url,
+// with the headers and credentials of the target.
+func newScrapeRequest(ctx context.Context, target influxdb.ScraperTarget) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range target.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if target.Auth == nil {
+		return req, nil
+	}
+	switch target.Auth.Method {
+	case influxdb.ScraperAuthBasic:
+		if target.Auth.Username.Value == nil || target.Auth.Password.Value == nil {
+			return nil, fmt.Errorf("missing username/password for basic auth")
+		}
+		req.SetBasicAuth(*target.Auth.Username.Value, *target.Auth.Password.Value)
+	case influxdb.ScraperAuthBearer:
+		if target.Auth.Token.Value == nil {
+			return nil, fmt.Errorf("missing token for bearer auth")
+		}
+		req.Header.Set("Authorization", "Bearer "+*target.Auth.Token.Value)
+	default:
+		return nil, fmt.Errorf("unsupported auth method: %s", target.Auth.Method)
+	}
+	return req, nil
+}
+
 func (p *prometheusScraper) parse(r io.Reader, header http.Header, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
 	var parser expfmt.TextParser
 	now := time.Now()
@@ -84,9 +116,15 @@ func (p *prometheusScraper) parse(r io.Reader, header http.Header, target influx
 
 	// read metrics
 	for name, family := range metricFamilies {
+		if !target.MatchMetric(name) {
+			continue
+		}
 		for _, m := range family.Metric {
 			// reading tags
 			tags := makeLabels(m)
+			for k, v := range target.Labels {
+				tags[k] = v
+			}
 			// reading fields
 			var fields map[string]interface{}
 			switch family.GetType() {
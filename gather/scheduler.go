@@ -19,12 +19,18 @@ const (
 	promTargetSubject = "promTarget"
 )
 
+// resolution is the longest time between two checks for targets due to be
+// scraped.
+const resolution = time.Second
+
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets influxdb.ScraperTargetStoreService
-	// Interval is between each metrics gathering event.
+	// Discoverers provide targets in addition to the ones in Targets.
+	Discoverers []Discoverer
+	// Interval is the default time between two scrapes of a target.
 	Interval time.Duration
-	// Timeout is the maximum time duration allowed by each TCP request
+	// Timeout is the maximum time duration allowed to list the targets.
 	Timeout time.Duration
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
@@ -33,6 +39,9 @@ type Scheduler struct {
 	log *zap.Logger
 
 	gather chan struct{}
+
+	// lastGather is the time each target was last requested to be scraped.
+	lastGather map[influxdb.ID]time.Time
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -40,6 +49,7 @@ func NewScheduler(
 	log *zap.Logger,
 	numScrapers int,
 	targets influxdb.ScraperTargetStoreService,
+	secrets influxdb.SecretService,
 	p nats.Publisher,
 	s nats.Subscriber,
 	interval time.Duration,
@@ -52,18 +62,20 @@ func NewScheduler(
 		timeout = 30 * time.Second
 	}
 	scheduler := &Scheduler{
-		Targets:   targets,
-		Interval:  interval,
-		Timeout:   timeout,
-		Publisher: p,
-		log:       log,
-		gather:    make(chan struct{}, 100),
+		Targets:    targets,
+		Interval:   interval,
+		Timeout:    timeout,
+		Publisher:  p,
+		log:        log,
+		gather:     make(chan struct{}, 100),
+		lastGather: make(map[influxdb.ID]time.Time),
 	}
 
 	for i := 0; i < numScrapers; i++ {
 		err := s.Subscribe(promTargetSubject, "metrics", &handler{
 			Scraper:   newPrometheusScraper(),
 			Publisher: p,
+			Secrets:   secrets,
 			log:       log,
 		})
 		if err != nil {
@@ -78,11 +90,17 @@ func NewScheduler(
 // and publish them to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
 	go func(s *Scheduler, ctx context.Context) {
+		tick := resolution
+		if s.Interval < tick {
+			tick = s.Interval
+		}
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-ticker.C:
 				s.gather <- struct{}{}
 			}
 		}
@@ -113,12 +131,41 @@ func (s *Scheduler) doGather(ctx context.Context) {
 		tracing.LogError(span, err)
 		return
 	}
+	for _, d := range s.Discoverers {
+		targets = append(targets, d.Targets()...)
+	}
+
+	now := time.Now()
+	lastGather := make(map[influxdb.ID]time.Time, len(targets))
 	for _, target := range targets {
+		last, ok := s.lastGather[target.ID]
+		if ok && !s.due(target, last, now) {
+			lastGather[target.ID] = last
+			continue
+		}
+		lastGather[target.ID] = now
 		if err := requestScrape(target, s.Publisher); err != nil {
 			s.log.Error("JSON encoding error", zap.Error(err))
 			tracing.LogError(span, err)
 		}
 	}
+	s.lastGather = lastGather
+}
+
+// due returns true if the target last scraped at last should be scraped
+// again at now. Targets without an interval use the scheduler's one.
+func (s *Scheduler) due(t influxdb.ScraperTarget, last, now time.Time) bool {
+	interval := s.Interval
+	if t.Interval != nil {
+		interval = t.Interval.Duration
+	}
+	// Allow for some jitter of the ticker, so a target isn't pushed back
+	// a whole tick when it is checked a little early.
+	tolerance := interval / 10
+	if tolerance > resolution/2 {
+		tolerance = resolution / 2
+	}
+	return now.Sub(last) >= interval-tolerance
 }
 
 func requestScrape(t influxdb.ScraperTarget, publisher nats.Publisher) error {
@@ -46,7 +46,7 @@ func TestScheduler(t *testing.T) {
 		Recorder: storage,
 	})
 
-	scheduler, err := NewScheduler(logger, 10, storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+	scheduler, err := NewScheduler(logger, 10, storage, nil, publisher, subscriber, time.Millisecond, time.Microsecond)
 
 	go func() {
 		err = scheduler.run(ctx)
@@ -90,6 +90,33 @@ func TestScheduler(t *testing.T) {
 	ts.Close()
 }
 
+func TestScheduler_Due(t *testing.T) {
+	s := &Scheduler{Interval: 10 * time.Second}
+	last := time.Unix(0, 0)
+
+	cases := []struct {
+		name     string
+		interval *influxdb.Duration
+		elapsed  time.Duration
+		want     bool
+	}{
+		{name: "default interval not elapsed", elapsed: 5 * time.Second, want: false},
+		{name: "default interval elapsed", elapsed: 10 * time.Second, want: true},
+		{name: "default interval within jitter", elapsed: 9800 * time.Millisecond, want: true},
+		{name: "target interval not elapsed", interval: &influxdb.Duration{Duration: time.Minute}, elapsed: 10 * time.Second, want: false},
+		{name: "target interval elapsed", interval: &influxdb.Duration{Duration: time.Minute}, elapsed: time.Minute, want: true},
+		{name: "short target interval", interval: &influxdb.Duration{Duration: 2 * time.Second}, elapsed: 2 * time.Second, want: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			target := influxdb.ScraperTarget{Interval: c.interval}
+			if got := s.due(target, last, last.Add(c.elapsed)); got != c.want {
+				t.Fatalf("due: want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
 const sampleRespSmall = `
 # HELP go_goroutines Number of goroutines that currently exist.
 # TYPE go_goroutines gauge
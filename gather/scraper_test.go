@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -155,6 +156,148 @@ func TestPrometheusScraper(t *testing.T) {
 	}
 }
 
+func TestPrometheusScraper_Request(t *testing.T) {
+	token, username, password := "tok", "user", "pass"
+	cases := []struct {
+		name   string
+		auth   *influxdb.ScraperAuth
+		check  func(r *http.Request) bool
+		hasErr bool
+	}{
+		{
+			name: "headers",
+			check: func(r *http.Request) bool {
+				return r.Header.Get("X-Scope") == "metrics"
+			},
+		},
+		{
+			name: "bearer auth",
+			auth: &influxdb.ScraperAuth{
+				Method: influxdb.ScraperAuthBearer,
+				Token:  influxdb.SecretField{Key: "k-token", Value: &token},
+			},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "Bearer tok"
+			},
+		},
+		{
+			name: "basic auth",
+			auth: &influxdb.ScraperAuth{
+				Method:   influxdb.ScraperAuthBasic,
+				Username: influxdb.SecretField{Key: "k-username", Value: &username},
+				Password: influxdb.SecretField{Key: "k-password", Value: &password},
+			},
+			check: func(r *http.Request) bool {
+				u, p, ok := r.BasicAuth()
+				return ok && u == username && p == password
+			},
+		},
+		{
+			name: "missing secret value",
+			auth: &influxdb.ScraperAuth{
+				Method: influxdb.ScraperAuthBearer,
+				Token:  influxdb.SecretField{Key: "k-token"},
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c.check != nil && !c.check(r) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+				w.Write([]byte(sampleRespSmall))
+			}))
+			defer ts.Close()
+
+			results, err := newPrometheusScraper().Gather(context.Background(), influxdb.ScraperTarget{
+				URL:      ts.URL + "/metrics",
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+				Headers:  map[string]string{"X-Scope": "metrics"},
+				Auth:     c.auth,
+			})
+			if c.hasErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(results.MetricsSlice); got != 1 {
+				t.Fatalf("scraper parse metrics incorrect length, want 1, got %d", got)
+			}
+		})
+	}
+}
+
+func TestPrometheusScraper_Labels(t *testing.T) {
+	ts := httptest.NewServer(&mockHTTPHandler{
+		responseMap: map[string]string{
+			"/metrics": sampleResp,
+		},
+	})
+	defer ts.Close()
+
+	results, err := newPrometheusScraper().Gather(context.Background(), influxdb.ScraperTarget{
+		URL:           ts.URL + "/metrics",
+		OrgID:         *orgID,
+		BucketID:      *bucketID,
+		Labels:        map[string]string{"instance": "host:9100"},
+		MetricInclude: []string{"go_info"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(results.MetricsSlice); got != 1 {
+		t.Fatalf("scraper parse metrics incorrect length, want 1, got %d", got)
+	}
+	want := map[string]string{"instance": "host:9100", "version": "go1.10.3"}
+	if diff := cmp.Diff(want, results.MetricsSlice[0].Tags); diff != "" {
+		t.Fatalf("unexpected tags -want/+got:\n%s", diff)
+	}
+}
+
+func TestPrometheusScraper_MetricFilters(t *testing.T) {
+	ts := httptest.NewServer(&mockHTTPHandler{
+		responseMap: map[string]string{
+			"/metrics": sampleResp,
+		},
+	})
+	defer ts.Close()
+
+	results, err := newPrometheusScraper().Gather(context.Background(), influxdb.ScraperTarget{
+		URL:           ts.URL + "/metrics",
+		OrgID:         *orgID,
+		BucketID:      *bucketID,
+		MetricInclude: []string{"go_memstats_*", "go_goroutines"},
+		MetricExclude: []string{"*_total"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make([]string, 0, len(results.MetricsSlice))
+	for _, m := range results.MetricsSlice {
+		got = append(got, m.Name)
+	}
+	sort.Strings(got)
+	want := []string{
+		"go_goroutines",
+		"go_memstats_alloc_bytes",
+		"go_memstats_buck_hash_sys_bytes",
+		"go_memstats_gc_cpu_fraction",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected metrics -want/+got:\n%s", diff)
+	}
+}
+
 const sampleResp = `
 # 	HELP go_gc_duration_seconds A summary of the GC invocation durations.
 # TYPE go_gc_duration_seconds summary
@@ -0,0 +1,98 @@
+package gather
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+// TargetService wraps a scraper target store and keeps the credentials of
+// the scraper targets in a secret service.
+type TargetService struct {
+	influxdb.ScraperTargetStoreService
+	secretSVC influxdb.SecretService
+}
+
+// NewTargetService constructs a new TargetService.
+func NewTargetService(store influxdb.ScraperTargetStoreService, secretSVC influxdb.SecretService) *TargetService {
+	return &TargetService{
+		ScraperTargetStoreService: store,
+		secretSVC:                 secretSVC,
+	}
+}
+
+var _ influxdb.ScraperTargetStoreService = (*TargetService)(nil)
+
+// AddTarget adds a new scraper target and stores its credentials.
+func (s *TargetService) AddTarget(ctx context.Context, t *influxdb.ScraperTarget, userID influxdb.ID) error {
+	if err := s.ScraperTargetStoreService.AddTarget(ctx, t, userID); err != nil {
+		return err
+	}
+	return s.putSecrets(ctx, t)
+}
+
+// UpdateTarget updates a scraper target and stores its new credentials.
+func (s *TargetService) UpdateTarget(ctx context.Context, update *influxdb.ScraperTarget, userID influxdb.ID) (*influxdb.ScraperTarget, error) {
+	update.BackfillSecretKeys()
+	target, err := s.ScraperTargetStoreService.UpdateTarget(ctx, update, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.putSecrets(ctx, update); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// RemoveTarget removes a scraper target along with its credentials.
+func (s *TargetService) RemoveTarget(ctx context.Context, id influxdb.ID) error {
+	target, err := s.ScraperTargetStoreService.GetTargetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.ScraperTargetStoreService.RemoveTarget(ctx, id); err != nil {
+		return err
+	}
+
+	flds := target.SecretFields()
+	if len(flds) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(flds))
+	for _, fld := range flds {
+		keys = append(keys, fld.Key)
+	}
+	return s.secretSVC.DeleteSecret(ctx, target.OrgID, keys...)
+}
+
+func (s *TargetService) putSecrets(ctx context.Context, t *influxdb.ScraperTarget) error {
+	secrets := make(map[string]string)
+	for _, fld := range t.SecretFields() {
+		if fld.Value != nil {
+			secrets[fld.Key] = *fld.Value
+		}
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	return s.secretSVC.PatchSecrets(ctx, t.OrgID, secrets)
+}
+
+// loadSecrets fills in the values of the target credentials from the
+// secret service.
+func loadSecrets(ctx context.Context, secretSVC influxdb.SecretService, t *influxdb.ScraperTarget) error {
+	if t.Auth == nil {
+		return nil
+	}
+	for _, fld := range []*influxdb.SecretField{&t.Auth.Token, &t.Auth.Username, &t.Auth.Password} {
+		if fld.Key == "" || fld.Value != nil {
+			continue
+		}
+		v, err := secretSVC.LoadSecret(ctx, t.OrgID, fld.Key)
+		if err != nil {
+			return err
+		}
+		fld.Value = &v
+	}
+	return nil
+}
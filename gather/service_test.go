@@ -0,0 +1,80 @@
+package gather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+)
+
+func TestTargetService(t *testing.T) {
+	secrets := make(map[string]string)
+	secretSVC := mock.NewSecretService()
+	secretSVC.PatchSecretsFn = func(ctx context.Context, orgID influxdb.ID, m map[string]string) error {
+		for k, v := range m {
+			secrets[k] = v
+		}
+		return nil
+	}
+	secretSVC.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+		return secrets[k], nil
+	}
+	secretSVC.DeleteSecretFn = func(ctx context.Context, orgID influxdb.ID, ks ...string) error {
+		for _, k := range ks {
+			delete(secrets, k)
+		}
+		return nil
+	}
+
+	storage := &mockStorage{
+		Metrics:         make(map[time.Time]Metrics),
+		TotalGatherJobs: make(chan struct{}),
+	}
+	svc := NewTargetService(storage, secretSVC)
+	ctx := context.Background()
+
+	token := "secret-token"
+	target := &influxdb.ScraperTarget{
+		ID:       influxdbtesting.MustIDBase16("3a0d0a6365646120"),
+		OrgID:    *orgID,
+		BucketID: *bucketID,
+		Auth: &influxdb.ScraperAuth{
+			Method: influxdb.ScraperAuthBearer,
+			Token:  influxdb.SecretField{Value: &token},
+		},
+	}
+	// mockStorage does not backfill the secret keys as the kv store does.
+	target.BackfillSecretKeys()
+	if err := svc.AddTarget(ctx, target, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"3a0d0a6365646120-token": "secret-token"}
+	if diff := cmp.Diff(want, secrets); diff != "" {
+		t.Fatalf("unexpected secrets -want/+got:\n%s", diff)
+	}
+
+	loaded := influxdb.ScraperTarget{
+		OrgID: *orgID,
+		Auth: &influxdb.ScraperAuth{
+			Method: influxdb.ScraperAuthBearer,
+			Token:  influxdb.SecretField{Key: "3a0d0a6365646120-token"},
+		},
+	}
+	if err := loadSecrets(ctx, secretSVC, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if v := loaded.Auth.Token.Value; v == nil || *v != token {
+		t.Fatalf("unexpected loaded token %v", v)
+	}
+
+	if err := svc.RemoveTarget(ctx, target.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets) != 0 {
+		t.Fatalf("expected secrets to be deleted, got %v", secrets)
+	}
+}
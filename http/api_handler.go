@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/influxdb/v2/authorizer"
 	"github.com/influxdata/influxdb/v2/chronograf/server"
 	"github.com/influxdata/influxdb/v2/dbrp"
+	"github.com/influxdata/influxdb/v2/http/legacy"
 	"github.com/influxdata/influxdb/v2/http/metric"
 	"github.com/influxdata/influxdb/v2/influxql"
 	"github.com/influxdata/influxdb/v2/kit/feature"
@@ -17,6 +18,7 @@ import (
 	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
 	"github.com/influxdata/influxdb/v2/query"
 	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -37,6 +39,10 @@ type APIBackend struct {
 	// in a single points batch
 	MaxBatchSizeBytes int64
 
+	// WriteStreamBatchBytes, when positive, streams write request bodies and
+	// writes their points in sub-batches of roughly this many bytes.
+	WriteStreamBatchBytes int
+
 	// WriteParserMaxBytes specifies the maximum number of bytes that may be allocated when processing a single
 	// write request. A value of zero specifies there is no limit.
 	WriteParserMaxBytes int
@@ -57,6 +63,9 @@ type APIBackend struct {
 
 	AlgoWProxy FeatureProxyHandler
 
+	// ReadStore, when set, serves the Prometheus remote read endpoint.
+	ReadStore reads.Store
+
 	PointsWriter                    storage.PointsWriter
 	DeleteService                   influxdb.DeleteService
 	BackupService                   influxdb.BackupService
@@ -81,9 +90,11 @@ type APIBackend struct {
 	PasswordsService                influxdb.PasswordsService
 	InfluxQLService                 query.ProxyQueryService
 	InfluxqldService                influxql.ProxyQueryService
+	InfluxQLQueryTracker            legacy.QueryTracker
 	FluxService                     query.ProxyQueryService
 	FluxLanguageService             influxdb.FluxLanguageService
 	TaskService                     influxdb.TaskService
+	BackfillService                 influxdb.BackfillService
 	CheckService                    influxdb.CheckService
 	TelegrafService                 influxdb.TelegrafConfigStore
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
@@ -177,6 +188,9 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	taskLogger := b.Logger.With(zap.String("handler", "bucket"))
 	taskBackend := NewTaskBackend(taskLogger, b)
 	taskBackend.TaskService = authorizer.NewTaskService(taskLogger, b.TaskService)
+	if b.BackfillService != nil {
+		taskBackend.BackfillService = authorizer.NewBackfillService(taskLogger, b.BackfillService, b.TaskService)
+	}
 	taskHandler := NewTaskHandler(b.Logger, taskBackend)
 	h.Mount(prefixTasks, taskHandler)
 
@@ -202,14 +216,20 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	h.Mount(dbrp.PrefixDBRP, dbrp.NewHTTPHandler(b.Logger, b.DBRPService, b.OrganizationService))
 
 	writeBackend := NewWriteBackend(b.Logger.With(zap.String("handler", "write")), b)
-	h.Mount(prefixWrite, NewWriteHandler(b.Logger, writeBackend,
+	writeHandler := NewWriteHandler(b.Logger, writeBackend,
 		WithMaxBatchSizeBytes(b.MaxBatchSizeBytes),
+		WithStreamingWrites(b.WriteStreamBatchBytes),
 		//WithParserOptions(
 		//	models.WithParserMaxBytes(b.WriteParserMaxBytes),
 		//	models.WithParserMaxLines(b.WriteParserMaxLines),
 		//	models.WithParserMaxValues(b.WriteParserMaxValues),
 		//),
-	))
+	)
+	h.Mount(prefixWrite, writeHandler)
+	h.Mount(prefixPrometheusWrite, NewPrometheusWriteHandler(b.Logger.With(zap.String("handler", "prometheus_write")), writeHandler))
+	if b.ReadStore != nil {
+		h.Mount(prefixPrometheusRead, NewPrometheusReadHandler(b.Logger.With(zap.String("handler", "prometheus_read")), b))
+	}
 
 	for _, o := range opts {
 		o(h)
@@ -11,6 +11,7 @@ func newLegacyBackend(b *APIBackend) *legacy.Backend {
 		Logger:           b.Logger,
 		// TODO(sgc): /write support
 		//MaxBatchSizeBytes:     b.APIBackend.MaxBatchSizeBytes,
+		WriteStreamBatchBytes: b.WriteStreamBatchBytes,
 		AuthorizationService:  b.AuthorizationService,
 		OrganizationService:   b.OrganizationService,
 		BucketService:         b.BucketService,
@@ -18,6 +19,7 @@ func newLegacyBackend(b *APIBackend) *legacy.Backend {
 		DBRPMappingServiceV2:  b.DBRPService,
 		ProxyQueryService:     b.InfluxQLService,
 		InfluxqldQueryService: b.InfluxqldService,
+		QueryTracker:          b.InfluxQLQueryTracker,
 		WriteEventRecorder:    b.WriteEventRecorder,
 	}
 }
@@ -29,11 +31,16 @@ func newLegacyHandler(b *legacy.Backend, config legacy.HandlerConfig) *legacy.Ha
 	}
 
 	pointsWriterBackend := legacy.NewPointsWriterBackend(b)
-	h.PointsWriterHandler = legacy.NewWriterHandler(pointsWriterBackend, legacy.WithMaxBatchSizeBytes(b.MaxBatchSizeBytes))
+	h.PointsWriterHandler = legacy.NewWriterHandler(pointsWriterBackend,
+		legacy.WithMaxBatchSizeBytes(b.MaxBatchSizeBytes),
+		legacy.WithStreamingWrites(b.WriteStreamBatchBytes),
+	)
 
 	influxqlBackend := legacy.NewInfluxQLBackend(b)
 	h.InfluxQLHandler = legacy.NewInfluxQLHandler(influxqlBackend, config)
 
+	h.QueriesHandler = legacy.NewQueriesHandler(b)
+
 	h.PingHandler = legacy.NewPingHandler(config.Version)
 	return h
 }
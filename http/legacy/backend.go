@@ -19,12 +19,16 @@ type Handler struct {
 	PointsWriterHandler *WriteHandler
 	PingHandler         *PingHandler
 	InfluxQLHandler     *InfluxqlHandler
+	QueriesHandler      *QueriesHandler
 }
 
 type Backend struct {
 	influxdb.HTTPErrorHandler
 	Logger            *zap.Logger
 	MaxBatchSizeBytes int64
+	// WriteStreamBatchBytes, when positive, streams write request bodies and
+	// writes their points in sub-batches of roughly this many bytes.
+	WriteStreamBatchBytes int
 
 	WriteEventRecorder    metric.EventRecorder
 	AuthorizationService  influxdb.AuthorizationService
@@ -34,6 +38,7 @@ type Backend struct {
 	DBRPMappingServiceV2  influxdb.DBRPMappingServiceV2
 	ProxyQueryService     query.ProxyQueryService
 	InfluxqldQueryService influxql.ProxyQueryService
+	QueryTracker          QueryTracker
 }
 
 // HandlerConfig provides configuration for the legacy handler.
@@ -75,6 +80,11 @@ func (h *Handler) ServeHTTP(w http2.ResponseWriter, r *http2.Request) {
 		return
 	}
 
+	if r.URL.Path == "/queries" {
+		h.QueriesHandler.ServeHTTP(w, r)
+		return
+	}
+
 	w.WriteHeader(http2.StatusNotFound)
 }
 
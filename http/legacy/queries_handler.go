@@ -0,0 +1,74 @@
+package legacy
+
+import (
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/influxql/query"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"go.uber.org/zap"
+)
+
+// QueryTracker lists the InfluxQL queries running in an organization.
+type QueryTracker interface {
+	Queries(orgID influxdb.ID) []query.QueryInfo
+}
+
+// QueriesHandler represents an HTTP API handler listing the running InfluxQL queries.
+type QueriesHandler struct {
+	influxdb.HTTPErrorHandler
+	QueryTracker QueryTracker
+
+	router *httprouter.Router
+	api    *kithttp.API
+}
+
+// NewQueriesHandler returns a new instance of QueriesHandler.
+func NewQueriesHandler(b *Backend) *QueriesHandler {
+	logger := b.Logger.With(zap.String("handler", "queries"))
+	h := &QueriesHandler{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		QueryTracker:     b.QueryTracker,
+
+		router: NewRouter(b.HTTPErrorHandler),
+		api:    kithttp.NewAPI(kithttp.WithLog(logger)),
+	}
+
+	h.router.HandlerFunc(http.MethodGet, "/queries", h.handleGetQueries)
+	return h
+}
+
+func (h *QueriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+type queriesResponse struct {
+	Queries []query.QueryInfo `json:"queries"`
+}
+
+// handleGetQueries is the HTTP handler for the GET /queries route.
+// It lists the queries running in the organization of the caller.
+func (h *QueriesHandler) handleGetQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	auth, err := getAuthorization(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if !auth.IsActive() {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "insufficient permissions",
+		}, w)
+		return
+	}
+
+	resp := queriesResponse{Queries: []query.QueryInfo{}}
+	if h.QueryTracker != nil {
+		resp.Queries = h.QueryTracker.Queries(auth.OrgID)
+	}
+	h.api.Respond(w, r, http.StatusOK, resp)
+}
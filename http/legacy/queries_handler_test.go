@@ -0,0 +1,61 @@
+package legacy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	pcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/influxql/query"
+	"github.com/influxdata/influxql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestQueriesHandler_HandleGetQueries(t *testing.T) {
+	orgID := influxdb.ID(1)
+
+	tracker := query.NewQueryTracker()
+	q, err := influxql.ParseQuery(`SELECT value FROM cpu`)
+	require.NoError(t, err)
+	_, qid, detach, err := tracker.Attach(context.Background(), q, query.ExecutionOptions{OrgID: orgID, Database: "db0"})
+	require.NoError(t, err)
+	defer detach()
+	_, _, detachOther, err := tracker.Attach(context.Background(), q, query.ExecutionOptions{OrgID: orgID + 1, Database: "db1"})
+	require.NoError(t, err)
+	defer detachOther()
+
+	h := NewQueriesHandler(&Backend{
+		HTTPErrorHandler: DefaultErrorHandler,
+		Logger:           zaptest.NewLogger(t),
+		QueryTracker:     tracker,
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/queries", nil)
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{
+		OrgID:  orgID,
+		Status: influxdb.Active,
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Queries []struct {
+			ID       uint64 `json:"id"`
+			Query    string `json:"query"`
+			Database string `json:"database"`
+			Status   string `json:"status"`
+		} `json:"queries"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Queries, 1)
+	assert.Equal(t, qid, resp.Queries[0].ID)
+	assert.Equal(t, "SELECT value FROM cpu", resp.Queries[0].Query)
+	assert.Equal(t, "db0", resp.Queries[0].Database)
+	assert.Equal(t, "running", resp.Queries[0].Status)
+}
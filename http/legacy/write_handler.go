@@ -2,9 +2,12 @@ package legacy
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb/v2"
@@ -12,6 +15,7 @@ import (
 	"github.com/influxdata/influxdb/v2/http/points"
 	"github.com/influxdata/influxdb/v2/kit/tracing"
 	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/models"
 	"github.com/influxdata/influxdb/v2/storage"
 	"github.com/influxdata/influxdb/v2/tsdb"
 	"go.uber.org/zap"
@@ -54,9 +58,10 @@ type WriteHandler struct {
 	PointsWriter       storage.PointsWriter
 	DBRPMappingService influxdb.DBRPMappingServiceV2
 
-	router            *httprouter.Router
-	logger            *zap.Logger
-	maxBatchSizeBytes int64
+	router               *httprouter.Router
+	logger               *zap.Logger
+	maxBatchSizeBytes    int64
+	streamBatchSizeBytes int
 }
 
 // NewWriterHandler returns a new instance of PointsWriterHandler.
@@ -92,6 +97,17 @@ func WithMaxBatchSizeBytes(n int64) WriteHandlerOption {
 	}
 }
 
+// WithStreamingWrites configures the write handler to parse and write the
+// request body in sub-batches of roughly n bytes rather than buffering it
+// whole. A body which may still be rejected as a whole, because of the maximum
+// batch size or because partial writes are disabled, is only written once it
+// has been read. A value of zero disables streaming.
+func WithStreamingWrites(n int) WriteHandlerOption {
+	return func(w *WriteHandler) {
+		w.streamBatchSizeBytes = n
+	}
+}
+
 // ServeHTTP implements http.Handler
 func (h *WriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.router.ServeHTTP(w, r)
@@ -135,35 +151,102 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parsed, err := points.NewParser(req.Precision).Parse(ctx, auth.OrgID, bucket.ID, req.Body)
+	if h.streamBatchSizeBytes > 0 {
+		requestBytes = h.streamWrite(ctx, sw, r, req, auth.OrgID, bucket.ID)
+		return
+	}
+
+	parser := points.NewParser(req.Precision)
+	parser.PartialWrites = req.Partial
+	parsed, err := parser.Parse(ctx, auth.OrgID, bucket.ID, req.Body)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, sw)
 		return
 	}
 
-	if err := h.PointsWriter.WritePoints(ctx, auth.OrgID, bucket.ID, parsed.Points); err != nil {
-		if partialErr, ok := err.(tsdb.PartialWriteError); ok {
-			h.HandleHTTPError(ctx, &influxdb.Error{
-				Code: influxdb.EUnprocessableEntity,
-				Op:   opWriteHandler,
-				Msg:  "failure writing points to database",
-				Err:  partialErr,
-			}, sw)
+	// a partial write may have rejected every line, leaving nothing to write
+	if len(parsed.Points) > 0 || len(parsed.Rejected) == 0 {
+		if err := h.PointsWriter.WritePoints(ctx, auth.OrgID, bucket.ID, parsed.Points); err != nil {
+			h.HandleHTTPError(ctx, pointsWriterError(err), sw)
 			return
 		}
+	}
 
-		h.HandleHTTPError(ctx, &influxdb.Error{
-			Code: influxdb.EInternal,
-			Op:   opWriteHandler,
-			Msg:  "unexpected error writing points to database",
-			Err:  err,
-		}, sw)
+	h.writeResponse(sw, r, len(parsed.Points), parsed.Rejected)
+}
+
+// streamWrite writes the request body in sub-batches as it is read and
+// returns the number of bytes read.
+func (h *WriteHandler) streamWrite(ctx context.Context, w http.ResponseWriter, r *http.Request, req *writeRequest, orgID, bucketID influxdb.ID) int {
+	stream := &points.StreamWriter{
+		Precision:         req.Precision,
+		BatchSizeBytes:    h.streamBatchSizeBytes,
+		PartialWrites:     req.Partial,
+		Writer:            h.PointsWriter,
+		MaxBatchSizeBytes: h.maxBatchSizeBytes,
+	}
+	res, err := stream.Write(ctx, orgID, bucketID, req.Body)
+	if err != nil {
+		h.logger.Info("Streamed write failed",
+			zap.Int("points_written", res.Written),
+			zap.Int("batches_written", res.Batches),
+			zap.Error(err))
+
+		var writeErr *points.WriteError
+		if errors.As(err, &writeErr) {
+			e := pointsWriterError(writeErr.Err)
+			e.Msg = fmt.Sprintf("%s at line %d after %d points were written", e.Msg, writeErr.Line, writeErr.Written)
+			err = e
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return res.RawSize
+	}
+
+	h.writeResponse(w, r, res.Written, res.Rejected)
+	return res.RawSize
+}
+
+// writeResponse reports a successful write, listing any lines rejected by a
+// partial write.
+func (h *WriteHandler) writeResponse(w http.ResponseWriter, r *http.Request, written int, rejected models.LineErrors) {
+	if len(rejected) > 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		res := points.NewPartialWriteResponse(written, rejected)
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			// the headers have already been written, so the error can
+			// only be logged.
+			h.logger.Info("Error encoding response",
+				zap.String("path", r.URL.Path),
+				zap.String("method", r.Method),
+				zap.Error(err))
+		}
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// pointsWriterError maps an error returned by the PointsWriter to the error
+// reported to the client.
+func pointsWriterError(err error) *influxdb.Error {
+	if partialErr, ok := err.(tsdb.PartialWriteError); ok {
+		return &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Op:   opWriteHandler,
+			Msg:  "failure writing points to database",
+			Err:  partialErr,
+		}
+	}
+
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Op:   opWriteHandler,
+		Msg:  "unexpected error writing points to database",
+		Err:  err,
+	}
+}
+
 // findBucket finds a bucket for the specified database and
 // retention policy combination.
 func (h *WriteHandler) findBucket(ctx context.Context, orgID influxdb.ID, db, rp string) (*influxdb.Bucket, error) {
@@ -232,6 +315,7 @@ type writeRequest struct {
 	Database         string
 	RetentionPolicy  string
 	Precision        string
+	Partial          bool
 	Body             io.ReadCloser
 }
 
@@ -251,6 +335,18 @@ func decodeWriteRequest(_ context.Context, r *http.Request, maxBatchSizeBytes in
 		}
 	}
 
+	var partial bool
+	if v := qp.Get("partial"); v != "" {
+		var err error
+		if partial, err = strconv.ParseBool(v); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid partial value %q", v),
+				Err:  err,
+			}
+		}
+	}
+
 	encoding := r.Header.Get("Content-Encoding")
 	body, err := points.BatchReadCloser(r.Body, encoding, maxBatchSizeBytes)
 	if err != nil {
@@ -262,6 +358,7 @@ func decodeWriteRequest(_ context.Context, r *http.Request, maxBatchSizeBytes in
 		Database:         db,
 		RetentionPolicy:  qp.Get("rp"),
 		Precision:        precision,
+		Partial:          partial,
 		Body:             body,
 	}, nil
 }
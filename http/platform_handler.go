@@ -55,6 +55,7 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// TODO(affo): change this to be mounted prefixes: https://github.com/influxdata/idpe/issues/6689.
 	if r.URL.Path == "/write" ||
 		r.URL.Path == "/query" ||
+		r.URL.Path == "/queries" ||
 		r.URL.Path == "/ping" {
 		h.LegacyHandler.ServeHTTP(w, r)
 		return
@@ -69,6 +70,7 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// of the platform API.
 	if !strings.HasPrefix(r.URL.Path, "/v1") &&
 		!strings.HasPrefix(r.URL.Path, "/api/v2") &&
+		!strings.HasPrefix(r.URL.Path, "/api/v1/prom/") &&
 		!strings.HasPrefix(r.URL.Path, "/chronograf/") &&
 		!strings.HasPrefix(r.URL.Path, "/private/") {
 		h.AssetHandler.ServeHTTP(w, r)
@@ -0,0 +1,45 @@
+package points
+
+import (
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+)
+
+// PartialWriteResponse is the body returned to a client that opted in to
+// partial writes when some of its lines were rejected. The code and message
+// fields match influxdb.Error so that clients unaware of the extra fields can
+// still decode it as a regular error.
+type PartialWriteResponse struct {
+	Code     string         `json:"code"`
+	Message  string         `json:"message"`
+	Written  int            `json:"written"`
+	Rejected int            `json:"rejected"`
+	Errors   []RejectedLine `json:"errors"`
+}
+
+// RejectedLine describes a single line that was dropped from a partial write.
+type RejectedLine struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// NewPartialWriteResponse builds the response for a partial write in which
+// written points were stored and the rejected lines were dropped.
+func NewPartialWriteResponse(written int, rejected models.LineErrors) *PartialWriteResponse {
+	res := &PartialWriteResponse{
+		Code:     influxdb.EInvalid,
+		Message:  fmt.Sprintf("partial write: dropped=%d", len(rejected)),
+		Written:  written,
+		Rejected: len(rejected),
+		Errors:   make([]RejectedLine, 0, len(rejected)),
+	}
+	for _, le := range rejected {
+		res.Errors = append(res.Errors, RejectedLine{
+			Line:   le.Line,
+			Reason: le.Error(),
+		})
+	}
+	return res
+}
@@ -35,11 +35,20 @@ const (
 type ParsedPoints struct {
 	Points  models.Points
 	RawSize int
+
+	// Rejected holds the lines that failed to parse when the Parser
+	// accepts partial writes.
+	Rejected models.LineErrors
 }
 
 // Parser parses batches of Points.
 type Parser struct {
 	Precision string
+
+	// PartialWrites, when set, keeps the points from valid lines and reports
+	// the rejected lines via ParsedPoints.Rejected instead of failing the
+	// whole batch.
+	PartialWrites bool
 	//ParserOptions []models.ParserOption
 }
 
@@ -78,6 +87,17 @@ func (pw *Parser) parsePoints(ctx context.Context, orgID, bucketID influxdb.ID,
 
 	span, _ := tracing.StartSpanFromContextWithOperationName(ctx, "encoding and parsing")
 
+	if pw.PartialWrites {
+		points, rejected := models.ParsePointsWithLineErrors(data, time.Now().UTC(), pw.Precision)
+		span.LogKV("values_total", len(points), "lines_rejected", len(rejected))
+		span.Finish()
+		return &ParsedPoints{
+			Points:   points,
+			RawSize:  requestBytes,
+			Rejected: rejected,
+		}, nil
+	}
+
 	points, err := models.ParsePointsWithPrecision(data, time.Now().UTC(), pw.Precision)
 	span.LogKV("values_total", len(points))
 	span.Finish()
@@ -0,0 +1,222 @@
+package points
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	io2 "github.com/influxdata/influxdb/v2/kit/io"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/models"
+)
+
+// DefaultStreamBatchSizeBytes is the sub-batch size used by a StreamWriter
+// when none is configured.
+const DefaultStreamBatchSizeBytes = 1 << 20 // 1 MiB
+
+// PointsWriter writes points to a bucket. It is satisfied by storage.PointsWriter.
+type PointsWriter interface {
+	WritePoints(ctx context.Context, orgID, bucketID influxdb.ID, points []models.Point) error
+}
+
+// StreamResult describes the progress of a streamed write.
+type StreamResult struct {
+	RawSize int // bytes read after decompression
+	Written int // points written
+	Batches int // sub-batches written
+
+	// Rejected holds the lines that failed to parse when the StreamWriter
+	// accepts partial writes.
+	Rejected models.LineErrors
+}
+
+// WriteError is returned by a StreamWriter when writing a sub-batch fails.
+// Sub-batches before it have already been written.
+type WriteError struct {
+	Line    int // 1-based line at which the failed sub-batch starts
+	Written int // points written before the failure
+	Err     error
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("write failed at line %d after %d points were written: %v", e.Line, e.Written, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// StreamWriter parses line protocol incrementally and writes it in sub-batches
+// of roughly BatchSizeBytes.
+//
+// A body which is too large, or which has a line that fails to parse when
+// PartialWrites is false, is rejected as a whole, so its sub-batches are only
+// written once the whole body has been read. Otherwise each sub-batch is
+// written as soon as it is parsed and the whole request body is never held in
+// memory. In both cases, a failure writing a sub-batch leaves the sub-batches
+// before it written.
+type StreamWriter struct {
+	Precision      string
+	BatchSizeBytes int
+	PartialWrites  bool
+	Writer         PointsWriter
+
+	// MaxBatchSizeBytes is the limit on the size of the body enforced by the
+	// reader passed to Write, if any.
+	MaxBatchSizeBytes int64
+}
+
+// streamBatch is a parsed sub-batch waiting to be written.
+type streamBatch struct {
+	line   int // 1-based line at which the sub-batch starts
+	points []models.Point
+}
+
+// Write reads line protocol from rc until EOF and writes it to the bucket. The
+// returned StreamResult reports the progress made, including on failure.
+func (s *StreamWriter) Write(ctx context.Context, orgID, bucketID influxdb.ID, rc io.ReadCloser) (*StreamResult, error) {
+	span, ctx := tracing.StartSpanFromContextWithOperationName(ctx, "stream points")
+	defer span.Finish()
+
+	res := &StreamResult{}
+	defer func() {
+		span.LogKV("request_bytes", res.RawSize, "values_total", res.Written, "batches", res.Batches)
+	}()
+
+	closed := false
+	defer func() {
+		if !closed {
+			_ = rc.Close()
+		}
+	}()
+
+	batchSize := s.BatchSizeBytes
+	if batchSize <= 0 {
+		batchSize = DefaultStreamBatchSizeBytes
+	}
+
+	// the sub-batches are held back until the body is known to be accepted
+	// as a whole.
+	hold := s.MaxBatchSizeBytes > 0 || !s.PartialWrites
+
+	var (
+		buf     = make([]byte, 0, batchSize)
+		line    = 1 // line number at the start of buf
+		eof     bool
+		pending []streamBatch
+	)
+	for !eof || len(buf) > 0 {
+		for !eof && len(buf) < cap(buf) {
+			n, err := rc.Read(buf[len(buf):cap(buf)])
+			buf = buf[:len(buf)+n]
+			res.RawSize += n
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return res, readError(err, line, res.Written)
+			}
+		}
+
+		if eof && !closed {
+			// the size limit is only reported once the body is closed, so
+			// check it before writing the final sub-batch.
+			closed = true
+			if err := rc.Close(); err != nil {
+				return res, readError(err, line, res.Written)
+			}
+		}
+
+		n := len(buf)
+		if !eof {
+			if n = models.CompleteLinesLen(buf); n == 0 {
+				// a single line is larger than a sub-batch; grow the buffer
+				// until it holds the whole line.
+				grown := make([]byte, len(buf), 2*cap(buf))
+				copy(grown, buf)
+				buf = grown
+				continue
+			}
+		}
+
+		// parsed points refer to the chunk, so the remainder is moved to a
+		// fresh buffer rather than compacted in place.
+		chunk, rest := buf[:n], buf[n:]
+		buf = make([]byte, len(rest), len(rest)+batchSize)
+		copy(buf, rest)
+
+		points, rejected := models.ParsePointsWithLineErrors(chunk, time.Now().UTC(), s.Precision)
+		for _, le := range rejected {
+			le.Line += line - 1
+		}
+		if len(rejected) > 0 && !s.PartialWrites {
+			return res, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   opPointsWriter,
+				Msg:  fmt.Sprintf("unable to parse line %d after %d points were written", rejected[0].Line, res.Written),
+				Err:  rejected,
+			}
+		}
+		res.Rejected = append(res.Rejected, rejected...)
+
+		if len(points) > 0 {
+			batch := streamBatch{line: line, points: points}
+			if hold {
+				pending = append(pending, batch)
+			} else if err := s.writeBatch(ctx, orgID, bucketID, batch, res); err != nil {
+				return res, err
+			}
+		}
+		line += bytes.Count(chunk, []byte{'\n'})
+	}
+
+	if res.RawSize == 0 {
+		return res, &influxdb.Error{
+			Op:   opPointsWriter,
+			Code: influxdb.EInvalid,
+			Msg:  msgWritingRequiresPoints,
+		}
+	}
+
+	for _, batch := range pending {
+		if err := s.writeBatch(ctx, orgID, bucketID, batch, res); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// writeBatch writes a sub-batch and records it in res.
+func (s *StreamWriter) writeBatch(ctx context.Context, orgID, bucketID influxdb.ID, batch streamBatch, res *StreamResult) error {
+	if err := s.Writer.WritePoints(ctx, orgID, bucketID, batch.points); err != nil {
+		return &WriteError{
+			Line:    batch.line,
+			Written: res.Written,
+			Err:     err,
+		}
+	}
+	res.Written += len(batch.points)
+	res.Batches++
+	return nil
+}
+
+func readError(err error, line, written int) error {
+	code := influxdb.EInternal
+	if errors.Is(err, io2.ErrReadLimitExceeded) {
+		err = ErrMaxBatchSizeExceeded
+		code = influxdb.ETooLarge
+	} else if errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) {
+		code = influxdb.EInvalid
+	}
+	return &influxdb.Error{
+		Code: code,
+		Op:   opPointsWriter,
+		Msg:  fmt.Sprintf("%s at line %d after %d points were written", msgUnableToReadData, line, written),
+		Err:  err,
+	}
+}
@@ -0,0 +1,183 @@
+package points
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchRecorder struct {
+	batches [][]string
+	err     error
+	failAt  int // fail the n-th batch, 1-based
+}
+
+func (w *batchRecorder) WritePoints(ctx context.Context, orgID, bucketID influxdb.ID, points []models.Point) error {
+	if w.failAt > 0 && len(w.batches)+1 == w.failAt {
+		return w.err
+	}
+	batch := make([]string, 0, len(points))
+	for _, p := range points {
+		batch = append(batch, p.String())
+	}
+	w.batches = append(w.batches, batch)
+	return nil
+}
+
+func (w *batchRecorder) points() []string {
+	var all []string
+	for _, b := range w.batches {
+		all = append(all, b...)
+	}
+	return all
+}
+
+// failingReadCloser fails reads past the first failAfter bytes.
+type failingReadCloser struct {
+	r         io.Reader
+	n         int
+	failAfter int
+	failed    bool
+}
+
+func (rc *failingReadCloser) Read(p []byte) (int, error) {
+	if rc.n >= rc.failAfter {
+		rc.failed = true
+		return 0, errors.New("read past the failed sub-batch")
+	}
+	if len(p) > rc.failAfter-rc.n {
+		p = p[:rc.failAfter-rc.n]
+	}
+	n, err := rc.r.Read(p)
+	rc.n += n
+	return n, err
+}
+
+func (rc *failingReadCloser) Close() error { return nil }
+
+func TestStreamWriter_Write(t *testing.T) {
+	orgID, bucketID := influxdb.ID(1), influxdb.ID(2)
+
+	t.Run("writes in sub-batches", func(t *testing.T) {
+		body := "m f=1 1\n" +
+			"m s=\"multi\nline\" 2\n" +
+			"# comment\n" +
+			"m f=3 3\n" +
+			"m f=4 4"
+
+		w := new(batchRecorder)
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 10, Writer: w}
+		res, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader(body)))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"m f=1 1",
+			"m s=\"multi\nline\" 2",
+			"m f=3 3",
+			"m f=4 4",
+		}, w.points())
+		assert.Greater(t, len(w.batches), 1)
+		assert.Equal(t, len(w.batches), res.Batches)
+		assert.Equal(t, 4, res.Written)
+		assert.Equal(t, len(body), res.RawSize)
+	})
+
+	t.Run("line longer than a sub-batch", func(t *testing.T) {
+		body := "m,host=" + strings.Repeat("a", 100) + " f=1 1\nm f=2 2\n"
+
+		w := new(batchRecorder)
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 8, Writer: w}
+		res, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader(body)))
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Written)
+	})
+
+	t.Run("parse error reports position and writes nothing", func(t *testing.T) {
+		body := "m f=1 1\nm f=2 2\ninvalid\nm f=4 4\n"
+
+		w := new(batchRecorder)
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 16, Writer: w}
+		res, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader(body)))
+		require.Error(t, err)
+		assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+		assert.Equal(t, "unable to parse line 3 after 0 points were written: unable to parse 'invalid': missing fields", err.Error())
+		assert.Empty(t, w.points())
+		assert.Equal(t, 0, res.Written)
+	})
+
+	t.Run("partial write writes sub-batches as they are parsed", func(t *testing.T) {
+		body := "m f=1 1\nm f=2 2\nm f=3 3\n"
+
+		writeErr := errors.New("disk full")
+		w := &batchRecorder{err: writeErr, failAt: 2}
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 8, PartialWrites: true, Writer: w}
+		rc := &failingReadCloser{r: strings.NewReader(body), failAfter: 16}
+		_, err := s.Write(context.Background(), orgID, bucketID, rc)
+
+		var werr *WriteError
+		require.True(t, errors.As(err, &werr))
+		assert.Equal(t, 2, werr.Line)
+		assert.Equal(t, []string{"m f=1 1"}, w.points())
+		assert.False(t, rc.failed, "the body was read past the failed sub-batch")
+	})
+
+	t.Run("partial write collects rejected lines", func(t *testing.T) {
+		body := "m f=1 1\nbad\nm f=3 3\nm f= 4\nm f=5 5"
+
+		w := new(batchRecorder)
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 8, PartialWrites: true, Writer: w}
+		res, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader(body)))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"m f=1 1", "m f=3 3", "m f=5 5"}, w.points())
+		require.Len(t, res.Rejected, 2)
+		assert.Equal(t, 2, res.Rejected[0].Line)
+		assert.Equal(t, 4, res.Rejected[1].Line)
+	})
+
+	t.Run("write failure reports position and progress", func(t *testing.T) {
+		body := "m f=1 1\nm f=2 2\nm f=3 3\n"
+
+		writeErr := errors.New("disk full")
+		w := &batchRecorder{err: writeErr, failAt: 2}
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 8, Writer: w}
+		res, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader(body)))
+
+		var werr *WriteError
+		require.True(t, errors.As(err, &werr))
+		assert.Equal(t, 2, werr.Line)
+		assert.Equal(t, 1, werr.Written)
+		assert.True(t, errors.Is(err, writeErr))
+		assert.Equal(t, 1, res.Written)
+	})
+
+	t.Run("max batch size exceeded writes nothing", func(t *testing.T) {
+		body := "m f=1 1\nm f=2 2\nm f=3 3\n"
+
+		rc, err := BatchReadCloser(ioutil.NopCloser(strings.NewReader(body)), "", 12)
+		require.NoError(t, err)
+
+		w := new(batchRecorder)
+		s := &StreamWriter{Precision: "ns", BatchSizeBytes: 8, PartialWrites: true, Writer: w, MaxBatchSizeBytes: 12}
+		res, err := s.Write(context.Background(), orgID, bucketID, rc)
+		require.Error(t, err)
+		assert.Equal(t, influxdb.ETooLarge, influxdb.ErrorCode(err))
+		assert.Equal(t, "unable to read data at line 2 after 0 points were written: points batch is too large", err.Error())
+		assert.Empty(t, w.points())
+		assert.Equal(t, 0, res.Written)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		s := &StreamWriter{Precision: "ns", Writer: new(batchRecorder)}
+		_, err := s.Write(context.Background(), orgID, bucketID, ioutil.NopCloser(strings.NewReader("")))
+		require.Error(t, err)
+		assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+	})
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	"github.com/influxdata/influxdb/v2/http/points"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixPrometheusRead = "/api/v1/prom/read"
+
+	opPrometheusReadHandler = "http/prometheusReadHandler"
+
+	// maxPrometheusReadBytes limits the size of remote read requests,
+	// which only hold a few label matchers per query.
+	maxPrometheusReadBytes = 1 << 20
+
+	// maxPrometheusReadSamples limits the number of samples of a remote
+	// read response, which is built in memory. It matches the default
+	// remote read sample limit of Prometheus.
+	maxPrometheusReadSamples = 50000000
+)
+
+// PrometheusReadHandler answers the queries of the Prometheus remote read
+// protocol by reading the series of a bucket from the storage engine.
+type PrometheusReadHandler struct {
+	influxdb.HTTPErrorHandler
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+	Store               reads.Store
+
+	router     *httprouter.Router
+	log        *zap.Logger
+	maxSamples int
+}
+
+// NewPrometheusReadHandler creates a new handler at /api/v1/prom/read.
+func NewPrometheusReadHandler(log *zap.Logger, b *APIBackend) *PrometheusReadHandler {
+	h := &PrometheusReadHandler{
+		HTTPErrorHandler:    b.HTTPErrorHandler,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+		Store:               b.ReadStore,
+
+		router:     NewRouter(b.HTTPErrorHandler),
+		log:        log,
+		maxSamples: maxPrometheusReadSamples,
+	}
+
+	h.router.HandlerFunc(http.MethodPost, prefixPrometheusRead, h.handleRead)
+	return h
+}
+
+// Prefix provides the route prefix.
+func (*PrometheusReadHandler) Prefix() string {
+	return prefixPrometheusRead
+}
+
+func (h *PrometheusReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+func (h *PrometheusReadHandler) handleRead(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PrometheusReadHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	org, err := queryOrganization(ctx, r, h.OrganizationService)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	span.LogKV("org_id", org.ID)
+
+	bucketName := r.URL.Query().Get("bucket")
+	if bucketName == "" {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Op:   opPrometheusReadHandler,
+			Msg:  "bucket not found",
+		}, w)
+		return
+	}
+	bucket, err := findBucketByIDOrName(ctx, h.BucketService, org.ID, bucketName)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	span.LogKV("bucket_id", bucket.ID)
+
+	if _, _, err := authorizer.AuthorizeReadBucket(ctx, bucket.Type, bucket.ID, org.ID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	resp := &remote.ReadResponse{
+		Results: make([]*remote.QueryResult, 0, len(req.Queries)),
+	}
+	// the sample limit applies to the response as a whole
+	remaining := h.maxSamples
+	for _, q := range req.Queries {
+		series, err := h.readQuery(r, org.ID, bucket.ID, q, remaining)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		for _, ts := range series {
+			remaining -= len(ts.Samples)
+		}
+		resp.Results = append(resp.Results, &remote.QueryResult{Timeseries: series})
+	}
+
+	b, err := remote.EncodeReadResponse(resp)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   opPrometheusReadHandler,
+			Msg:  "unable to encode remote read response",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b); err != nil {
+		h.log.Info("Unable to write remote read response", zap.Error(err))
+	}
+}
+
+// readQuery reads the series of the bucket selected by a query, holding at
+// most maxSamples samples.
+func (h *PrometheusReadHandler) readQuery(r *http.Request, orgID, bucketID influxdb.ID, q *remote.Query, maxSamples int) ([]*remote.TimeSeries, error) {
+	pred, err := q.Predicate()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   opPrometheusReadHandler,
+			Msg:  "invalid label matcher",
+			Err:  err,
+		}
+	}
+
+	src, err := types.MarshalAny(h.Store.GetSource(uint64(orgID), uint64(bucketID)))
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   opPrometheusReadHandler,
+			Err:  err,
+		}
+	}
+
+	rs, err := h.Store.ReadFilter(r.Context(), &datatypes.ReadFilterRequest{
+		ReadSource: src,
+		Range:      q.TimeRange(),
+		Predicate:  pred,
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   opPrometheusReadHandler,
+			Msg:  "unable to read series",
+			Err:  err,
+		}
+	}
+	if rs == nil {
+		return []*remote.TimeSeries{}, nil
+	}
+
+	series, err := remote.ReadSeries(rs, q, maxSamples)
+	if err == remote.ErrSampleLimit {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   opPrometheusReadHandler,
+			Msg:  fmt.Sprintf("remote read exceeds the limit of %d samples; narrow the time range or the label matchers", h.maxSamples),
+		}
+	} else if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   opPrometheusReadHandler,
+			Msg:  "unable to read series",
+			Err:  err,
+		}
+	}
+	return series, nil
+}
+
+// decodeRequest reads the snappy compressed remote read request.
+func (h *PrometheusReadHandler) decodeRequest(r *http.Request) (*remote.ReadRequest, error) {
+	body, err := points.BatchReadCloser(r.Body, "", maxPrometheusReadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := ioutil.ReadAll(body)
+	if cerr := body.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, tooLargeOr(opPrometheusReadHandler, err, influxdb.EInternal, "unable to read data")
+	}
+
+	req, err := remote.DecodeReadRequest(compressed, maxPrometheusReadBytes)
+	if err != nil {
+		return nil, tooLargeOr(opPrometheusReadHandler, err, influxdb.EInvalid, "unable to decode remote read request")
+	}
+	return req, nil
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	httpmock "github.com/influxdata/influxdb/v2/http/mock"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	influxtesting "github.com/influxdata/influxdb/v2/testing"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPrometheusReadHandler_handleRead(t *testing.T) {
+	body, err := remote.EncodeReadRequest(&remote.ReadRequest{
+		Queries: []*remote.Query{{
+			StartTimestampMs: 1000,
+			EndTimestampMs:   2000,
+			Matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_EQ, Name: "__name__", Value: "up"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	twoQueries, err := remote.EncodeReadRequest(&remote.ReadRequest{
+		Queries: []*remote.Query{
+			{Matchers: []*remote.LabelMatcher{{Type: remote.LabelMatcher_EQ, Name: "__name__", Value: "up"}}},
+			{Matchers: []*remote.LabelMatcher{{Type: remote.LabelMatcher_EQ, Name: "job", Value: "api"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	badMatcher, err := remote.EncodeReadRequest(&remote.ReadRequest{
+		Queries: []*remote.Query{{
+			Matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_RE, Name: "job", Value: "("},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		auth       influxdb.Authorizer
+		body       []byte
+		maxSamples int
+		code       int
+		want       *remote.ReadResponse
+	}{
+		{
+			name: "series are read",
+			auth: bucketReadPermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: body,
+			code: http.StatusOK,
+			want: &remote.ReadResponse{
+				Results: []*remote.QueryResult{{
+					Timeseries: []*remote.TimeSeries{{
+						Labels: []remote.Label{
+							{Name: "__name__", Value: "up"},
+							{Name: "job", Value: "api"},
+						},
+						Samples: []remote.Sample{{Value: 1, Timestamp: 1000}},
+					}},
+				}},
+			},
+		},
+		{
+			name: "forbidden to read with insufficient permission",
+			auth: bucketReadPermission("043e0780ee2b1000", "000000000000000a"),
+			body: body,
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid body",
+			auth: bucketReadPermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: []byte("m1,t1=v1 f1=1"),
+			code: http.StatusBadRequest,
+		},
+		{
+			name:       "too many samples",
+			auth:       bucketReadPermission("043e0780ee2b1000", "04504b356e23b000"),
+			body:       twoQueries,
+			maxSamples: 1,
+			code:       http.StatusBadRequest,
+		},
+		{
+			name: "invalid matcher",
+			auth: bucketReadPermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: badMatcher,
+			code: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := mock.NewOrganizationService()
+			orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+				return &influxdb.Organization{ID: influxtesting.MustIDBase16("043e0780ee2b1000")}, nil
+			}
+			buckets := mock.NewBucketService()
+			buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+				return &influxdb.Bucket{ID: influxtesting.MustIDBase16("04504b356e23b000")}, nil
+			}
+			store := &fakeReadStore{
+				tags: models.ParseTags([]byte("up,_measurement=up,_field=value,job=api")),
+				a: &cursors.FloatArray{
+					Timestamps: []int64{1000000000},
+					Values:     []float64{1},
+				},
+			}
+
+			b := &APIBackend{
+				HTTPErrorHandler:    DefaultErrorHandler,
+				Logger:              zaptest.NewLogger(t),
+				OrganizationService: orgs,
+				BucketService:       buckets,
+				ReadStore:           store,
+			}
+			h := NewPrometheusReadHandler(zaptest.NewLogger(t), b)
+			if tt.maxSamples > 0 {
+				h.maxSamples = tt.maxSamples
+			}
+			handler := httpmock.NewAuthMiddlewareHandler(h, tt.auth)
+
+			r := httptest.NewRequest(
+				"POST",
+				"http://localhost:8086/api/v1/prom/read?org=043e0780ee2b1000&bucket=04504b356e23b000",
+				bytes.NewReader(tt.body),
+			)
+			r.Header.Set("Content-Encoding", "snappy")
+			r.Header.Set("Content-Type", "application/x-protobuf")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("unexpected status code: got %d want %d, body: %s", got, want, w.Body.String())
+			}
+			if tt.want == nil {
+				return
+			}
+
+			if got, want := store.req.Range, (datatypes.TimestampRange{Start: 1000000000, End: 2001000000}); got != want {
+				t.Errorf("unexpected range: got %v want %v", got, want)
+			}
+			b2, err := ioutil.ReadAll(w.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := remote.DecodeReadResponse(b2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected response -want/+got\n%s", diff)
+			}
+		})
+	}
+}
+
+func bucketReadPermission(org, bucket string) *influxdb.Authorization {
+	oid := influxtesting.MustIDBase16(org)
+	bid := influxtesting.MustIDBase16(bucket)
+	return &influxdb.Authorization{
+		OrgID:  oid,
+		Status: influxdb.Active,
+		Permissions: []influxdb.Permission{
+			{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: &oid,
+					ID:    &bid,
+				},
+			},
+		},
+	}
+}
+
+// fakeReadStore serves a single series from ReadFilter.
+type fakeReadStore struct {
+	reads.Store
+	tags models.Tags
+	a    *cursors.FloatArray
+	req  *datatypes.ReadFilterRequest
+}
+
+func (s *fakeReadStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &types.Empty{}
+}
+
+func (s *fakeReadStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	s.req = req
+	return &fakeResultSet{tags: s.tags, cur: &fakeFloatCursor{a: s.a}}, nil
+}
+
+type fakeResultSet struct {
+	tags models.Tags
+	cur  cursors.Cursor
+	done bool
+}
+
+func (rs *fakeResultSet) Next() bool {
+	if rs.done {
+		return false
+	}
+	rs.done = true
+	return true
+}
+
+func (rs *fakeResultSet) Cursor() cursors.Cursor     { return rs.cur }
+func (rs *fakeResultSet) Tags() models.Tags          { return rs.tags }
+func (rs *fakeResultSet) Close()                     {}
+func (rs *fakeResultSet) Err() error                 { return nil }
+func (rs *fakeResultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type fakeFloatCursor struct {
+	a *cursors.FloatArray
+}
+
+func (c *fakeFloatCursor) Next() *cursors.FloatArray {
+	a := c.a
+	c.a = &cursors.FloatArray{}
+	return a
+}
+
+func (c *fakeFloatCursor) Close()                     {}
+func (c *fakeFloatCursor) Err() error                 { return nil }
+func (c *fakeFloatCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
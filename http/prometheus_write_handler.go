@@ -0,0 +1,178 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	pcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/http/points"
+	io2 "github.com/influxdata/influxdb/v2/kit/io"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixPrometheusWrite = "/api/v1/prom/write"
+
+	opPrometheusWriteHandler = "http/prometheusWriteHandler"
+)
+
+// PrometheusWriteHandler receives samples from the Prometheus remote write
+// protocol and writes them as points.
+type PrometheusWriteHandler struct {
+	influxdb.HTTPErrorHandler
+	WriteHandler *WriteHandler
+
+	router *httprouter.Router
+	log    *zap.Logger
+}
+
+// NewPrometheusWriteHandler creates a new handler at /api/v1/prom/write.
+// Buckets are looked up, authorized and written to as by the write handler.
+func NewPrometheusWriteHandler(log *zap.Logger, w *WriteHandler) *PrometheusWriteHandler {
+	h := &PrometheusWriteHandler{
+		HTTPErrorHandler: w.HTTPErrorHandler,
+		WriteHandler:     w,
+
+		router: NewRouter(w.HTTPErrorHandler),
+		log:    log,
+	}
+
+	h.router.HandlerFunc(http.MethodPost, prefixPrometheusWrite, h.handleWrite)
+	return h
+}
+
+// Prefix provides the route prefix.
+func (*PrometheusWriteHandler) Prefix() string {
+	return prefixPrometheusWrite
+}
+
+func (h *PrometheusWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+func (h *PrometheusWriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PrometheusWriteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	org, err := queryOrganization(ctx, r, h.WriteHandler.OrganizationService)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	span.LogKV("org_id", org.ID)
+
+	sw := kithttp.NewStatusResponseWriter(w)
+	recorder := NewWriteUsageRecorder(sw, h.WriteHandler.EventRecorder)
+	var requestBytes int
+	defer func() {
+		// Close around the requestBytes variable to placate the linter.
+		recorder.Record(ctx, requestBytes, org.ID, r.URL.Path)
+	}()
+
+	bucketName := r.URL.Query().Get("bucket")
+	if bucketName == "" {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Op:   opPrometheusWriteHandler,
+			Msg:  "bucket not found",
+		}, sw)
+		return
+	}
+	bucket, err := h.WriteHandler.findBucket(ctx, org.ID, bucketName)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, sw)
+		return
+	}
+	span.LogKV("bucket_id", bucket.ID)
+
+	if err := checkBucketWritePermissions(auth, org.ID, bucket.ID); err != nil {
+		h.HandleHTTPError(ctx, err, sw)
+		return
+	}
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, sw)
+		return
+	}
+
+	pts, err := req.Points()
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   opPrometheusWriteHandler,
+			Msg:  "unable to map samples to points",
+			Err:  err,
+		}, sw)
+		return
+	}
+	for _, pt := range pts {
+		requestBytes += pt.StringSize()
+	}
+
+	if len(pts) > 0 {
+		if err := h.WriteHandler.PointsWriter.WritePoints(ctx, org.ID, bucket.ID, pts); err != nil {
+			h.HandleHTTPError(ctx, pointsWriterError(err), sw)
+			return
+		}
+	}
+
+	sw.WriteHeader(http.StatusNoContent)
+}
+
+// decodeRequest reads the snappy compressed remote write request, limiting
+// both the compressed and decompressed body to the maximum batch size of
+// the write handler.
+func (h *PrometheusWriteHandler) decodeRequest(r *http.Request) (*remote.WriteRequest, error) {
+	maxBytes := h.WriteHandler.maxBatchSizeBytes
+	body, err := points.BatchReadCloser(r.Body, "", maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := ioutil.ReadAll(body)
+	if cerr := body.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, tooLargeOr(opPrometheusWriteHandler, err, influxdb.EInternal, "unable to read data")
+	}
+
+	req, err := remote.DecodeWriteRequest(compressed, maxBytes)
+	if err != nil {
+		return nil, tooLargeOr(opPrometheusWriteHandler, err, influxdb.EInvalid, "unable to decode remote write request")
+	}
+	return req, nil
+}
+
+// tooLargeOr wraps err in a too large error if the request exceeds the
+// maximum batch size, or in an error with code otherwise.
+func tooLargeOr(op string, err error, code, msg string) *influxdb.Error {
+	if errors.Is(err, remote.ErrDecodedTooLarge) || errors.Is(err, io2.ErrReadLimitExceeded) {
+		return &influxdb.Error{
+			Code: influxdb.ETooLarge,
+			Op:   op,
+			Msg:  points.ErrMaxBatchSizeExceeded.Error(),
+			Err:  err,
+		}
+	}
+	return &influxdb.Error{
+		Code: code,
+		Op:   op,
+		Msg:  msg,
+		Err:  err,
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http/metric"
+	httpmock "github.com/influxdata/influxdb/v2/http/mock"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	influxtesting "github.com/influxdata/influxdb/v2/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPrometheusWriteHandler_handleWrite(t *testing.T) {
+	body, err := remote.EncodeWriteRequest(&remote.WriteRequest{
+		Timeseries: []remote.TimeSeries{
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "up"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		auth   influxdb.Authorizer
+		body   []byte
+		opts   []WriteHandlerOption
+		code   int
+		points []string
+	}{
+		{
+			name:   "samples are written",
+			auth:   bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			body:   body,
+			code:   http.StatusNoContent,
+			points: []string{"up,job=api value=1 1000000000"},
+		},
+		{
+			name: "forbidden to write with insufficient permission",
+			auth: bucketWritePermission("043e0780ee2b1000", "000000000000000a"),
+			body: body,
+			code: http.StatusForbidden,
+		},
+		{
+			name: "invalid body",
+			auth: bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: []byte("m1,t1=v1 f1=1"),
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "body larger than the max batch size",
+			auth: bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: body,
+			opts: []WriteHandlerOption{WithMaxBatchSizeBytes(8)},
+			code: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := mock.NewOrganizationService()
+			orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+				return &influxdb.Organization{ID: influxtesting.MustIDBase16("043e0780ee2b1000")}, nil
+			}
+			buckets := mock.NewBucketService()
+			buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+				return &influxdb.Bucket{ID: influxtesting.MustIDBase16("04504b356e23b000")}, nil
+			}
+			pointsWriter := &mock.PointsWriter{}
+
+			b := &APIBackend{
+				HTTPErrorHandler:    DefaultErrorHandler,
+				Logger:              zaptest.NewLogger(t),
+				OrganizationService: orgs,
+				BucketService:       buckets,
+				PointsWriter:        pointsWriter,
+				WriteEventRecorder:  &metric.NopEventRecorder{},
+			}
+			writeHandler := NewWriteHandler(zaptest.NewLogger(t), NewWriteBackend(zaptest.NewLogger(t), b), tt.opts...)
+			handler := httpmock.NewAuthMiddlewareHandler(NewPrometheusWriteHandler(zaptest.NewLogger(t), writeHandler), tt.auth)
+
+			r := httptest.NewRequest(
+				"POST",
+				"http://localhost:8086/api/v1/prom/write?org=043e0780ee2b1000&bucket=04504b356e23b000",
+				bytes.NewReader(tt.body),
+			)
+			r.Header.Set("Content-Encoding", "snappy")
+			r.Header.Set("Content-Type", "application/x-protobuf")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("unexpected status code: got %d want %d, body: %s", got, want, w.Body.String())
+			}
+
+			var got []string
+			for _, pt := range pointsWriter.Points {
+				got = append(got, pt.String())
+			}
+			if len(got) != len(tt.points) {
+				t.Fatalf("unexpected points: got %v want %v", got, tt.points)
+			}
+			for i := range got {
+				if got[i] != tt.points[i] {
+					t.Errorf("unexpected point: got %s want %s", got[i], tt.points[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/pkg/httpc"
+)
+
+const (
+	tasksIDBackfillsPath   = "/api/v2/tasks/:id/backfills"
+	tasksIDBackfillsIDPath = "/api/v2/tasks/:id/backfills/:bid"
+)
+
+// backfillResponse is the API representation of a backfill. It uses a pointer
+// for the finish time, so that it is left out while the backfill is running.
+type backfillResponse struct {
+	Links         map[string]string `json:"links,omitempty"`
+	ID            influxdb.ID       `json:"id"`
+	TaskID        influxdb.ID       `json:"taskID"`
+	Start         time.Time         `json:"start"`
+	End           time.Time         `json:"end"`
+	Status        string            `json:"status"`
+	TotalRuns     int               `json:"totalRuns"`
+	CompletedRuns int               `json:"completedRuns"`
+	FailedRuns    int               `json:"failedRuns"`
+	LastError     string            `json:"lastError,omitempty"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	FinishedAt    *time.Time        `json:"finishedAt,omitempty"`
+}
+
+func newBackfillResponse(b influxdb.Backfill) backfillResponse {
+	res := backfillResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills/%s", b.TaskID, b.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", b.TaskID),
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", b.TaskID),
+		},
+		ID:            b.ID,
+		TaskID:        b.TaskID,
+		Start:         b.Start,
+		End:           b.End,
+		Status:        b.Status,
+		TotalRuns:     b.TotalRuns,
+		CompletedRuns: b.CompletedRuns,
+		FailedRuns:    b.FailedRuns,
+		LastError:     b.LastError,
+		CreatedAt:     b.CreatedAt,
+	}
+	if !b.FinishedAt.IsZero() {
+		res.FinishedAt = &b.FinishedAt
+	}
+	return res
+}
+
+func (b backfillResponse) toInfluxDB() *influxdb.Backfill {
+	bf := &influxdb.Backfill{
+		ID:            b.ID,
+		TaskID:        b.TaskID,
+		Start:         b.Start,
+		End:           b.End,
+		Status:        b.Status,
+		TotalRuns:     b.TotalRuns,
+		CompletedRuns: b.CompletedRuns,
+		FailedRuns:    b.FailedRuns,
+		LastError:     b.LastError,
+		CreatedAt:     b.CreatedAt,
+	}
+	if b.FinishedAt != nil {
+		bf.FinishedAt = *b.FinishedAt
+	}
+	return bf
+}
+
+type backfillsResponse struct {
+	Links     map[string]string   `json:"links"`
+	Backfills []*backfillResponse `json:"backfills"`
+}
+
+func newBackfillsResponse(bs []*influxdb.Backfill, taskID influxdb.ID) backfillsResponse {
+	res := backfillsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Backfills: make([]*backfillResponse, len(bs)),
+	}
+	for i := range bs {
+		b := newBackfillResponse(*bs[i])
+		res.Backfills[i] = &b
+	}
+	return res
+}
+
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackfillRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}, w)
+		return
+	}
+
+	b, err := h.BackfillService.CreateBackfill(ctx, req.TaskID, req.Start, req.End)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type postBackfillRequest struct {
+	TaskID     influxdb.ID
+	Start, End time.Time
+}
+
+func decodePostBackfillRequest(ctx context.Context, r *http.Request) (*postBackfillRequest, error) {
+	taskID, err := decodeTaskIDParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(time.RFC3339, body.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %v", err)
+	}
+	end, err := time.Parse(time.RFC3339, body.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end: %v", err)
+	}
+
+	return &postBackfillRequest{
+		TaskID: taskID,
+		Start:  start,
+		End:    end,
+	}, nil
+}
+
+func (h *TaskHandler) handleGetBackfills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeTaskIDParam(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	bs, err := h.BackfillService.FindBackfills(ctx, taskID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillsResponse(bs, taskID)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillIDParams(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BackfillService.FindBackfillByID(ctx, taskID, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillIDParams(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.BackfillService.CancelBackfill(ctx, taskID, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeTaskIDParam(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	var id influxdb.ID
+	if err := id.DecodeFromString(params.ByName("id")); err != nil {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task ID",
+			Err:  err,
+		}
+	}
+	return id, nil
+}
+
+func decodeBackfillIDParams(ctx context.Context) (influxdb.ID, influxdb.ID, error) {
+	taskID, err := decodeTaskIDParam(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+	var id influxdb.ID
+	if err := id.DecodeFromString(params.ByName("bid")); err != nil {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid backfill ID",
+			Err:  err,
+		}
+	}
+	return taskID, id, nil
+}
+
+// BackfillService connects to Influx via HTTP using tokens to manage task backfills.
+type BackfillService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.BackfillService = (*BackfillService)(nil)
+
+// CreateBackfill starts a backfill of the task for the runs scheduled from start until end.
+func (s *BackfillService) CreateBackfill(ctx context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	body := struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}{
+		Start: start.UTC().Format(time.RFC3339),
+		End:   end.UTC().Format(time.RFC3339),
+	}
+
+	var b backfillResponse
+	err := s.Client.
+		PostJSON(body, taskIDBackfillsPath(taskID)).
+		DecodeJSON(&b).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.toInfluxDB(), nil
+}
+
+// FindBackfills returns the backfills of a task.
+func (s *BackfillService) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var bs backfillsResponse
+	err := s.Client.
+		Get(taskIDBackfillsPath(taskID)).
+		DecodeJSON(&bs).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backfills := make([]*influxdb.Backfill, 0, len(bs.Backfills))
+	for _, b := range bs.Backfills {
+		backfills = append(backfills, b.toInfluxDB())
+	}
+	return backfills, nil
+}
+
+// FindBackfillByID returns a single backfill.
+func (s *BackfillService) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var b backfillResponse
+	err := s.Client.
+		Get(taskIDBackfillsPath(taskID), id.String()).
+		DecodeJSON(&b).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.toInfluxDB(), nil
+}
+
+// CancelBackfill stops a backfill.
+func (s *BackfillService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.Client.
+		Delete(taskIDBackfillsPath(taskID), id.String()).
+		Do(ctx)
+}
+
+func taskIDBackfillsPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "backfills")
+}
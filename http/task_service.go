@@ -35,6 +35,7 @@ type TaskBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	BucketService              influxdb.BucketService
+	BackfillService            influxdb.BackfillService
 }
 
 // NewTaskBackend returns a new instance of TaskBackend.
@@ -50,6 +51,7 @@ func NewTaskBackend(log *zap.Logger, b *APIBackend) *TaskBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		BackfillService:            b.BackfillService,
 	}
 }
 
@@ -66,22 +68,24 @@ type TaskHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	BucketService              influxdb.BucketService
+	BackfillService            influxdb.BackfillService
 }
 
 const (
-	prefixTasks            = "/api/v2/tasks"
-	tasksIDPath            = "/api/v2/tasks/:id"
-	tasksIDLogsPath        = "/api/v2/tasks/:id/logs"
-	tasksIDMembersPath     = "/api/v2/tasks/:id/members"
-	tasksIDMembersIDPath   = "/api/v2/tasks/:id/members/:userID"
-	tasksIDOwnersPath      = "/api/v2/tasks/:id/owners"
-	tasksIDOwnersIDPath    = "/api/v2/tasks/:id/owners/:userID"
-	tasksIDRunsPath        = "/api/v2/tasks/:id/runs"
-	tasksIDRunsIDPath      = "/api/v2/tasks/:id/runs/:rid"
-	tasksIDRunsIDLogsPath  = "/api/v2/tasks/:id/runs/:rid/logs"
-	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
-	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
-	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
+	prefixTasks             = "/api/v2/tasks"
+	tasksIDPath             = "/api/v2/tasks/:id"
+	tasksIDLogsPath         = "/api/v2/tasks/:id/logs"
+	tasksIDDependenciesPath = "/api/v2/tasks/:id/dependencies"
+	tasksIDMembersPath      = "/api/v2/tasks/:id/members"
+	tasksIDMembersIDPath    = "/api/v2/tasks/:id/members/:userID"
+	tasksIDOwnersPath       = "/api/v2/tasks/:id/owners"
+	tasksIDOwnersIDPath     = "/api/v2/tasks/:id/owners/:userID"
+	tasksIDRunsPath         = "/api/v2/tasks/:id/runs"
+	tasksIDRunsIDPath       = "/api/v2/tasks/:id/runs/:rid"
+	tasksIDRunsIDLogsPath   = "/api/v2/tasks/:id/runs/:rid/logs"
+	tasksIDRunsIDRetryPath  = "/api/v2/tasks/:id/runs/:rid/retry"
+	tasksIDLabelsPath       = "/api/v2/tasks/:id/labels"
+	tasksIDLabelsIDPath     = "/api/v2/tasks/:id/labels/:lid"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -98,6 +102,7 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		BackfillService:            b.BackfillService,
 	}
 
 	h.HandlerFunc("GET", prefixTasks, h.handleGetTasks)
@@ -110,6 +115,8 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
 
+	h.HandlerFunc("GET", tasksIDDependenciesPath, h.handleGetDependencies)
+
 	memberBackend := MemberBackend{
 		HTTPErrorHandler:           b.HTTPErrorHandler,
 		log:                        b.log.With(zap.String("handler", "member")),
@@ -140,6 +147,13 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	if b.BackfillService != nil {
+		h.HandlerFunc("POST", tasksIDBackfillsPath, h.handlePostBackfill)
+		h.HandlerFunc("GET", tasksIDBackfillsPath, h.handleGetBackfills)
+		h.HandlerFunc("GET", tasksIDBackfillsIDPath, h.handleGetBackfill)
+		h.HandlerFunc("DELETE", tasksIDBackfillsIDPath, h.handleCancelBackfill)
+	}
+
 	labelBackend := &LabelBackend{
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              b.log.With(zap.String("handler", "label")),
@@ -166,7 +180,9 @@ type Task struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Timezone        string                 `json:"timezone,omitempty"`
 	Offset          string                 `json:"offset,omitempty"`
+	Upstream        []influxdb.ID          `json:"upstream,omitempty"`
 	LatestCompleted string                 `json:"latestCompleted,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
@@ -211,7 +227,9 @@ func NewFrontEndTask(t influxdb.Task) Task {
 		Flux:            t.Flux,
 		Every:           t.Every,
 		Cron:            t.Cron,
+		Timezone:        t.Timezone,
 		Offset:          offset,
+		Upstream:        t.Upstream,
 		LatestCompleted: latestCompleted,
 		LastRunStatus:   t.LastRunStatus,
 		LastRunError:    t.LastRunError,
@@ -259,7 +277,9 @@ func convertTask(t Task) *influxdb.Task {
 		Flux:            t.Flux,
 		Every:           t.Every,
 		Cron:            t.Cron,
+		Timezone:        t.Timezone,
 		Offset:          offset,
+		Upstream:        t.Upstream,
 		LatestCompleted: latestCompleted,
 		LastRunStatus:   t.LastRunStatus,
 		LastRunError:    t.LastRunError,
@@ -707,6 +727,65 @@ func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type taskDependenciesResponse struct {
+	Links map[string]string `json:"links"`
+	*influxdb.TaskDependencyGraph
+}
+
+// handleGetDependencies returns the graph of the tasks of the organization of
+// a task connected to it through upstream tasks.
+func (h *TaskHandler) handleGetDependencies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeGetTaskRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	task, err := h.TaskService.FindTaskByID(ctx, req.TaskID)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.ENotFound,
+			Msg:  "failed to find task",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var tasks []*influxdb.Task
+	filter := influxdb.TaskFilter{OrganizationID: &task.OrganizationID}
+	for {
+		ts, _, err := h.TaskService.FindTasks(ctx, filter)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		if len(ts) == 0 {
+			break
+		}
+		tasks = append(tasks, ts...)
+		filter.After = &ts[len(ts)-1].ID
+	}
+
+	res := taskDependenciesResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/dependencies", task.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", task.ID),
+		},
+		TaskDependencyGraph: influxdb.NewTaskDependencyGraph(task.ID, tasks),
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
 type getTaskRequest struct {
 	TaskID influxdb.ID
 }
@@ -818,6 +818,86 @@ func TestTaskHandler_handleGetRuns(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_handleGetDependencies(t *testing.T) {
+	tasks := []*influxdb.Task{
+		{ID: 1, Name: "raw", Status: "active", OrganizationID: 1},
+		{ID: 2, Name: "1m", Status: "active", OrganizationID: 1, Upstream: []influxdb.ID{1}},
+		{ID: 3, Name: "1h", Status: "inactive", OrganizationID: 1, Upstream: []influxdb.ID{2}},
+		{ID: 4, Name: "other", Status: "active", OrganizationID: 1},
+	}
+	taskService := &mock.TaskService{
+		FindTaskByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Task, error) {
+			for _, t := range tasks {
+				if t.ID == id {
+					return t, nil
+				}
+			}
+			return nil, influxdb.ErrTaskNotFound
+		},
+		FindTasksFn: func(ctx context.Context, f influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
+			if f.OrganizationID == nil || *f.OrganizationID != 1 {
+				t.Errorf("expected tasks of organization 1, got filter %v", f)
+			}
+			var ts []*influxdb.Task
+			for _, t := range tasks {
+				if f.After == nil || t.ID > *f.After {
+					ts = append(ts, t)
+				}
+			}
+			// page through the tasks two at a time
+			if len(ts) > 2 {
+				ts = ts[:2]
+			}
+			return ts, len(ts), nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url", nil)
+	r = r.WithContext(context.WithValue(
+		context.Background(),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: influxdb.ID(2).String(),
+			},
+		}))
+	w := httptest.NewRecorder()
+	taskBackend := NewMockTaskBackend(t)
+	taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	taskBackend.TaskService = taskService
+	h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+	h.handleGetDependencies(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("handleGetDependencies() = %v, want %v: %s", res.StatusCode, http.StatusOK, body)
+	}
+
+	want := `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000002/dependencies",
+    "task": "/api/v2/tasks/0000000000000002"
+  },
+  "nodes": [
+    {"id": "0000000000000001", "name": "raw", "status": "active"},
+    {"id": "0000000000000002", "name": "1m", "status": "active"},
+    {"id": "0000000000000003", "name": "1h", "status": "inactive"}
+  ],
+  "edges": [
+    {"upstream": "0000000000000001", "downstream": "0000000000000002"},
+    {"upstream": "0000000000000002", "downstream": "0000000000000003"}
+  ]
+}`
+	if eq, diff, err := jsonEqual(string(body), want); err != nil {
+		t.Errorf("handleGetDependencies(). error unmarshalling json %v", err)
+	} else if !eq {
+		t.Errorf("handleGetDependencies() = ***%s***", diff)
+	}
+}
+
 func TestTaskHandler_NotFoundStatus(t *testing.T) {
 	// Ensure that the HTTP handlers return 404s for missing resources, and OKs for matching.
 
@@ -3,9 +3,11 @@ package http
 import (
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb/v2"
@@ -53,9 +55,10 @@ type WriteHandler struct {
 	PointsWriter        storage.PointsWriter
 	EventRecorder       metric.EventRecorder
 
-	router            *httprouter.Router
-	log               *zap.Logger
-	maxBatchSizeBytes int64
+	router               *httprouter.Router
+	log                  *zap.Logger
+	maxBatchSizeBytes    int64
+	streamBatchSizeBytes int
 	// parserOptions     []models.ParserOption
 }
 
@@ -70,6 +73,17 @@ func WithMaxBatchSizeBytes(n int64) WriteHandlerOption {
 	}
 }
 
+// WithStreamingWrites configures the write handler to parse and write the
+// request body in sub-batches of roughly n bytes rather than buffering it
+// whole. A body which may still be rejected as a whole, because of the maximum
+// batch size or because partial writes are disabled, is only written once it
+// has been read. A value of zero disables streaming.
+func WithStreamingWrites(n int) WriteHandlerOption {
+	return func(w *WriteHandler) {
+		w.streamBatchSizeBytes = n
+	}
+}
+
 //func WithParserOptions(opts ...models.ParserOption) WriteHandlerOption {
 //	return func(w *WriteHandler) {
 //		w.parserOptions = opts
@@ -111,8 +125,14 @@ func NewWriteHandler(log *zap.Logger, b *WriteBackend, opts ...WriteHandlerOptio
 }
 
 func (h *WriteHandler) findBucket(ctx context.Context, orgID influxdb.ID, bucket string) (*influxdb.Bucket, error) {
+	return findBucketByIDOrName(ctx, h.BucketService, orgID, bucket)
+}
+
+// findBucketByIDOrName finds the bucket of the organization with bucket
+// as its ID, or else as its name.
+func findBucketByIDOrName(ctx context.Context, svc influxdb.BucketService, orgID influxdb.ID, bucket string) (*influxdb.Bucket, error) {
 	if id, err := influxdb.IDFromString(bucket); err == nil {
-		b, err := h.BucketService.FindBucket(ctx, influxdb.BucketFilter{
+		b, err := svc.FindBucket(ctx, influxdb.BucketFilter{
 			OrganizationID: &orgID,
 			ID:             id,
 		})
@@ -123,7 +143,7 @@ func (h *WriteHandler) findBucket(ctx context.Context, orgID influxdb.ID, bucket
 		}
 	}
 
-	return h.BucketService.FindBucket(ctx, influxdb.BucketFilter{
+	return svc.FindBucket(ctx, influxdb.BucketFilter{
 		OrganizationID: &orgID,
 		Name:           &bucket,
 	})
@@ -177,37 +197,97 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.streamBatchSizeBytes > 0 {
+		requestBytes = h.streamWrite(ctx, sw, r, req, org.ID, bucket.ID)
+		return
+	}
+
 	// TODO: Backport?
 	//opts := append([]models.ParserOption{}, h.parserOptions...)
 	//opts = append(opts, models.WithParserPrecision(req.Precision))
-	parsed, err := points.NewParser(req.Precision).Parse(ctx, org.ID, bucket.ID, req.Body)
+	parser := points.NewParser(req.Precision)
+	parser.PartialWrites = req.Partial
+	parsed, err := parser.Parse(ctx, org.ID, bucket.ID, req.Body)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, sw)
 		return
 	}
 	requestBytes = parsed.RawSize
 
-	if err := h.PointsWriter.WritePoints(ctx, org.ID, bucket.ID, parsed.Points); err != nil {
-		if partialErr, ok := err.(tsdb.PartialWriteError); ok {
-			h.HandleHTTPError(ctx, &influxdb.Error{
-				Code: influxdb.EUnprocessableEntity,
-				Op:   opWriteHandler,
-				Msg:  "failure writing points to database",
-				Err:  partialErr,
-			}, sw)
+	// a partial write may have rejected every line, leaving nothing to write
+	if len(parsed.Points) > 0 || len(parsed.Rejected) == 0 {
+		if err := h.PointsWriter.WritePoints(ctx, org.ID, bucket.ID, parsed.Points); err != nil {
+			h.HandleHTTPError(ctx, pointsWriterError(err), sw)
 			return
 		}
+	}
 
-		h.HandleHTTPError(ctx, &influxdb.Error{
-			Code: influxdb.EInternal,
-			Op:   opWriteHandler,
-			Msg:  "unexpected error writing points to database",
-			Err:  err,
-		}, sw)
+	h.writeResponse(ctx, sw, r, len(parsed.Points), parsed.Rejected)
+}
+
+// streamWrite writes the request body in sub-batches as it is read and
+// returns the number of bytes read.
+func (h *WriteHandler) streamWrite(ctx context.Context, w http.ResponseWriter, r *http.Request, req *writeRequest, orgID, bucketID influxdb.ID) int {
+	stream := &points.StreamWriter{
+		Precision:         req.Precision,
+		BatchSizeBytes:    h.streamBatchSizeBytes,
+		PartialWrites:     req.Partial,
+		Writer:            h.PointsWriter,
+		MaxBatchSizeBytes: h.maxBatchSizeBytes,
+	}
+	res, err := stream.Write(ctx, orgID, bucketID, req.Body)
+	if err != nil {
+		h.log.Info("Streamed write failed",
+			zap.Int("points_written", res.Written),
+			zap.Int("batches_written", res.Batches),
+			zap.Error(err))
+
+		var writeErr *points.WriteError
+		if errors.As(err, &writeErr) {
+			e := pointsWriterError(writeErr.Err)
+			e.Msg = fmt.Sprintf("%s at line %d after %d points were written", e.Msg, writeErr.Line, writeErr.Written)
+			err = e
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return res.RawSize
+	}
+
+	h.writeResponse(ctx, w, r, res.Written, res.Rejected)
+	return res.RawSize
+}
+
+// writeResponse reports a successful write, listing any lines rejected by a
+// partial write.
+func (h *WriteHandler) writeResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, written int, rejected models.LineErrors) {
+	if len(rejected) > 0 {
+		res := points.NewPartialWriteResponse(written, rejected)
+		if err := encodeResponse(ctx, w, http.StatusBadRequest, res); err != nil {
+			logEncodingError(h.log, r, err)
+		}
 		return
 	}
 
-	sw.WriteHeader(http.StatusNoContent)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pointsWriterError maps an error returned by the PointsWriter to the error
+// reported to the client.
+func pointsWriterError(err error) *influxdb.Error {
+	if partialErr, ok := err.(tsdb.PartialWriteError); ok {
+		return &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Op:   opWriteHandler,
+			Msg:  "failure writing points to database",
+			Err:  partialErr,
+		}
+	}
+
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Op:   opWriteHandler,
+		Msg:  "unexpected error writing points to database",
+		Err:  err,
+	}
 }
 
 // checkBucketWritePermissions checks an Authorizer for write permissions to a
@@ -239,6 +319,7 @@ type writeRequest struct {
 	Org       string
 	Bucket    string
 	Precision string
+	Partial   bool
 	Body      io.ReadCloser
 }
 
@@ -268,6 +349,19 @@ func decodeWriteRequest(ctx context.Context, r *http.Request, maxBatchSizeBytes
 		}
 	}
 
+	var partial bool
+	if v := qp.Get("partial"); v != "" {
+		var err error
+		if partial, err = strconv.ParseBool(v); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   "http/newWriteRequest",
+				Msg:  fmt.Sprintf("invalid partial value %q", v),
+				Err:  err,
+			}
+		}
+	}
+
 	encoding := r.Header.Get("Content-Encoding")
 	body, err := points.BatchReadCloser(r.Body, encoding, maxBatchSizeBytes)
 	if err != nil {
@@ -278,6 +372,7 @@ func decodeWriteRequest(ctx context.Context, r *http.Request, maxBatchSizeBytes
 		Bucket:    qp.Get("bucket"),
 		Org:       qp.Get("org"),
 		Precision: precision,
+		Partial:   partial,
 		Body:      body,
 	}, nil
 }
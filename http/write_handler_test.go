@@ -159,10 +159,11 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 
 	// request is sent to the HTTP endpoint
 	type request struct {
-		auth   influxdb.Authorizer
-		org    string
-		bucket string
-		body   string
+		auth    influxdb.Authorizer
+		org     string
+		bucket  string
+		body    string
+		partial string
 	}
 
 	tests := []struct {
@@ -322,6 +323,78 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 				body: `{"code":"invalid","message":"unable to parse 'invalid': missing fields"}`,
 			},
 		},
+		{
+			name: "partial write reports rejected lines",
+			request: request{
+				org:     "043e0780ee2b1000",
+				bucket:  "04504b356e23b000",
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				body:    "m1,t1=v1 f1=1\ninvalid\nm1,t1=v1 f1=2\nm1 f1=\n",
+				partial: "true",
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 400,
+				body: `{"code":"invalid","message":"partial write: dropped=2","written":2,"rejected":2,"errors":[{"line":2,"reason":"unable to parse 'invalid': missing fields"},{"line":4,"reason":"unable to parse 'm1 f1=': missing field value"}]}` + "\n",
+			},
+		},
+		{
+			name: "partial write with only valid lines is accepted",
+			request: request{
+				org:     "043e0780ee2b1000",
+				bucket:  "04504b356e23b000",
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				body:    "m1,t1=v1 f1=1",
+				partial: "true",
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 204,
+			},
+		},
+		{
+			name: "partial write with every line rejected",
+			request: request{
+				org:     "043e0780ee2b1000",
+				bucket:  "04504b356e23b000",
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				body:    "invalid",
+				partial: "true",
+			},
+			state: state{
+				org:      testOrg("043e0780ee2b1000"),
+				bucket:   testBucket("043e0780ee2b1000", "04504b356e23b000"),
+				writeErr: fmt.Errorf("no points should be written"),
+			},
+			wants: wants{
+				code: 400,
+				body: `{"code":"invalid","message":"partial write: dropped=1","written":0,"rejected":1,"errors":[{"line":1,"reason":"unable to parse 'invalid': missing fields"}]}` + "\n",
+			},
+		},
+		{
+			name: "invalid partial value returns 400",
+			request: request{
+				org:     "043e0780ee2b1000",
+				bucket:  "04504b356e23b000",
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				body:    "m1,t1=v1 f1=1",
+				partial: "maybe",
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 400,
+				body: `{"code":"invalid","message":"invalid partial value \"maybe\": strconv.ParseBool: parsing \"maybe\": invalid syntax"}`,
+			},
+		},
 		{
 			name: "forbidden to write with insufficient permission",
 			request: request{
@@ -405,6 +478,9 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 			params := r.URL.Query()
 			params.Set("org", tt.request.org)
 			params.Set("bucket", tt.request.bucket)
+			if tt.request.partial != "" {
+				params.Set("partial", tt.request.partial)
+			}
 			r.URL.RawQuery = params.Encode()
 
 			w := httptest.NewRecorder()
@@ -3660,7 +3660,6 @@ func TestServer_Query_Aggregates_FloatOverlap(t *testing.T) {
 }
 
 func TestServer_Query_ShowFieldKeyCardinality(t *testing.T) {
-	t.Skip(NotSupported)
 	t.Parallel()
 	s := OpenServer(t)
 	defer s.Close()
@@ -4474,7 +4473,6 @@ func TestServer_Query_ShowQueries_Future(t *testing.T) {
 		},
 		&Query{
 			name:    `show series`,
-			skip:    NotSupported,
 			command: "SHOW SERIES",
 			exp:     `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=server01"]]}]}]}`,
 			params:  url.Values{"db": []string{"db0"}},
@@ -4504,7 +4502,6 @@ func TestServer_Query_ShowQueries_Future(t *testing.T) {
 }
 
 func TestServer_Query_ShowSeries(t *testing.T) {
-	t.Skip(NotSupported)
 	t.Parallel()
 	s := OpenServer(t)
 	defer s.Close()
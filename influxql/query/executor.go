@@ -170,6 +170,9 @@ type Executor struct {
 	// StatementNormalizer normalizes a statement before it is executed.
 	StatementNormalizer StatementNormalizer
 
+	// Tracker keeps track of the running queries so they can be listed and killed.
+	Tracker *QueryTracker
+
 	Metrics *control.ControllerMetrics
 
 	log *zap.Logger
@@ -179,6 +182,7 @@ type Executor struct {
 func NewExecutor(logger *zap.Logger, cm *control.ControllerMetrics) *Executor {
 	return &Executor{
 		StatementNormalizer: nullNormalizer,
+		Tracker:             NewQueryTracker(),
 		Metrics:             cm,
 		log:                 logger.With(zap.String("service", "query")),
 	}
@@ -186,7 +190,7 @@ func NewExecutor(logger *zap.Logger, cm *control.ControllerMetrics) *Executor {
 
 // Close kills all running queries and prevents new queries from being attached.
 func (e *Executor) Close() error {
-	return nil
+	return e.Tracker.Close()
 }
 
 // ExecuteQuery executes each statement within a query.
@@ -206,6 +210,19 @@ func (e *Executor) executeQuery(ctx context.Context, query *influxql.Query, opt
 
 	defer e.recover(query, results)
 
+	// Register the query so it can be listed and killed. The parent context
+	// is kept to tell a killed query apart from an abandoned one.
+	parent := ctx
+	ctx, _, detach, err := e.Tracker.Attach(ctx, query, opt)
+	if err != nil {
+		select {
+		case results <- &Result{Err: err}:
+		case <-parent.Done():
+		}
+		return
+	}
+	defer detach()
+
 	gatherer := new(iql.StatisticsGatherer)
 
 	statusLabel := control.LabelSuccess
@@ -318,6 +335,16 @@ LOOP:
 		}
 	}
 
+	// Report the interruption of a killed query, which can no longer send
+	// results through its own context.
+	if ctx.Err() != nil && parent.Err() == nil {
+		select {
+		case results <- &Result{StatementID: i, Err: ErrQueryInterrupted}:
+		case <-parent.Done():
+		}
+		return
+	}
+
 	// Send error results for any statements which were not executed.
 	for ; i < len(query.Statements)-1; i++ {
 		if err := ectx.Send(ctx, &Result{
@@ -59,6 +59,53 @@ func TestQueryExecutor_Interrupt(t *testing.T) {
 	}
 }
 
+func TestQueryExecutor_Kill(t *testing.T) {
+	q, err := influxql.ParseQuery(`SELECT count(value) FROM cpu`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	e := NewQueryExecutor(t)
+	e.StatementExecutor = &StatementExecutor{
+		ExecuteStatementFn: func(ctx context.Context, stmt influxql.Statement, ectx *query.ExecutionContext) error {
+			close(started)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(100 * time.Millisecond):
+				t.Error("killing the query did not close the channel after 100 milliseconds")
+				return errUnexpected
+			}
+		},
+	}
+
+	results, _ := e.ExecuteQuery(context.Background(), q, query.ExecutionOptions{OrgID: 1, Database: "db0"})
+	<-started
+
+	queries := e.Tracker.Queries(1)
+	if len(queries) != 1 {
+		t.Fatalf("expected 1 running query, got %d", len(queries))
+	}
+	assert.Equal(t, "SELECT count(value) FROM cpu", queries[0].Query)
+	assert.Equal(t, "db0", queries[0].Database)
+	assert.Equal(t, query.RunningQuery, queries[0].Status)
+
+	if err := e.Tracker.Kill(1, queries[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	result := <-results
+	if result == nil || result.Err != query.ErrQueryInterrupted {
+		t.Errorf("unexpected result: %v", result)
+	}
+	discardOutput(results)
+
+	if queries := e.Tracker.Queries(1); len(queries) != 0 {
+		t.Errorf("expected no running query, got %d", len(queries))
+	}
+}
+
 func TestQueryExecutor_Abort(t *testing.T) {
 	q, err := influxql.ParseQuery(`SELECT count(value) FROM cpu`)
 	if err != nil {
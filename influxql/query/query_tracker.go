@@ -0,0 +1,172 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxql"
+)
+
+// ErrQueryEngineShutdown is returned when a query is attached after the
+// query tracker has been closed.
+var ErrQueryEngineShutdown = errors.New("query engine shutdown")
+
+// ErrQueryNotFound returns an error for a query ID which is not running.
+func ErrQueryNotFound(qid uint64) error { return fmt.Errorf("no such query id: %d", qid) }
+
+// QueryStatus is the status of a running query.
+type QueryStatus int
+
+const (
+	// RunningQuery is set when the query is running.
+	RunningQuery QueryStatus = iota + 1
+
+	// KilledQuery is set when the query has been killed, but resources are
+	// still being released.
+	KilledQuery
+)
+
+// String returns the string representation of the status.
+func (s QueryStatus) String() string {
+	switch s {
+	case RunningQuery:
+		return "running"
+	case KilledQuery:
+		return "killed"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes the status as its string representation.
+func (s QueryStatus) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// QueryInfo describes a running query.
+type QueryInfo struct {
+	ID       uint64        `json:"id"`
+	Query    string        `json:"query"`
+	OrgID    influxdb.ID   `json:"orgID"`
+	Database string        `json:"database"`
+	Duration time.Duration `json:"duration"`
+	Status   QueryStatus   `json:"status"`
+}
+
+// runningQuery is the state kept by the QueryTracker for each query.
+type runningQuery struct {
+	query     string
+	orgID     influxdb.ID
+	database  string
+	status    QueryStatus
+	startTime time.Time
+	cancel    context.CancelFunc
+}
+
+// QueryTracker keeps track of the queries running in the Executor so they
+// can be listed and killed.
+type QueryTracker struct {
+	mu       sync.RWMutex
+	queries  map[uint64]*runningQuery
+	nextID   uint64
+	shutdown bool
+}
+
+// NewQueryTracker returns a new instance of QueryTracker.
+func NewQueryTracker() *QueryTracker {
+	return &QueryTracker{
+		queries: make(map[uint64]*runningQuery),
+		nextID:  1,
+	}
+}
+
+// Attach registers a query as running. The returned context is canceled when
+// the query is killed and detach must be called once the query has finished.
+func (t *QueryTracker) Attach(ctx context.Context, q *influxql.Query, opt ExecutionOptions) (_ context.Context, qid uint64, detach func(), err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.shutdown {
+		return nil, 0, nil, ErrQueryEngineShutdown
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	qid = t.nextID
+	t.queries[qid] = &runningQuery{
+		query:     q.String(),
+		orgID:     opt.OrgID,
+		database:  opt.Database,
+		status:    RunningQuery,
+		startTime: time.Now(),
+		cancel:    cancel,
+	}
+	t.nextID++
+
+	return ctx, qid, func() { t.detach(qid) }, nil
+}
+
+func (t *QueryTracker) detach(qid uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if q, ok := t.queries[qid]; ok {
+		q.cancel()
+		delete(t.queries, qid)
+	}
+}
+
+// Kill interrupts the query with the given ID running in the organization.
+func (t *QueryTracker) Kill(orgID influxdb.ID, qid uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	q, ok := t.queries[qid]
+	if !ok || q.orgID != orgID {
+		return ErrQueryNotFound(qid)
+	}
+	q.status = KilledQuery
+	q.cancel()
+	return nil
+}
+
+// Queries returns the queries running in the organization, ordered by ID.
+func (t *QueryTracker) Queries(orgID influxdb.ID) []QueryInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := time.Now()
+	queries := make([]QueryInfo, 0, len(t.queries))
+	for id, q := range t.queries {
+		if q.orgID != orgID {
+			continue
+		}
+		queries = append(queries, QueryInfo{
+			ID:       id,
+			Query:    q.query,
+			OrgID:    q.orgID,
+			Database: q.database,
+			Duration: now.Sub(q.startTime),
+			Status:   q.status,
+		})
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].ID < queries[j].ID })
+	return queries
+}
+
+// Close kills all running queries and prevents new queries from being attached.
+func (t *QueryTracker) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.shutdown = true
+	for _, q := range t.queries {
+		q.status = KilledQuery
+		q.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/influxql/query"
+	"github.com/influxdata/influxql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTracker(t *testing.T) {
+	q, err := influxql.ParseQuery(`SELECT value FROM cpu`)
+	require.NoError(t, err)
+
+	tracker := query.NewQueryTracker()
+	org1, org2 := influxdb.ID(1), influxdb.ID(2)
+
+	ctx1, qid1, detach1, err := tracker.Attach(context.Background(), q, query.ExecutionOptions{OrgID: org1, Database: "db0"})
+	require.NoError(t, err)
+	ctx2, qid2, detach2, err := tracker.Attach(context.Background(), q, query.ExecutionOptions{OrgID: org2, Database: "db1"})
+	require.NoError(t, err)
+
+	queries := tracker.Queries(org1)
+	require.Len(t, queries, 1)
+	assert.Equal(t, qid1, queries[0].ID)
+	assert.Equal(t, "db0", queries[0].Database)
+	assert.Equal(t, query.RunningQuery, queries[0].Status)
+
+	// Queries of another organization can be neither listed nor killed.
+	assert.EqualError(t, tracker.Kill(org1, qid2), query.ErrQueryNotFound(qid2).Error())
+	assert.NoError(t, ctx2.Err())
+
+	require.NoError(t, tracker.Kill(org1, qid1))
+	assert.Equal(t, context.Canceled, ctx1.Err())
+	assert.Equal(t, query.KilledQuery, tracker.Queries(org1)[0].Status)
+
+	detach1()
+	assert.Empty(t, tracker.Queries(org1))
+	assert.EqualError(t, tracker.Kill(org1, qid1), query.ErrQueryNotFound(qid1).Error())
+
+	require.NoError(t, tracker.Close())
+	assert.Equal(t, context.Canceled, ctx2.Err())
+	detach2()
+
+	_, _, _, err = tracker.Attach(context.Background(), q, query.ExecutionOptions{OrgID: org1})
+	assert.Equal(t, query.ErrQueryEngineShutdown, err)
+}
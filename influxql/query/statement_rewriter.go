@@ -12,8 +12,6 @@ var matchAllRegex = regexp.MustCompile(`.+`)
 // RewriteStatement rewrites stmt into a new statement, if applicable.
 func RewriteStatement(stmt influxql.Statement) (influxql.Statement, error) {
 	switch stmt := stmt.(type) {
-	case *influxql.ShowFieldKeysStatement:
-		return rewriteShowFieldKeysStatement(stmt)
 	case *influxql.ShowFieldKeyCardinalityStatement:
 		return rewriteShowFieldKeyCardinalityStatement(stmt)
 	case *influxql.ShowMeasurementsStatement:
@@ -37,27 +35,18 @@ func RewriteStatement(stmt influxql.Statement) (influxql.Statement, error) {
 	}
 }
 
-func rewriteShowFieldKeysStatement(stmt *influxql.ShowFieldKeysStatement) (influxql.Statement, error) {
-	return &influxql.SelectStatement{
-		Fields: influxql.Fields([]*influxql.Field{
-			{Expr: &influxql.VarRef{Val: "fieldKey"}},
-			{Expr: &influxql.VarRef{Val: "fieldType"}},
-		}),
-		Sources:    rewriteSources(stmt.Sources, "_fieldKeys", stmt.Database),
-		Condition:  rewriteSourcesCondition(stmt.Sources, nil),
-		Offset:     stmt.Offset,
-		Limit:      stmt.Limit,
-		SortFields: stmt.SortFields,
-		OmitTime:   true,
-		Dedupe:     true,
-		IsRawQuery: true,
-	}, nil
-}
-
 func rewriteShowFieldKeyCardinalityStatement(stmt *influxql.ShowFieldKeyCardinalityStatement) (influxql.Statement, error) {
-	// Check for time in WHERE clause (not supported).
+	// Without GROUP BY the query is answered from the field sets of the
+	// shards overlapping the time range, rather than through a SELECT
+	// statement.
+	if len(stmt.Dimensions) == 0 {
+		stmt.Condition = rewriteSourcesCondition(stmt.Sources, stmt.Condition)
+		stmt.Sources = nil
+		return stmt, nil
+	}
+
 	if influxql.HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW FIELD KEY CARDINALITY doesn't support time in WHERE clause")
+		return nil, errors.New("SHOW FIELD KEY CARDINALITY doesn't support GROUP BY with time in WHERE clause")
 	}
 
 	// Use all field keys, if zero.
@@ -117,9 +106,15 @@ func rewriteShowMeasurementCardinalityStatement(stmt *influxql.ShowMeasurementCa
 		return stmt, nil
 	}
 
-	// Check for time in WHERE clause (not supported).
+	// A time bounded query is answered from the indexes of the shards
+	// overlapping the time range, rather than through a SELECT statement.
 	if influxql.HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW MEASUREMENT EXACT CARDINALITY doesn't support time in WHERE clause")
+		if len(stmt.Dimensions) > 0 {
+			return nil, errors.New("SHOW MEASUREMENT CARDINALITY doesn't support GROUP BY with time in WHERE clause")
+		}
+		stmt.Condition = rewriteSourcesCondition(stmt.Sources, stmt.Condition)
+		stmt.Sources = nil
+		return stmt, nil
 	}
 
 	// Use all measurements, if zero.
@@ -155,31 +150,13 @@ func rewriteShowMeasurementCardinalityStatement(stmt *influxql.ShowMeasurementCa
 }
 
 func rewriteShowSeriesStatement(stmt *influxql.ShowSeriesStatement) (influxql.Statement, error) {
-	s := &influxql.SelectStatement{
-		Condition:  stmt.Condition,
-		Offset:     stmt.Offset,
-		Limit:      stmt.Limit,
+	return &influxql.ShowSeriesStatement{
+		Database:   stmt.Database,
+		Condition:  rewriteSourcesCondition(stmt.Sources, stmt.Condition),
 		SortFields: stmt.SortFields,
-		OmitTime:   true,
-		StripName:  true,
-		Dedupe:     true,
-		IsRawQuery: true,
-	}
-	// Check if we can exclusively use the index.
-	if !influxql.HasTimeExpr(stmt.Condition) {
-		s.Fields = []*influxql.Field{{Expr: &influxql.VarRef{Val: "key"}}}
-		s.Sources = rewriteSources(stmt.Sources, "_series", stmt.Database)
-		s.Condition = rewriteSourcesCondition(s.Sources, s.Condition)
-		return s, nil
-	}
-
-	// The query is bounded by time then it will have to query TSM data rather
-	// than utilising the index via system iterators.
-	s.Fields = []*influxql.Field{
-		{Expr: &influxql.VarRef{Val: "_seriesKey"}, Alias: "key"},
-	}
-	s.Sources = rewriteSources2(stmt.Sources, stmt.Database)
-	return s, nil
+		Limit:      stmt.Limit,
+		Offset:     stmt.Offset,
+	}, nil
 }
 
 func rewriteShowSeriesCardinalityStatement(stmt *influxql.ShowSeriesCardinalityStatement) (influxql.Statement, error) {
@@ -190,9 +167,15 @@ func rewriteShowSeriesCardinalityStatement(stmt *influxql.ShowSeriesCardinalityS
 		return stmt, nil
 	}
 
-	// Check for time in WHERE clause (not supported).
+	// A time bounded query is answered from the indexes of the shards
+	// overlapping the time range, rather than through a SELECT statement.
 	if influxql.HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW SERIES EXACT CARDINALITY doesn't support time in WHERE clause")
+		if len(stmt.Dimensions) > 0 {
+			return nil, errors.New("SHOW SERIES CARDINALITY doesn't support GROUP BY with time in WHERE clause")
+		}
+		stmt.Condition = rewriteSourcesCondition(stmt.Sources, stmt.Condition)
+		stmt.Sources = nil
+		return stmt, nil
 	}
 
 	// Use all measurements, if zero.
@@ -425,6 +408,12 @@ func rewriteSources(sources influxql.Sources, systemIterator, defaultDatabase st
 	return newSources
 }
 
+// SourcesCondition returns a condition matching the measurement names of
+// sources, for statements which have no condition to rewrite them into.
+func SourcesCondition(sources influxql.Sources) influxql.Expr {
+	return rewriteSourcesCondition(sources, nil)
+}
+
 // rewriteSourcesCondition rewrites sources into `name` expressions.
 // Merges with cond and returns a new condition.
 func rewriteSourcesCondition(sources influxql.Sources, cond influxql.Expr) influxql.Expr {
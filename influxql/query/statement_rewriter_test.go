@@ -14,127 +14,95 @@ func TestRewriteStatement(t *testing.T) {
 	}{
 		{
 			stmt: `SHOW FIELD KEYS`,
-			s:    `SELECT fieldKey, fieldType FROM _fieldKeys`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS ON db0`,
-			s:    `SELECT fieldKey, fieldType FROM db0.._fieldKeys`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS FROM cpu`,
-			s:    `SELECT fieldKey, fieldType FROM _fieldKeys WHERE _name = 'cpu'`,
+			s:    `SHOW FIELD KEYS`,
 		},
 		{
 			stmt: `SHOW FIELD KEYS ON db0 FROM cpu`,
-			s:    `SELECT fieldKey, fieldType FROM db0.._fieldKeys WHERE _name = 'cpu'`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS FROM /c.*/`,
-			s:    `SELECT fieldKey, fieldType FROM _fieldKeys WHERE _name =~ /c.*/`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS ON db0 FROM /c.*/`,
-			s:    `SELECT fieldKey, fieldType FROM db0.._fieldKeys WHERE _name =~ /c.*/`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS FROM mydb.myrp2.cpu`,
-			s:    `SELECT fieldKey, fieldType FROM mydb.myrp2._fieldKeys WHERE _name = 'cpu'`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS ON db0 FROM mydb.myrp2.cpu`,
-			s:    `SELECT fieldKey, fieldType FROM mydb.myrp2._fieldKeys WHERE _name = 'cpu'`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS FROM mydb.myrp2./c.*/`,
-			s:    `SELECT fieldKey, fieldType FROM mydb.myrp2._fieldKeys WHERE _name =~ /c.*/`,
-		},
-		{
-			stmt: `SHOW FIELD KEYS ON db0 FROM mydb.myrp2./c.*/`,
-			s:    `SELECT fieldKey, fieldType FROM mydb.myrp2._fieldKeys WHERE _name =~ /c.*/`,
+			s:    `SHOW FIELD KEYS ON db0 FROM cpu`,
 		},
 		{
 			stmt: `SHOW SERIES`,
-			s:    `SELECT "key" FROM _series`,
+			s:    `SHOW SERIES`,
 		},
 		{
 			stmt: `SHOW SERIES ON db0`,
-			s:    `SELECT "key" FROM db0.._series`,
+			s:    `SHOW SERIES ON db0`,
 		},
 		{
 			stmt: `SHOW SERIES FROM cpu`,
-			s:    `SELECT "key" FROM _series WHERE _name = 'cpu'`,
+			s:    `SHOW SERIES WHERE _name = 'cpu'`,
 		},
 		{
 			stmt: `SHOW SERIES ON db0 FROM cpu`,
-			s:    `SELECT "key" FROM db0.._series WHERE _name = 'cpu'`,
+			s:    `SHOW SERIES ON db0 WHERE _name = 'cpu'`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1.cpu`,
-			s:    `SELECT "key" FROM mydb.myrp1._series WHERE _name = 'cpu'`,
+			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/`,
+			s:    `SHOW SERIES WHERE _name =~ /c.*/`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 FROM mydb.myrp1.cpu`,
-			s:    `SELECT "key" FROM mydb.myrp1._series WHERE _name = 'cpu'`,
+			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/ WHERE region = 'uswest'`,
+			s:    `SHOW SERIES WHERE (_name =~ /c.*/) AND (region = 'uswest')`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/`,
-			s:    `SELECT "key" FROM mydb.myrp1._series WHERE _name =~ /c.*/`,
+			stmt: `SHOW SERIES WHERE time > 0`,
+			s:    `SHOW SERIES WHERE time > 0`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/ WHERE region = 'uswest'`,
-			s:    `SELECT "key" FROM mydb.myrp1._series WHERE (_name =~ /c.*/) AND (region = 'uswest')`,
+			stmt: `SHOW SERIES ON db0 FROM cpu WHERE region = 'uswest' AND time > 0`,
+			s:    `SHOW SERIES ON db0 WHERE (_name = 'cpu') AND (region = 'uswest' AND time > 0)`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 FROM mydb.myrp1./c.*/`,
-			s:    `SELECT "key" FROM mydb.myrp1._series WHERE _name =~ /c.*/`,
+			stmt: `SHOW SERIES FROM cpu LIMIT 2 OFFSET 1`,
+			s:    `SHOW SERIES WHERE _name = 'cpu' LIMIT 2 OFFSET 1`,
 		},
 		{
-			stmt: `SHOW SERIES WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM /.+/ WHERE time > 0`,
+			stmt: `SHOW SERIES CARDINALITY FROM m`,
+			s:    `SELECT count(distinct(_seriesKey)) AS count FROM m`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM db0../.+/ WHERE time > 0`,
+			stmt: `SHOW SERIES EXACT CARDINALITY`,
+			s:    `SELECT count(distinct(_seriesKey)) AS count FROM /.+/`,
 		},
 		{
-			stmt: `SHOW SERIES FROM cpu WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM cpu WHERE time > 0`,
+			stmt: `SHOW SERIES EXACT CARDINALITY FROM m`,
+			s:    `SELECT count(distinct(_seriesKey)) AS count FROM m`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 FROM cpu WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM db0..cpu WHERE time > 0`,
+			stmt: `SHOW SERIES CARDINALITY WHERE time > 0`,
+			s:    `SHOW SERIES CARDINALITY WHERE time > 0`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1.cpu WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM mydb.myrp1.cpu WHERE time > 0`,
+			stmt: `SHOW SERIES EXACT CARDINALITY FROM m WHERE region = 'uswest' AND time > 0`,
+			s:    `SHOW SERIES EXACT CARDINALITY WHERE (_name = 'm') AND (region = 'uswest' AND time > 0)`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 FROM mydb.myrp1.cpu WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM mydb.myrp1.cpu WHERE time > 0`,
+			stmt: `SHOW FIELD KEY CARDINALITY`,
+			s:    `SHOW FIELD KEY CARDINALITY`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/ WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM mydb.myrp1./c.*/ WHERE time > 0`,
+			stmt: `SHOW FIELD KEY CARDINALITY FROM m`,
+			s:    `SHOW FIELD KEY CARDINALITY WHERE _name = 'm'`,
 		},
 		{
-			stmt: `SHOW SERIES FROM mydb.myrp1./c.*/ WHERE region = 'uswest' AND time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM mydb.myrp1./c.*/ WHERE region = 'uswest' AND time > 0`,
+			stmt: `SHOW FIELD KEY CARDINALITY FROM m GROUP BY region`,
+			s:    `SELECT count(distinct(_fieldKey)) AS count FROM m GROUP BY region`,
 		},
 		{
-			stmt: `SHOW SERIES ON db0 FROM mydb.myrp1./c.*/ WHERE time > 0`,
-			s:    `SELECT _seriesKey AS "key" FROM mydb.myrp1./c.*/ WHERE time > 0`,
+			stmt: `SHOW FIELD KEY EXACT CARDINALITY ON db0 FROM m WHERE region = 'uswest' AND time > 0`,
+			s:    `SHOW FIELD KEY EXACT CARDINALITY ON db0 WHERE (_name = 'm') AND (region = 'uswest' AND time > 0)`,
 		},
 		{
-			stmt: `SHOW SERIES CARDINALITY FROM m`,
-			s:    `SELECT count(distinct(_seriesKey)) AS count FROM m`,
+			stmt: `SHOW MEASUREMENT CARDINALITY`,
+			s:    `SHOW MEASUREMENT CARDINALITY`,
 		},
 		{
-			stmt: `SHOW SERIES EXACT CARDINALITY`,
-			s:    `SELECT count(distinct(_seriesKey)) AS count FROM /.+/`,
+			stmt: `SHOW MEASUREMENT EXACT CARDINALITY`,
+			s:    `SELECT count(distinct(_name)) AS count FROM /.+/`,
 		},
 		{
-			stmt: `SHOW SERIES EXACT CARDINALITY FROM m`,
-			s:    `SELECT count(distinct(_seriesKey)) AS count FROM m`,
+			stmt: `SHOW MEASUREMENT EXACT CARDINALITY ON db0 WHERE time > 0`,
+			s:    `SHOW MEASUREMENT EXACT CARDINALITY ON db0 WHERE time > 0`,
 		},
 		{
 			stmt: `SHOW TAG KEYS`,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/influxdata/influxdb/v2/influxql/query"
 	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/pkg/estimator"
 	"github.com/influxdata/influxdb/v2/tsdb"
 	"github.com/influxdata/influxql"
 	"go.uber.org/zap"
@@ -13,40 +14,46 @@ import (
 
 // TSDBStoreMock is a mockable implementation of tsdb.Store.
 type TSDBStoreMock struct {
-	BackupShardFn             func(id uint64, since time.Time, w io.Writer) error
-	BackupSeriesFileFn        func(database string, w io.Writer) error
-	ExportShardFn             func(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
-	CloseFn                   func() error
-	CreateShardFn             func(database, policy string, shardID uint64, enabled bool) error
-	CreateShardSnapshotFn     func(id uint64) (string, error)
-	DatabasesFn               func() []string
-	DeleteDatabaseFn          func(name string) error
-	DeleteMeasurementFn       func(database, name string) error
-	DeleteRetentionPolicyFn   func(database, name string) error
-	DeleteSeriesFn            func(database string, sources []influxql.Source, condition influxql.Expr) error
-	DeleteShardFn             func(id uint64) error
-	DiskSizeFn                func() (int64, error)
-	ExpandSourcesFn           func(sources influxql.Sources) (influxql.Sources, error)
-	ImportShardFn             func(id uint64, r io.Reader) error
-	MeasurementSeriesCountsFn func(database string) (measurements int, series int)
-	MeasurementsCardinalityFn func(database string) (int64, error)
-	MeasurementNamesFn        func(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error)
-	OpenFn                    func() error
-	PathFn                    func() string
-	RestoreShardFn            func(id uint64, r io.Reader) error
-	SeriesCardinalityFn       func(database string) (int64, error)
-	SetShardEnabledFn         func(shardID uint64, enabled bool) error
-	ShardFn                   func(id uint64) *tsdb.Shard
-	ShardGroupFn              func(ids []uint64) tsdb.ShardGroup
-	ShardIDsFn                func() []uint64
-	ShardNFn                  func() int
-	ShardRelativePathFn       func(id uint64) (string, error)
-	ShardsFn                  func(ids []uint64) []*tsdb.Shard
-	StatisticsFn              func(tags map[string]string) []models.Statistic
-	TagKeysFn                 func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
-	TagValuesFn               func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
-	WithLoggerFn              func(log *zap.Logger)
-	WriteToShardFn            func(shardID uint64, points []models.Point) error
+	BackupShardFn                  func(id uint64, since time.Time, w io.Writer) error
+	BackupSeriesFileFn             func(database string, w io.Writer) error
+	ExportShardFn                  func(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
+	CloseFn                        func() error
+	CreateShardFn                  func(database, policy string, shardID uint64, enabled bool) error
+	CreateShardSnapshotFn          func(id uint64) (string, error)
+	DatabasesFn                    func() []string
+	DeleteDatabaseFn               func(name string) error
+	DeleteMeasurementFn            func(database, name string) error
+	DeleteRetentionPolicyFn        func(database, name string) error
+	DeleteSeriesFn                 func(database string, sources []influxql.Source, condition influxql.Expr) error
+	DeleteShardFn                  func(id uint64) error
+	DiskSizeFn                     func() (int64, error)
+	ExpandSourcesFn                func(sources influxql.Sources) (influxql.Sources, error)
+	FieldKeysByShardsFn            func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error)
+	ImportShardFn                  func(id uint64, r io.Reader) error
+	MeasurementSeriesCountsFn      func(database string) (measurements int, series int)
+	MeasurementsCardinalityFn      func(database string) (int64, error)
+	MeasurementNamesFn             func(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error)
+	MeasurementNamesByShardsFn     func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error)
+	MeasurementsSketchesByShardsFn func(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
+	OpenFn                         func() error
+	PathFn                         func() string
+	RestoreShardFn                 func(id uint64, r io.Reader) error
+	SeriesCardinalityFn            func(database string) (int64, error)
+	SeriesCardinalityByShardsFn    func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.SeriesCardinality, error)
+	SeriesKeysByShardsFn           func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error)
+	SeriesSketchesByShardsFn       func(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
+	SetShardEnabledFn              func(shardID uint64, enabled bool) error
+	ShardFn                        func(id uint64) *tsdb.Shard
+	ShardGroupFn                   func(ids []uint64) tsdb.ShardGroup
+	ShardIDsFn                     func() []uint64
+	ShardNFn                       func() int
+	ShardRelativePathFn            func(id uint64) (string, error)
+	ShardsFn                       func(ids []uint64) []*tsdb.Shard
+	StatisticsFn                   func(tags map[string]string) []models.Statistic
+	TagKeysFn                      func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
+	TagValuesFn                    func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
+	WithLoggerFn                   func(log *zap.Logger)
+	WriteToShardFn                 func(shardID uint64, points []models.Point) error
 }
 
 func (s *TSDBStoreMock) BackupShard(id uint64, since time.Time, w io.Writer) error {
@@ -89,12 +96,21 @@ func (s *TSDBStoreMock) DiskSize() (int64, error) {
 func (s *TSDBStoreMock) ExpandSources(sources influxql.Sources) (influxql.Sources, error) {
 	return s.ExpandSourcesFn(sources)
 }
+func (s *TSDBStoreMock) FieldKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error) {
+	return s.FieldKeysByShardsFn(auth, shardIDs, cond)
+}
 func (s *TSDBStoreMock) ImportShard(id uint64, r io.Reader) error {
 	return s.ImportShardFn(id, r)
 }
 func (s *TSDBStoreMock) MeasurementNames(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error) {
 	return s.MeasurementNamesFn(auth, database, cond)
 }
+func (s *TSDBStoreMock) MeasurementNamesByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+	return s.MeasurementNamesByShardsFn(auth, shardIDs, cond)
+}
+func (s *TSDBStoreMock) MeasurementsSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error) {
+	return s.MeasurementsSketchesByShardsFn(shardIDs)
+}
 func (s *TSDBStoreMock) MeasurementSeriesCounts(database string) (measurements int, series int) {
 	return s.MeasurementSeriesCountsFn(database)
 }
@@ -113,6 +129,15 @@ func (s *TSDBStoreMock) RestoreShard(id uint64, r io.Reader) error {
 func (s *TSDBStoreMock) SeriesCardinality(database string) (int64, error) {
 	return s.SeriesCardinalityFn(database)
 }
+func (s *TSDBStoreMock) SeriesCardinalityByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.SeriesCardinality, error) {
+	return s.SeriesCardinalityByShardsFn(auth, shardIDs, cond)
+}
+func (s *TSDBStoreMock) SeriesKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error) {
+	return s.SeriesKeysByShardsFn(auth, shardIDs, cond)
+}
+func (s *TSDBStoreMock) SeriesSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error) {
+	return s.SeriesSketchesByShardsFn(shardIDs)
+}
 func (s *TSDBStoreMock) SetShardEnabled(shardID uint64, enabled bool) error {
 	return s.SetShardEnabledFn(shardID, enabled)
 }
@@ -164,6 +164,10 @@ func (s *Service) addTarget(ctx context.Context, tx Tx, target *influxdb.Scraper
 	}
 
 	target.ID = s.IDGenerator.ID()
+	target.BackfillSecretKeys()
+	if err := target.Valid(); err != nil {
+		return err
+	}
 	if err := s.putTarget(ctx, tx, target); err != nil {
 		return err
 	}
@@ -237,6 +241,10 @@ func (s *Service) updateTarget(ctx context.Context, tx Tx, update *influxdb.Scra
 	if !update.OrgID.Valid() {
 		update.OrgID = target.OrgID
 	}
+	update.BackfillSecretKeys()
+	if err := update.Valid(); err != nil {
+		return nil, err
+	}
 	target = update
 	return target, s.putTarget(ctx, tx, target)
 }
@@ -47,9 +47,11 @@ type kvTask struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Timezone        string                 `json:"timezone,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
 	Offset          influxdb.Duration      `json:"offset,omitempty"`
+	Upstream        []influxdb.ID          `json:"upstream,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	LatestSuccess   time.Time              `json:"latestSuccess,omitempty"`
@@ -72,9 +74,11 @@ func kvToInfluxTask(k *kvTask) *influxdb.Task {
 		Flux:            k.Flux,
 		Every:           k.Every,
 		Cron:            k.Cron,
+		Timezone:        k.Timezone,
 		LastRunStatus:   k.LastRunStatus,
 		LastRunError:    k.LastRunError,
 		Offset:          k.Offset.Duration,
+		Upstream:        k.Upstream,
 		LatestCompleted: k.LatestCompleted,
 		LatestScheduled: k.LatestScheduled,
 		LatestSuccess:   k.LatestSuccess,
@@ -493,9 +497,14 @@ func (s *Service) createTask(ctx context.Context, tx Tx, org *influxdb.Organizat
 		tc.Status = string(influxdb.TaskActive)
 	}
 
+	id := s.IDGenerator.ID()
+	if err := s.validateUpstream(ctx, tx, id, org.ID, tc.Upstream); err != nil {
+		return nil, err
+	}
+
 	createdAt := s.clock.Now().Truncate(time.Second).UTC()
 	task := &influxdb.Task{
-		ID:              s.IDGenerator.ID(),
+		ID:              id,
 		Type:            tc.Type,
 		OrganizationID:  org.ID,
 		Organization:    org.Name,
@@ -507,6 +516,8 @@ func (s *Service) createTask(ctx context.Context, tx Tx, org *influxdb.Organizat
 		Flux:            tc.Flux,
 		Every:           opts.Every.String(),
 		Cron:            opts.Cron,
+		Timezone:        opts.Timezone,
+		Upstream:        tc.Upstream,
 		CreatedAt:       createdAt,
 		LatestCompleted: createdAt,
 		LatestScheduled: createdAt,
@@ -592,6 +603,35 @@ func (s *Service) UpdateTask(ctx context.Context, id influxdb.ID, upd influxdb.T
 	return t, nil
 }
 
+// validateUpstream returns an error if the task id of the organization orgID
+// can't depend on the upstream tasks.
+func (s *Service) validateUpstream(ctx context.Context, tx Tx, id, orgID influxdb.ID, upstream []influxdb.ID) error {
+	for _, up := range upstream {
+		t, err := s.findTaskByID(ctx, tx, up)
+		if err == influxdb.ErrTaskNotFound {
+			return influxdb.ErrInvalidTaskUpstream(fmt.Sprintf("task %s not found", up))
+		}
+		if err != nil {
+			return err
+		}
+		if t.OrganizationID != orgID {
+			return influxdb.ErrInvalidTaskUpstream(fmt.Sprintf("task %s belongs to another organization", up))
+		}
+	}
+
+	return influxdb.ValidateTaskUpstream(id, upstream, func(id influxdb.ID) ([]influxdb.ID, error) {
+		t, err := s.findTaskByID(ctx, tx, id)
+		if err == influxdb.ErrTaskNotFound {
+			// A deleted upstream task doesn't depend on anything.
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return t.Upstream, nil
+	})
+}
+
 func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd influxdb.TaskUpdate) (*influxdb.Task, error) {
 	// retrieve the task
 	task, err := s.findTaskByID(ctx, tx, id)
@@ -615,6 +655,7 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		task.Name = opts.Name
 		task.Every = opts.Every.String()
 		task.Cron = opts.Cron
+		task.Timezone = opts.Timezone
 
 		var off time.Duration
 		if opts.Offset != nil {
@@ -644,6 +685,14 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		}
 	}
 
+	if upd.Upstream != nil {
+		if err := s.validateUpstream(ctx, tx, task.ID, task.OrganizationID, *upd.Upstream); err != nil {
+			return nil, err
+		}
+		task.Upstream = *upd.Upstream
+		task.UpdatedAt = updatedAt
+	}
+
 	if upd.Metadata != nil {
 		task.Metadata = upd.Metadata
 		task.UpdatedAt = updatedAt
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.BackfillService = (*BackfillService)(nil)
+
+// BackfillService is a mock implementation of influxdb.BackfillService.
+type BackfillService struct {
+	CreateBackfillFn   func(ctx context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error)
+	FindBackfillsFn    func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error)
+	FindBackfillByIDFn func(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error)
+	CancelBackfillFn   func(ctx context.Context, taskID, id influxdb.ID) error
+}
+
+// CreateBackfill starts a backfill of a task.
+func (s *BackfillService) CreateBackfill(ctx context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error) {
+	return s.CreateBackfillFn(ctx, taskID, start, end)
+}
+
+// FindBackfills returns the backfills of a task.
+func (s *BackfillService) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	return s.FindBackfillsFn(ctx, taskID)
+}
+
+// FindBackfillByID returns a single backfill.
+func (s *BackfillService) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	return s.FindBackfillByIDFn(ctx, taskID, id)
+}
+
+// CancelBackfill stops a backfill.
+func (s *BackfillService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) error {
+	return s.CancelBackfillFn(ctx, taskID, id)
+}
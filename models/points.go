@@ -356,16 +356,36 @@ func ValidPrecision(precision string) bool {
 // NOTE: to minimize heap allocations, the returned Points will refer to subslices of buf.
 // This can have the unintended effect preventing buf from being garbage collected.
 func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision string) ([]Point, error) {
+	points, failed := ParsePointsWithLineErrors(buf, defaultTime, precision)
+	if len(failed) > 0 {
+		return points, fmt.Errorf("%s", failed.Error())
+	}
+	return points, nil
+}
+
+// ParsePointsWithLineErrors is similar to ParsePointsWithPrecision, but reports
+// every line that failed to parse along with its 1-based line number. Points
+// parsed from the remaining lines are always returned.
+func ParsePointsWithLineErrors(buf []byte, defaultTime time.Time, precision string) ([]Point, LineErrors) {
 	points := make([]Point, 0, bytes.Count(buf, []byte{'\n'})+1)
 	var (
 		pos    int
 		block  []byte
-		failed []string
+		line   = 1
+		failed LineErrors
 	)
 	for pos < len(buf) {
+		begin, lineNo := pos, line
 		pos, block = scanLine(buf, pos)
 		pos++
 
+		// quoted string fields may span several physical lines
+		end := pos
+		if end > len(buf) {
+			end = len(buf)
+		}
+		line += bytes.Count(buf[begin:end], []byte{'\n'})
+
 		if len(block) == 0 {
 			continue
 		}
@@ -389,17 +409,43 @@ func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision strin
 
 		pt, err := parsePoint(block[start:], defaultTime, precision)
 		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
+			failed = append(failed, &LineError{
+				Line: lineNo,
+				Text: string(block[start:]),
+				Err:  err,
+			})
 		} else {
 			points = append(points, pt)
 		}
 
 	}
-	if len(failed) > 0 {
-		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
-	}
-	return points, nil
+	return points, failed
+}
+
+// LineError describes a single line of line protocol that could not be parsed.
+type LineError struct {
+	Line int    // 1-based line number within the parsed buffer
+	Text string // the rejected line
+	Err  error  // the reason the line was rejected
+}
 
+func (e *LineError) Error() string {
+	return fmt.Sprintf("unable to parse '%s': %v", e.Text, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// LineErrors is a list of lines that failed to parse.
+type LineErrors []*LineError
+
+func (e LineErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, le := range e {
+		msgs = append(msgs, le.Error())
+	}
+	return strings.Join(msgs, "\n")
 }
 
 func parsePoint(buf []byte, defaultTime time.Time, precision string) (Point, error) {
@@ -1177,6 +1223,23 @@ func scanLine(buf []byte, i int) (int, []byte) {
 	return i, buf[start:i]
 }
 
+// CompleteLinesLen returns the length of the longest prefix of buf made up of
+// complete lines of line protocol, that is up to and including the last newline
+// which does not belong to a quoted string field. It returns 0 if buf does not
+// contain a complete line.
+func CompleteLinesLen(buf []byte) int {
+	var pos, n int
+	for pos < len(buf) {
+		pos, _ = scanLine(buf, pos)
+		if pos >= len(buf) {
+			break
+		}
+		pos++
+		n = pos
+	}
+	return n
+}
+
 // scanTo returns the end position in buf and the next consecutive block
 // of bytes, starting from i and ending with stop byte, where stop byte
 // has not been escaped.
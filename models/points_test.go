@@ -1949,6 +1949,39 @@ cpu,host=serverA,region=us-east value=1.0 946730096789012345`,
 	}
 }
 
+func TestParsePointsWithPrecisionLineErrors(t *testing.T) {
+	batch := "# comment\n" +
+		"cpu value=1 1\n" +
+		"invalid\n" +
+		"cpu value=\"multi\nline\" 2\n" +
+		"\n" +
+		"cpu value= 3\n" +
+		"cpu value=4 4"
+
+	pts, lerrs := models.ParsePointsWithLineErrors([]byte(batch), time.Now().UTC(), "")
+	if got, exp := len(pts), 3; got != exp {
+		t.Fatalf("ParsePoints() len mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := len(lerrs), 2; got != exp {
+		t.Fatalf("LineErrors len mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := lerrs[0].Line, 3; got != exp {
+		t.Errorf("line mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := lerrs[0].Text, "invalid"; got != exp {
+		t.Errorf("text mismatch: got %v, exp %v", got, exp)
+	}
+	// the quoted newline in the string field counts as a line
+	if got, exp := lerrs[1].Line, 7; got != exp {
+		t.Errorf("line mismatch: got %v, exp %v", got, exp)
+	}
+
+	exp := "unable to parse 'invalid': missing fields\nunable to parse 'cpu value= 3': missing field value"
+	if got := lerrs.Error(); got != exp {
+		t.Errorf("error mismatch:\n got %v\n exp %v", got, exp)
+	}
+}
+
 func TestNewPointEscaped(t *testing.T) {
 	// commas
 	pt := models.MustNewPoint("cpu,main", models.NewTags(map[string]string{"tag,bar": "value"}), models.Fields{"name,bar": 1.0}, time.Unix(0, 0))
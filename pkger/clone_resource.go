@@ -56,11 +56,12 @@ var kindPriorities = map[Kind]int{
 	KindNotificationEndpointHTTP:      7,
 	KindNotificationEndpointPagerDuty: 8,
 	KindNotificationEndpointSlack:     9,
-	KindNotificationRule:              10,
-	KindTask:                          11,
-	KindVariable:                      12,
-	KindDashboard:                     13,
-	KindTelegraf:                      14,
+	KindNotificationEndpointTelegram:  10,
+	KindNotificationRule:              11,
+	KindTask:                          12,
+	KindVariable:                      13,
+	KindDashboard:                     14,
+	KindTelegraf:                      15,
 }
 
 type exportKey struct {
@@ -315,7 +316,8 @@ func (ex *resourceExporter) resourceCloneToKind(ctx context.Context, r ResourceT
 	case r.Kind.is(KindNotificationEndpoint),
 		r.Kind.is(KindNotificationEndpointHTTP),
 		r.Kind.is(KindNotificationEndpointPagerDuty),
-		r.Kind.is(KindNotificationEndpointSlack):
+		r.Kind.is(KindNotificationEndpointSlack),
+		r.Kind.is(KindNotificationEndpointTelegram):
 		var endpoints []influxdb.NotificationEndpoint
 
 		switch {
@@ -1262,6 +1264,12 @@ func NotificationEndpointToObject(name string, e influxdb.NotificationEndpoint)
 		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
 			fieldNotificationEndpointToken: actual.Token,
 		})
+	case *endpoint.Telegram:
+		o.Kind = KindNotificationEndpointTelegram
+		o.Spec[fieldNotificationEndpointChannel] = actual.Channel
+		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointToken: actual.Token,
+		})
 	}
 
 	return o
@@ -1322,6 +1330,13 @@ func NotificationRuleToObject(name, endpointPkgName string, iRule influxdb.Notif
 		assignBase(t.Base)
 		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
 		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationRuleChannel: t.Channel})
+	case *rule.Telegram:
+		assignBase(t.Base)
+		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationRuleParseMode: t.ParseMode})
+		if t.DisableWebPagePreview {
+			o.Spec[fieldNotificationRuleDisableWebPagePreview] = true
+		}
 	}
 
 	return o
@@ -425,7 +425,8 @@ func stackResLinks(r StackResource) RespStackResourceLinks {
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		linkResource = "notificationEndpoints"
 	case KindNotificationRule:
 		linkResource = "notificationRules"
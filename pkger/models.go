@@ -24,6 +24,7 @@ const (
 	KindNotificationEndpointHTTP      Kind = "NotificationEndpointHTTP"
 	KindNotificationEndpointPagerDuty Kind = "NotificationEndpointPagerDuty"
 	KindNotificationEndpointSlack     Kind = "NotificationEndpointSlack"
+	KindNotificationEndpointTelegram  Kind = "NotificationEndpointTelegram"
 	KindNotificationRule              Kind = "NotificationRule"
 	KindPackage                       Kind = "Package"
 	KindTask                          Kind = "Task"
@@ -51,6 +52,7 @@ var kinds = map[Kind]bool{
 	KindNotificationEndpointHTTP:      true,
 	KindNotificationEndpointPagerDuty: true,
 	KindNotificationEndpointSlack:     true,
+	KindNotificationEndpointTelegram:  true,
 	KindNotificationRule:              true,
 	KindTask:                          true,
 	KindTelegraf:                      true,
@@ -96,7 +98,8 @@ func (k Kind) ResourceType() influxdb.ResourceType {
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		return influxdb.NotificationEndpointResourceType
 	case KindNotificationRule:
 		return influxdb.NotificationRuleResourceType
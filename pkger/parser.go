@@ -500,7 +500,8 @@ func (p *Template) Contains(k Kind, pkgName string) bool {
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		_, ok := p.mNotificationEndpoints[pkgName]
 		return ok
 	case KindNotificationRule:
@@ -994,6 +995,10 @@ func (p *Template) graphNotificationEndpoints() *parseErr {
 			kind:             KindNotificationEndpointSlack,
 			notificationKind: notificationKindSlack,
 		},
+		{
+			kind:             KindNotificationEndpointTelegram,
+			notificationKind: notificationKindTelegram,
+		},
 	}
 
 	var pErr parseErr
@@ -1007,6 +1012,7 @@ func (p *Template) graphNotificationEndpoints() *parseErr {
 			endpoint := &notificationEndpoint{
 				kind:        nk.notificationKind,
 				identity:    ident,
+				channel:     o.Spec.stringShort(fieldNotificationEndpointChannel),
 				description: o.Spec.stringShort(fieldDescription),
 				method:      strings.TrimSpace(strings.ToUpper(o.Spec.stringShort(fieldNotificationEndpointHTTPMethod))),
 				httpType:    normStr(o.Spec.stringShort(fieldType)),
@@ -1064,6 +1070,9 @@ func (p *Template) graphNotificationRules() *parseErr {
 			msgTemplate:  o.Spec.stringShort(fieldNotificationRuleMessageTemplate),
 			offset:       o.Spec.durationShort(fieldOffset),
 			status:       normStr(o.Spec.stringShort(fieldStatus)),
+
+			parseMode:             o.Spec.stringShort(fieldNotificationRuleParseMode),
+			disableWebPagePreview: o.Spec.boolShort(fieldNotificationRuleDisableWebPagePreview),
 		}
 
 		for _, sRule := range o.Spec.slcResource(fieldNotificationRuleStatusRules) {
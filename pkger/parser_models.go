@@ -1560,14 +1560,16 @@ const (
 	notificationKindHTTP notificationEndpointKind = iota + 1
 	notificationKindPagerDuty
 	notificationKindSlack
+	notificationKindTelegram
 )
 
 func (n notificationEndpointKind) String() string {
-	if n > 0 && n < 4 {
+	if n > 0 && n < 5 {
 		return [...]string{
 			endpoint.HTTPType,
 			endpoint.PagerDutyType,
 			endpoint.SlackType,
+			endpoint.TelegramType,
 		}[n-1]
 	}
 	return ""
@@ -1580,6 +1582,7 @@ const (
 )
 
 const (
+	fieldNotificationEndpointChannel    = "channel"
 	fieldNotificationEndpointHTTPMethod = "method"
 	fieldNotificationEndpointPassword   = "password"
 	fieldNotificationEndpointRoutingKey = "routingKey"
@@ -1592,6 +1595,7 @@ type notificationEndpoint struct {
 	identity
 
 	kind        notificationEndpointKind
+	channel     string
 	description string
 	method      string
 	password    *references
@@ -1665,6 +1669,13 @@ func (n *notificationEndpoint) summarize() SummaryNotificationEndpoint {
 			URL:   n.url,
 			Token: n.token.SecretField(),
 		}
+	case notificationKindTelegram:
+		sum.Kind = KindNotificationEndpointTelegram
+		sum.NotificationEndpoint = &endpoint.Telegram{
+			Base:    base,
+			Token:   n.token.SecretField(),
+			Channel: n.channel,
+		}
 	}
 	return sum
 }
@@ -1693,7 +1704,8 @@ func (n *notificationEndpoint) valid() []validationErr {
 		failures = append(failures, err)
 	}
 
-	if _, err := url.Parse(n.url); err != nil || n.url == "" {
+	// telegram endpoints talk to the bot API and have no url of their own
+	if _, err := url.Parse(n.url); n.kind != notificationKindTelegram && (err != nil || n.url == "") {
 		failures = append(failures, validationErr{
 			Field: fieldNotificationEndpointURL,
 			Msg:   "must be valid url",
@@ -1716,6 +1728,19 @@ func (n *notificationEndpoint) valid() []validationErr {
 				Msg:   "must be provide",
 			})
 		}
+	case notificationKindTelegram:
+		if !n.token.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointToken,
+				Msg:   "must provide non empty string",
+			})
+		}
+		if n.channel == "" {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointChannel,
+				Msg:   "must provide non empty string",
+			})
+		}
 	case notificationKindHTTP:
 		if !validEndpointHTTPMethods[n.method] {
 			failures = append(failures, validationErr{
@@ -1770,13 +1795,15 @@ func (n *notificationEndpoint) valid() []validationErr {
 }
 
 const (
-	fieldNotificationRuleChannel         = "channel"
-	fieldNotificationRuleCurrentLevel    = "currentLevel"
-	fieldNotificationRuleEndpointName    = "endpointName"
-	fieldNotificationRuleMessageTemplate = "messageTemplate"
-	fieldNotificationRulePreviousLevel   = "previousLevel"
-	fieldNotificationRuleStatusRules     = "statusRules"
-	fieldNotificationRuleTagRules        = "tagRules"
+	fieldNotificationRuleChannel               = "channel"
+	fieldNotificationRuleCurrentLevel          = "currentLevel"
+	fieldNotificationRuleDisableWebPagePreview = "disableWebPagePreview"
+	fieldNotificationRuleEndpointName          = "endpointName"
+	fieldNotificationRuleMessageTemplate       = "messageTemplate"
+	fieldNotificationRuleParseMode             = "parseMode"
+	fieldNotificationRulePreviousLevel         = "previousLevel"
+	fieldNotificationRuleStatusRules           = "statusRules"
+	fieldNotificationRuleTagRules              = "tagRules"
 )
 
 type notificationRule struct {
@@ -1791,6 +1818,10 @@ type notificationRule struct {
 	statusRules []struct{ curLvl, prevLvl string }
 	tagRules    []struct{ k, v, op string }
 
+	// telegram specific fields
+	parseMode             string
+	disableWebPagePreview bool
+
 	associatedEndpoint *notificationEndpoint
 	endpointName       *references
 
@@ -1893,6 +1924,13 @@ func (r *notificationRule) toInfluxRule() influxdb.NotificationRule {
 			Channel:         r.channel,
 			MessageTemplate: r.msgTemplate,
 		}
+	case notificationKindTelegram:
+		return &rule.Telegram{
+			Base:                  base,
+			MessageTemplate:       r.msgTemplate,
+			ParseMode:             r.parseMode,
+			DisableWebPagePreview: r.disableWebPagePreview,
+		}
 	}
 	return nil
 }
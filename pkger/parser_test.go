@@ -17,6 +17,7 @@ import (
 	"github.com/influxdata/influxdb/v2/notification"
 	icheck "github.com/influxdata/influxdb/v2/notification/check"
 	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -2804,6 +2805,38 @@ spec:
 			})
 		})
 
+		t.Run("telegram endpoint and rule should be successful", func(t *testing.T) {
+			testfileRunner(t, "testdata/notification_endpoint_telegram.yml", func(t *testing.T, template *Template) {
+				sum := template.Summary()
+
+				require.Len(t, sum.NotificationEndpoints, 1)
+				actualEndpoint := sum.NotificationEndpoints[0]
+				assert.Equal(t, KindNotificationEndpointTelegram, actualEndpoint.Kind)
+				expectedEndpoint := &endpoint.Telegram{
+					Base: endpoint.Base{
+						Name:        "telegram name",
+						Description: "telegram desc",
+						Status:      influxdb.TaskStatusActive,
+					},
+					Token:   influxdb.SecretField{Key: "telegram-token"},
+					Channel: "-1001406363649",
+				}
+				assert.Equal(t, expectedEndpoint, actualEndpoint.NotificationEndpoint)
+				assert.Contains(t, template.mSecrets, "telegram-token")
+
+				require.Len(t, sum.NotificationRules, 1)
+				actualRule := sum.NotificationRules[0]
+				assert.Equal(t, "telegram-notification-endpoint", actualRule.EndpointMetaName)
+				assert.Equal(t, endpoint.TelegramType, actualRule.EndpointType)
+				assert.Equal(t, "${ r._message }", actualRule.MessageTemplate)
+
+				influxRule, ok := template.mNotificationRules["telegram-rule"].toInfluxRule().(*rule.Telegram)
+				require.True(t, ok)
+				assert.Equal(t, "MarkdownV2", influxRule.ParseMode)
+				assert.True(t, influxRule.DisableWebPagePreview)
+			})
+		})
+
 		t.Run("with env refs should be valid", func(t *testing.T) {
 			testfileRunner(t, "testdata/notification_endpoint_ref.yml", func(t *testing.T, template *Template) {
 				actual := template.Summary().NotificationEndpoints
@@ -2859,6 +2892,36 @@ kind: NotificationEndpointPagerDuty
 metadata:
   name: pager-duty-notification-endpoint
 spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointTelegram,
+					resErr: testTemplateResourceError{
+						name:           "missing telegram channel",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointChannel},
+						templateStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointTelegram
+metadata:
+  name: telegram-notification-endpoint
+spec:
+  token: bot-token
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointTelegram,
+					resErr: testTemplateResourceError{
+						name:           "missing telegram token",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointToken},
+						templateStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointTelegram
+metadata:
+  name: telegram-notification-endpoint
+spec:
+  channel: "-1001406363649"
 `,
 					},
 				},
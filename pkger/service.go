@@ -1458,7 +1458,8 @@ func ApplyWithResourceSkip(action ActionSkipResource) ApplyOptFn {
 			action.Kind = KindCheck
 		case KindNotificationEndpointHTTP,
 			KindNotificationEndpointPagerDuty,
-			KindNotificationEndpointSlack:
+			KindNotificationEndpointSlack,
+			KindNotificationEndpointTelegram:
 			action.Kind = KindNotificationEndpoint
 		}
 		opt.ResourcesToSkip[action] = true
@@ -1476,7 +1477,8 @@ func ApplyWithKindSkip(action ActionSkipKind) ApplyOptFn {
 			action.Kind = KindCheck
 		case KindNotificationEndpointHTTP,
 			KindNotificationEndpointPagerDuty,
-			KindNotificationEndpointSlack:
+			KindNotificationEndpointSlack,
+			KindNotificationEndpointTelegram:
 			action.Kind = KindNotificationEndpoint
 		}
 		opt.KindsToSkip[action.Kind] = true
@@ -2389,6 +2391,8 @@ func (s *Service) rollbackNotificationRules(ctx context.Context, userID influxdb
 				rr.EndpointID = endpointID
 			case *rule.Slack:
 				rr.EndpointID = endpointID
+			case *rule.Telegram:
+				rr.EndpointID = endpointID
 			}
 			return r.existing
 		}
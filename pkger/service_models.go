@@ -520,7 +520,8 @@ func (s *stateCoordinator) get(k Kind, metaName string) (interface{}, bool) {
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		v, ok := s.mEndpoints[metaName]
 		return v, ok
 	case KindNotificationRule:
@@ -603,7 +604,8 @@ func (s *stateCoordinator) addObjectForRemoval(k Kind, metaName string, id influ
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		s.mEndpoints[metaName] = &stateEndpoint{
 			id:             id,
 			parserEndpoint: &notificationEndpoint{identity: newIdentity},
@@ -665,7 +667,8 @@ func (s *stateCoordinator) getObjectIDSetter(k Kind, metaName string) (func(infl
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointTelegram:
 		r, ok := s.mEndpoints[metaName]
 		return func(id influxdb.ID) {
 			r.id = id
@@ -1265,6 +1268,9 @@ func (r *stateRule) diffRule() DiffNotificationRule {
 	case *rule.PagerDuty:
 		assignBase(p.Base)
 		sum.Old.MessageTemplate = p.MessageTemplate
+	case *rule.Telegram:
+		assignBase(p.Base)
+		sum.Old.MessageTemplate = p.MessageTemplate
 	}
 
 	return sum
@@ -1334,6 +1340,8 @@ func (r *stateRule) toInfluxRule() influxdb.NotificationRule {
 		e.EndpointID = r.associatedEndpoint.ID()
 	case *rule.Slack:
 		e.EndpointID = r.associatedEndpoint.ID()
+	case *rule.Telegram:
+		e.EndpointID = r.associatedEndpoint.ID()
 	}
 
 	return influxRule
@@ -3345,6 +3345,18 @@ func TestService(t *testing.T) {
 							URL:        "http://example.com",
 						},
 					},
+					{
+						name: "telegram",
+						expected: &endpoint.Telegram{
+							Base: endpoint.Base{
+								Name:        "tg-endpoint",
+								Description: "desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							Token:   influxdb.SecretField{Key: "-token"},
+							Channel: "-1001406363649",
+						},
+					},
 				}
 
 				for _, tt := range tests {
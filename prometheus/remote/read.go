@@ -0,0 +1,354 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+)
+
+// DecodeReadRequest decodes a snappy compressed, protobuf encoded
+// ReadRequest. When maxBytes is positive, bodies which decompress to
+// more than maxBytes are rejected with ErrDecodedTooLarge.
+func DecodeReadRequest(compressed []byte, maxBytes int64) (*ReadRequest, error) {
+	b, err := decompress(compressed, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var req ReadRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return nil, fmt.Errorf("decoding read request: %v", err)
+	}
+	return &req, nil
+}
+
+// EncodeReadRequest encodes a ReadRequest as sent by Prometheus.
+func EncodeReadRequest(req *ReadRequest) ([]byte, error) {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, b), nil
+}
+
+// DecodeReadResponse decodes a snappy compressed, protobuf encoded
+// ReadResponse.
+func DecodeReadResponse(compressed []byte) (*ReadResponse, error) {
+	b, err := decompress(compressed, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ReadResponse
+	if err := proto.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("decoding read response: %v", err)
+	}
+	return &resp, nil
+}
+
+// EncodeReadResponse encodes a ReadResponse as expected by Prometheus.
+func EncodeReadResponse(resp *ReadResponse) ([]byte, error) {
+	b, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, b), nil
+}
+
+// TimeRange returns the range of the query in nanoseconds. The end of a
+// query is inclusive, while the end of a storage range is exclusive.
+func (q *Query) TimeRange() datatypes.TimestampRange {
+	return datatypes.TimestampRange{
+		Start: q.StartTimestampMs * int64(time.Millisecond),
+		End:   (q.EndTimestampMs + 1) * int64(time.Millisecond),
+	}
+}
+
+// Predicate translates the matchers of the query to a storage predicate.
+// The __name__ label is the measurement, and other labels are tags. The
+// value field is selected, as written by remote write, as well as the sum
+// and count fields of histograms and summaries, which are read back as the
+// <name>_sum and <name>_count metrics. Histogram buckets and summary
+// quantiles, which are stored as fields named after their bound, are not
+// returned.
+//
+// Only equality matchers on __name__ translate exactly to a measurement and
+// field; ReadSeries checks the other matchers on __name__ against the
+// series read.
+func (q *Query) Predicate() (*datatypes.Predicate, error) {
+	var children []*datatypes.Node
+	named := false
+	for _, m := range q.Matchers {
+		if m.Name == nameLabel {
+			named = true
+			if m.Type == LabelMatcher_EQ {
+				measurement, field := metricField(m.Value)
+				children = append(children,
+					comparisonNode(datatypes.ComparisonEqual, models.MeasurementTagKey, stringNode(measurement)),
+					comparisonNode(datatypes.ComparisonEqual, models.FieldKeyTagKey, stringNode(field)),
+				)
+				continue
+			}
+		}
+
+		n, err := matcherNode(m)
+		if err != nil {
+			return nil, err
+		}
+		if m.Name == nameLabel {
+			// the measurement of the sum and count fields is the name
+			// of the metric without its suffix, so only the fields are
+			// selected for them.
+			n = parenNode(logicalNode(datatypes.LogicalOr, append([]*datatypes.Node{
+				logicalNode(datatypes.LogicalAnd, []*datatypes.Node{
+					comparisonNode(datatypes.ComparisonEqual, models.FieldKeyTagKey, stringNode(valueField)),
+					n,
+				}),
+			}, familyFieldNodes()...)))
+		}
+		children = append(children, n)
+	}
+	if !named {
+		fields := append([]*datatypes.Node{
+			comparisonNode(datatypes.ComparisonEqual, models.FieldKeyTagKey, stringNode(valueField)),
+		}, familyFieldNodes()...)
+		children = append([]*datatypes.Node{parenNode(logicalNode(datatypes.LogicalOr, fields))}, children...)
+	}
+
+	return &datatypes.Predicate{
+		Root: logicalNode(datatypes.LogicalAnd, children),
+	}, nil
+}
+
+// familyFieldNodes returns a comparison selecting each of the sum and
+// count fields.
+func familyFieldNodes() []*datatypes.Node {
+	nodes := make([]*datatypes.Node, 0, len(familyFields))
+	for _, f := range familyFields {
+		nodes = append(nodes, comparisonNode(datatypes.ComparisonEqual, models.FieldKeyTagKey, stringNode(f.field)))
+	}
+	return nodes
+}
+
+// nameFilter returns a function reporting whether a metric name matches
+// the matchers of the query on __name__.
+func (q *Query) nameFilter() (func(string) bool, error) {
+	var filters []func(string) bool
+	for _, m := range q.Matchers {
+		if m.Name != nameLabel {
+			continue
+		}
+		v := m.Value
+		switch m.Type {
+		case LabelMatcher_EQ:
+			filters = append(filters, func(name string) bool { return name == v })
+		case LabelMatcher_NEQ:
+			filters = append(filters, func(name string) bool { return name != v })
+		case LabelMatcher_RE, LabelMatcher_NRE:
+			re, err := regexp.Compile("^(?:" + v + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression for label %q: %v", m.Name, err)
+			}
+			match := m.Type == LabelMatcher_RE
+			filters = append(filters, func(name string) bool { return re.MatchString(name) == match })
+		default:
+			return nil, fmt.Errorf("unknown matcher type %d for label %q", m.Type, m.Name)
+		}
+	}
+	return func(name string) bool {
+		for _, f := range filters {
+			if !f(name) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func matcherNode(m *LabelMatcher) (*datatypes.Node, error) {
+	key := m.Name
+	if key == nameLabel {
+		key = models.MeasurementTagKey
+	}
+
+	switch m.Type {
+	case LabelMatcher_EQ:
+		return comparisonNode(datatypes.ComparisonEqual, key, stringNode(m.Value)), nil
+	case LabelMatcher_NEQ:
+		return comparisonNode(datatypes.ComparisonNotEqual, key, stringNode(m.Value)), nil
+	case LabelMatcher_RE, LabelMatcher_NRE:
+		// Prometheus regular expressions match the whole label value.
+		expr := "^(?:" + m.Value + ")$"
+		if _, err := regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid regular expression for label %q: %v", m.Name, err)
+		}
+		op := datatypes.ComparisonRegex
+		if m.Type == LabelMatcher_NRE {
+			op = datatypes.ComparisonNotRegex
+		}
+		return comparisonNode(op, key, &datatypes.Node{
+			NodeType: datatypes.NodeTypeLiteral,
+			Value:    &datatypes.Node_RegexValue{RegexValue: expr},
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown matcher type %d for label %q", m.Type, m.Name)
+	}
+}
+
+func comparisonNode(op datatypes.Node_Comparison, key string, value *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: op},
+		Children: []*datatypes.Node{
+			{
+				NodeType: datatypes.NodeTypeTagRef,
+				Value:    &datatypes.Node_TagRefValue{TagRefValue: key},
+			},
+			value,
+		},
+	}
+}
+
+func logicalNode(op datatypes.Node_Logical, children []*datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeLogicalExpression,
+		Value:    &datatypes.Node_Logical_{Logical: op},
+		Children: children,
+	}
+}
+
+func parenNode(n *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeParenExpression,
+		Children: []*datatypes.Node{n},
+	}
+}
+
+func stringNode(v string) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeLiteral,
+		Value:    &datatypes.Node_StringValue{StringValue: v},
+	}
+}
+
+// ErrSampleLimit is returned by ReadSeries when the result set holds more
+// samples than allowed.
+var ErrSampleLimit = errors.New("remote read sample limit exceeded")
+
+// ReadSeries reads the series of the result set of q into time series,
+// with the metric name of the measurement and field as the __name__ label
+// and the tags as other labels. Series whose name does not match the
+// matchers of q on __name__ are skipped. Integer and unsigned values are
+// converted to floats; series of other types are skipped. Result sets
+// holding more than maxSamples samples are rejected with ErrSampleLimit,
+// unless maxSamples is negative. The result set is closed.
+func ReadSeries(rs reads.ResultSet, q *Query, maxSamples int) ([]*TimeSeries, error) {
+	defer rs.Close()
+
+	matchName, err := q.nameFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	// remaining is the number of samples left to read, or negative if
+	// unlimited.
+	remaining := maxSamples
+
+	var series []*TimeSeries
+	for rs.Next() {
+		name, labels := seriesLabels(rs.Tags())
+		if !matchName(name) {
+			continue
+		}
+
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for the series in the range of the query
+			continue
+		}
+
+		samples, err := readSamples(cur, remaining)
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		if remaining >= 0 {
+			remaining -= len(samples)
+		}
+		series = append(series, &TimeSeries{
+			Labels:  labels,
+			Samples: samples,
+		})
+	}
+	return series, rs.Err()
+}
+
+// seriesLabels returns the metric name of a series of the storage engine
+// and its labels, sorted by name as Prometheus expects.
+func seriesLabels(tags models.Tags) (string, []Label) {
+	var measurement, field string
+	labels := make([]Label, 0, len(tags))
+	for _, t := range tags {
+		switch string(t.Key) {
+		case datatypes.MeasurementKey, models.MeasurementTagKey:
+			measurement = string(t.Value)
+		case datatypes.FieldKey, models.FieldKeyTagKey:
+			field = string(t.Value)
+		default:
+			labels = append(labels, Label{Name: string(t.Key), Value: string(t.Value)})
+		}
+	}
+	name := metricName(measurement, field)
+	labels = append(labels, Label{Name: nameLabel, Value: name})
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return name, labels
+}
+
+// readSamples reads the samples of a cursor, failing with ErrSampleLimit
+// once there are more than max samples, unless max is negative.
+func readSamples(cur cursors.Cursor, max int) ([]Sample, error) {
+	defer cur.Close()
+
+	var samples []Sample
+	switch c := cur.(type) {
+	case cursors.FloatArrayCursor:
+		for a := c.Next(); a.Len() > 0; a = c.Next() {
+			if max >= 0 && len(samples)+a.Len() > max {
+				return nil, ErrSampleLimit
+			}
+			for i, ts := range a.Timestamps {
+				samples = append(samples, Sample{Value: a.Values[i], Timestamp: ts / int64(time.Millisecond)})
+			}
+		}
+	case cursors.IntegerArrayCursor:
+		for a := c.Next(); a.Len() > 0; a = c.Next() {
+			if max >= 0 && len(samples)+a.Len() > max {
+				return nil, ErrSampleLimit
+			}
+			for i, ts := range a.Timestamps {
+				samples = append(samples, Sample{Value: float64(a.Values[i]), Timestamp: ts / int64(time.Millisecond)})
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		for a := c.Next(); a.Len() > 0; a = c.Next() {
+			if max >= 0 && len(samples)+a.Len() > max {
+				return nil, ErrSampleLimit
+			}
+			for i, ts := range a.Timestamps {
+				samples = append(samples, Sample{Value: float64(a.Values[i]), Timestamp: ts / int64(time.Millisecond)})
+			}
+		}
+	}
+	return samples, cur.Err()
+}
@@ -0,0 +1,366 @@
+package remote_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+)
+
+func TestQuery_Predicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		matchers []*remote.LabelMatcher
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "no matchers",
+			want: `( '\xff' = "value" OR '\xff' = "sum" OR '\xff' = "count" )`,
+		},
+		{
+			name: "equality",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_EQ, Name: "__name__", Value: "go_goroutines"},
+				{Type: remote.LabelMatcher_EQ, Name: "job", Value: "api"},
+			},
+			want: `'\x00' = "go_goroutines" AND '\xff' = "value" AND 'job' = "api"`,
+		},
+		{
+			name: "equality on the sum of a family",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_EQ, Name: "__name__", Value: "http_request_duration_seconds_sum"},
+			},
+			want: `'\x00' = "http_request_duration_seconds" AND '\xff' = "sum"`,
+		},
+		{
+			name: "negations and regular expressions",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_NEQ, Name: "job", Value: "api"},
+				{Type: remote.LabelMatcher_RE, Name: "__name__", Value: "go_.*"},
+				{Type: remote.LabelMatcher_NRE, Name: "instance", Value: "a|b"},
+			},
+			want: `'job' != "api" AND ( '\xff' = "value" AND '\x00' =~ /^(?:go_.*)$/ OR '\xff' = "sum" OR '\xff' = "count" ) AND 'instance' !~ /^(?:a|b)$/`,
+		},
+		{
+			name: "invalid regular expression",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_RE, Name: "job", Value: "("},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown matcher type",
+			matchers: []*remote.LabelMatcher{
+				{Type: 7, Name: "job", Value: "api"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &remote.Query{Matchers: tt.matchers}
+			pred, err := q.Predicate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := reads.PredicateToExprString(pred)
+			want := keyReplacer.Replace(tt.want)
+			if got != want {
+				t.Errorf("unexpected predicate -want/+got\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestQuery_TimeRange(t *testing.T) {
+	q := &remote.Query{StartTimestampMs: 1000, EndTimestampMs: 2000}
+	got := q.TimeRange()
+	want := datatypes.TimestampRange{Start: 1000000000, End: 2001000000}
+	if got != want {
+		t.Errorf("unexpected range: got %v, want %v", got, want)
+	}
+}
+
+func TestReadSeries(t *testing.T) {
+	rs := &sliceResultSet{
+		series: []fakeSeries{
+			{
+				tags: models.ParseTags([]byte("go_goroutines,_measurement=go_goroutines,_field=value,job=api,instance=a")),
+				cur: &floatCursor{arrays: []*cursors.FloatArray{
+					{Timestamps: []int64{1000000000, 2000000000}, Values: []float64{36, 40}},
+					{Timestamps: []int64{3000000000}, Values: []float64{41}},
+				}},
+			},
+			{
+				tags: models.ParseTags([]byte("up,_measurement=up,_field=value")),
+				cur:  &floatCursor{},
+			},
+			{
+				// every point of the series is outside of the range
+				tags: models.ParseTags([]byte("down,_measurement=down,_field=value")),
+			},
+			{
+				tags: models.ParseTags([]byte("events,_measurement=events,_field=value")),
+				cur: &integerCursor{arrays: []*cursors.IntegerArray{
+					{Timestamps: []int64{1000000000}, Values: []int64{5}},
+				}},
+			},
+		},
+	}
+
+	got, err := remote.ReadSeries(rs, &remote.Query{}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*remote.TimeSeries{
+		{
+			Labels: []remote.Label{
+				{Name: "__name__", Value: "go_goroutines"},
+				{Name: "instance", Value: "a"},
+				{Name: "job", Value: "api"},
+			},
+			Samples: []remote.Sample{
+				{Value: 36, Timestamp: 1000},
+				{Value: 40, Timestamp: 2000},
+				{Value: 41, Timestamp: 3000},
+			},
+		},
+		{
+			Labels:  []remote.Label{{Name: "__name__", Value: "events"}},
+			Samples: []remote.Sample{{Value: 5, Timestamp: 1000}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected series -want/+got\n%s", diff)
+	}
+	if !rs.closed {
+		t.Error("expected the result set to be closed")
+	}
+}
+
+func TestReadSeries_Family(t *testing.T) {
+	newResultSet := func() *sliceResultSet {
+		return &sliceResultSet{
+			series: []fakeSeries{
+				{
+					tags: models.ParseTags([]byte("rpc,_measurement=rpc,_field=count,job=a")),
+					cur: &floatCursor{arrays: []*cursors.FloatArray{
+						{Timestamps: []int64{1000000000}, Values: []float64{4}},
+					}},
+				},
+				{
+					tags: models.ParseTags([]byte("rpc,_measurement=rpc,_field=sum,job=a")),
+					cur: &floatCursor{arrays: []*cursors.FloatArray{
+						{Timestamps: []int64{1000000000}, Values: []float64{0.5}},
+					}},
+				},
+				{
+					tags: models.ParseTags([]byte("rpc_total,_measurement=rpc_total,_field=value,job=a")),
+					cur: &floatCursor{arrays: []*cursors.FloatArray{
+						{Timestamps: []int64{1000000000}, Values: []float64{7}},
+					}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		matchers []*remote.LabelMatcher
+		want     []string
+	}{
+		{
+			name: "all",
+			want: []string{"rpc_count", "rpc_sum", "rpc_total"},
+		},
+		{
+			name: "regular expression",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_RE, Name: "__name__", Value: "rpc_(sum|total)"},
+			},
+			want: []string{"rpc_sum", "rpc_total"},
+		},
+		{
+			name: "negation",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_NEQ, Name: "__name__", Value: "rpc_sum"},
+				{Type: remote.LabelMatcher_NRE, Name: "__name__", Value: "rpc_t.*"},
+			},
+			want: []string{"rpc_count"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := remote.ReadSeries(newResultSet(), &remote.Query{Matchers: tt.matchers}, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range series {
+				for _, l := range s.Labels {
+					if l.Name == "__name__" {
+						got = append(got, l.Value)
+					}
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected series -want/+got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadSeries_MaxSamples(t *testing.T) {
+	newResultSet := func() *sliceResultSet {
+		return &sliceResultSet{
+			series: []fakeSeries{
+				{
+					tags: models.ParseTags([]byte("up,_measurement=up,_field=value,job=a")),
+					cur: &floatCursor{arrays: []*cursors.FloatArray{
+						{Timestamps: []int64{1000000000, 2000000000}, Values: []float64{1, 1}},
+					}},
+				},
+				{
+					tags: models.ParseTags([]byte("up,_measurement=up,_field=value,job=b")),
+					cur: &integerCursor{arrays: []*cursors.IntegerArray{
+						{Timestamps: []int64{1000000000}, Values: []int64{0}},
+					}},
+				},
+			},
+		}
+	}
+
+	got, err := remote.ReadSeries(newResultSet(), &remote.Query{}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("unexpected number of series: got %d want 2", len(got))
+	}
+
+	rs := newResultSet()
+	if _, err := remote.ReadSeries(rs, &remote.Query{}, 2); err != remote.ErrSampleLimit {
+		t.Errorf("unexpected error: got %v want %v", err, remote.ErrSampleLimit)
+	}
+	if !rs.closed {
+		t.Error("expected the result set to be closed")
+	}
+}
+
+func TestReadResponse_RoundTrip(t *testing.T) {
+	req := &remote.ReadRequest{
+		Queries: []*remote.Query{{
+			StartTimestampMs: 1000,
+			EndTimestampMs:   2000,
+			Matchers: []*remote.LabelMatcher{
+				{Type: remote.LabelMatcher_NRE, Name: "job", Value: "api"},
+			},
+		}},
+	}
+	b, err := remote.EncodeReadRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotReq, err := remote.DecodeReadRequest(b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(req, gotReq); diff != "" {
+		t.Errorf("unexpected request -want/+got\n%s", diff)
+	}
+	if _, err := remote.DecodeReadRequest(b, 1); err != remote.ErrDecodedTooLarge {
+		t.Errorf("expected ErrDecodedTooLarge, got %v", err)
+	}
+
+	resp := &remote.ReadResponse{
+		Results: []*remote.QueryResult{{
+			Timeseries: []*remote.TimeSeries{{
+				Labels:  []remote.Label{{Name: "__name__", Value: "up"}},
+				Samples: []remote.Sample{{Value: 1, Timestamp: 1000}},
+			}},
+		}},
+	}
+	b, err = remote.EncodeReadResponse(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotResp, err := remote.DecodeReadResponse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(resp, gotResp); diff != "" {
+		t.Errorf("unexpected response -want/+got\n%s", diff)
+	}
+}
+
+// keyReplacer substitutes the measurement and field tag keys in the
+// expected predicates.
+var keyReplacer = strings.NewReplacer(`\xff`, models.FieldKeyTagKey, `\x00`, models.MeasurementTagKey)
+
+type fakeSeries struct {
+	tags models.Tags
+	cur  cursors.Cursor
+}
+
+type sliceResultSet struct {
+	series []fakeSeries
+	cur    fakeSeries
+	closed bool
+}
+
+func (rs *sliceResultSet) Next() bool {
+	if len(rs.series) == 0 {
+		return false
+	}
+	rs.cur, rs.series = rs.series[0], rs.series[1:]
+	return true
+}
+
+func (rs *sliceResultSet) Cursor() cursors.Cursor     { return rs.cur.cur }
+func (rs *sliceResultSet) Tags() models.Tags          { return rs.cur.tags }
+func (rs *sliceResultSet) Close()                     { rs.closed = true }
+func (rs *sliceResultSet) Err() error                 { return nil }
+func (rs *sliceResultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type floatCursor struct {
+	arrays []*cursors.FloatArray
+}
+
+func (c *floatCursor) Next() *cursors.FloatArray {
+	if len(c.arrays) == 0 {
+		return &cursors.FloatArray{}
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+func (c *floatCursor) Close()                     {}
+func (c *floatCursor) Err() error                 { return nil }
+func (c *floatCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type integerCursor struct {
+	arrays []*cursors.IntegerArray
+}
+
+func (c *integerCursor) Next() *cursors.IntegerArray {
+	if len(c.arrays) == 0 {
+		return &cursors.IntegerArray{}
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+func (c *integerCursor) Close()                     {}
+func (c *integerCursor) Err() error                 { return nil }
+func (c *integerCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
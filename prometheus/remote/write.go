@@ -0,0 +1,166 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/v2/models"
+)
+
+// Labels with a special meaning in the mapping of samples to points.
+const (
+	nameLabel     = "__name__"
+	bucketLabel   = "le"
+	quantileLabel = "quantile"
+	bucketSuffix  = "_bucket"
+)
+
+// valueField is the field of samples which are neither histogram buckets
+// nor summary quantiles. Remote write does not send the type of the
+// metrics, so samples are mapped like untyped metrics are by the scraper.
+const valueField = "value"
+
+// familyFields are the suffixes of the sum and count of histograms and
+// summaries, and the fields of the measurement of their metric family
+// which the scraper writes them to.
+var familyFields = []struct{ suffix, field string }{
+	{suffix: "_sum", field: "sum"},
+	{suffix: "_count", field: "count"},
+}
+
+// ErrDecodedTooLarge is returned when the decompressed body of a request
+// exceeds the allowed size.
+var ErrDecodedTooLarge = errors.New("decompressed body exceeds the maximum size")
+
+// DecodeWriteRequest decodes a snappy compressed, protobuf encoded
+// WriteRequest. When maxBytes is positive, bodies which decompress to
+// more than maxBytes are rejected with ErrDecodedTooLarge.
+func DecodeWriteRequest(compressed []byte, maxBytes int64) (*WriteRequest, error) {
+	b, err := decompress(compressed, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var req WriteRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return nil, fmt.Errorf("decoding write request: %v", err)
+	}
+	return &req, nil
+}
+
+// EncodeWriteRequest encodes a WriteRequest as sent by Prometheus.
+func EncodeWriteRequest(req *WriteRequest) ([]byte, error) {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, b), nil
+}
+
+func decompress(compressed []byte, maxBytes int64) ([]byte, error) {
+	n, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding snappy body: %v", err)
+	}
+	if maxBytes > 0 && int64(n) > maxBytes {
+		return nil, ErrDecodedTooLarge
+	}
+	b, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding snappy body: %v", err)
+	}
+	return b, nil
+}
+
+// Points maps the samples of the write request to points, the same way
+// the scraper maps metrics: the measurement is the metric name and the
+// other labels are tags. Histogram buckets and summary quantiles are
+// written to a field named after their upper bound or quantile, and the
+// <name>_sum and <name>_count samples to the sum and count fields, on the
+// measurement of their metric family. Samples with a NaN value, such as
+// the stale markers of Prometheus, are skipped.
+func (req *WriteRequest) Points() (models.Points, error) {
+	var pts models.Points
+	for _, ts := range req.Timeseries {
+		name, field, tags := seriesPoint(ts.Labels)
+		if name == "" {
+			return nil, fmt.Errorf("series without a %s label", nameLabel)
+		}
+
+		for _, s := range ts.Samples {
+			if math.IsNaN(s.Value) {
+				continue
+			}
+			pt, err := models.NewPoint(name, tags, models.Fields{field: s.Value}, time.Unix(0, s.Timestamp*int64(time.Millisecond)))
+			if err != nil {
+				return nil, err
+			}
+			pts = append(pts, pt)
+		}
+	}
+	return pts, nil
+}
+
+// seriesPoint returns the measurement, field and tags of the points of
+// the series with the labels.
+func seriesPoint(labels []Label) (string, string, models.Tags) {
+	var name, bucket, quantile string
+	for _, l := range labels {
+		switch l.Name {
+		case nameLabel:
+			name = l.Value
+		case bucketLabel:
+			bucket = l.Value
+		case quantileLabel:
+			quantile = l.Value
+		}
+	}
+
+	field := valueField
+	skip := ""
+	switch {
+	case bucket != "" && strings.HasSuffix(name, bucketSuffix):
+		name = strings.TrimSuffix(name, bucketSuffix)
+		field, skip = bucket, bucketLabel
+	case quantile != "":
+		field, skip = quantile, quantileLabel
+	default:
+		name, field = metricField(name)
+	}
+
+	tags := make(map[string]string, len(labels))
+	for _, l := range labels {
+		if l.Name == nameLabel || l.Name == skip {
+			continue
+		}
+		tags[l.Name] = l.Value
+	}
+	return name, field, models.NewTags(tags)
+}
+
+// metricField returns the measurement and field of the samples of the
+// metric named name, other than histogram buckets and summary quantiles.
+func metricField(name string) (string, string) {
+	for _, f := range familyFields {
+		if len(name) > len(f.suffix) && strings.HasSuffix(name, f.suffix) {
+			return strings.TrimSuffix(name, f.suffix), f.field
+		}
+	}
+	return name, valueField
+}
+
+// metricName returns the name of the metric whose samples are written to
+// field of measurement. It is the inverse of metricField.
+func metricName(measurement, field string) string {
+	for _, f := range familyFields {
+		if field == f.field {
+			return measurement + f.suffix
+		}
+	}
+	return measurement
+}
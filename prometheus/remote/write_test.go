@@ -0,0 +1,119 @@
+package remote_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+)
+
+func TestWriteRequest_Points(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []remote.TimeSeries{
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "go_goroutines"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []remote.Sample{
+					{Value: 36, Timestamp: 1000},
+					{Value: math.NaN(), Timestamp: 2000},
+					{Value: 40, Timestamp: 3000},
+				},
+			},
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "http_request_duration_seconds_bucket"},
+					{Name: "le", Value: "0.5"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []remote.Sample{{Value: 10, Timestamp: 1000}},
+			},
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "go_gc_duration_seconds"},
+					{Name: "quantile", Value: "0.75"},
+				},
+				Samples: []remote.Sample{{Value: 0.25, Timestamp: 1000}},
+			},
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "go_gc_duration_seconds_sum"},
+				},
+				Samples: []remote.Sample{{Value: 1.5, Timestamp: 1000}},
+			},
+			{
+				Labels: []remote.Label{
+					{Name: "__name__", Value: "http_request_duration_seconds_count"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []remote.Sample{{Value: 12, Timestamp: 1000}},
+			},
+		},
+	}
+
+	b, err := remote.EncodeWriteRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := remote.DecodeWriteRequest(b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pts, err := decoded.Points()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(pts))
+	for _, pt := range pts {
+		got = append(got, pt.String())
+	}
+	want := []string{
+		"go_goroutines,job=api value=36 1000000000",
+		"go_goroutines,job=api value=40 3000000000",
+		"http_request_duration_seconds,job=api 0.5=10 1000000000",
+		"go_gc_duration_seconds 0.75=0.25 1000000000",
+		"go_gc_duration_seconds sum=1.5 1000000000",
+		"http_request_duration_seconds,job=api count=12 1000000000",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected points -want/+got:\n%s", diff)
+	}
+}
+
+func TestWriteRequest_PointsWithoutName(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []remote.TimeSeries{
+			{
+				Labels:  []remote.Label{{Name: "job", Value: "api"}},
+				Samples: []remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+	if _, err := req.Points(); err == nil {
+		t.Fatal("expected error for series without a name")
+	}
+}
+
+func TestDecodeWriteRequest_TooLarge(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []remote.TimeSeries{
+			{
+				Labels:  []remote.Label{{Name: "__name__", Value: "m"}},
+				Samples: []remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+	b, err := remote.EncodeWriteRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := remote.DecodeWriteRequest(b, 4); err != remote.ErrDecodedTooLarge {
+		t.Fatalf("expected ErrDecodedTooLarge, got %v", err)
+	}
+	if _, err := remote.DecodeWriteRequest([]byte("not snappy"), 0); err == nil {
+		t.Fatal("expected error decoding an invalid body")
+	}
+}
@@ -52,7 +52,7 @@ type Controller struct {
 	lastID     uint64
 	queriesMu  sync.RWMutex
 	queries    map[QueryID]*Query
-	queryQueue chan *Query
+	queryQueue *queryQueue
 	wg         sync.WaitGroup
 	shutdown   bool
 	done       chan struct{}
@@ -108,6 +108,27 @@ type Config struct {
 	// this to follow suit.
 	QueueSize int32
 
+	// TaskConcurrencyQuota is the number of task queries that are allowed to execute concurrently.
+	// Task queries only start when no interactive query is waiting to start.
+	// If this is unset, then task queries may use the whole ConcurrencyQuota.
+	TaskConcurrencyQuota int32
+
+	// BackgroundConcurrencyQuota is the number of background queries that are allowed to execute
+	// concurrently. Background queries only start when no interactive or task query is waiting to start.
+	// If this is unset, then background queries may use the whole ConcurrencyQuota.
+	BackgroundConcurrencyQuota int32
+
+	// OrgConcurrencyQuota is the number of queries of a single organization that are allowed
+	// to execute concurrently. If this is unset, then there is no limit per organization.
+	OrgConcurrencyQuota int32
+
+	// OrgMemoryBytesQuota is the maximum number of bytes the executing queries of a single
+	// organization are allowed to use at any given time. If this is unset, then there is no
+	// limit per organization.
+	//
+	// This number must be greater than or equal to the InitialMemoryBytesQuotaPerQuery.
+	OrgMemoryBytesQuota int64
+
 	Logger *zap.Logger
 	// MetricLabelKeys is a list of labels to add to the metrics produced by the controller.
 	// The value for a given key will be read off the context.
@@ -152,6 +173,27 @@ func (c *Config) validate(isComplete bool) error {
 	if c.QueueSize <= 0 {
 		return errors.New("QueueSize must be positive")
 	}
+	if c.TaskConcurrencyQuota < 0 {
+		return errors.New("TaskConcurrencyQuota must not be negative")
+	}
+	if c.BackgroundConcurrencyQuota < 0 {
+		return errors.New("BackgroundConcurrencyQuota must not be negative")
+	}
+	if c.OrgConcurrencyQuota < 0 {
+		return errors.New("OrgConcurrencyQuota must not be negative")
+	}
+	if c.OrgMemoryBytesQuota < 0 {
+		return errors.New("OrgMemoryBytesQuota must not be negative")
+	}
+	if c.OrgMemoryBytesQuota != 0 {
+		initial := c.InitialMemoryBytesQuotaPerQuery
+		if initial == 0 {
+			initial = c.MemoryBytesQuotaPerQuery
+		}
+		if c.OrgMemoryBytesQuota < initial {
+			return fmt.Errorf("OrgMemoryBytesQuota must be greater than or equal to the InitialMemoryBytesQuotaPerQuery: %d < %d", c.OrgMemoryBytesQuota, initial)
+		}
+	}
 	return nil
 }
 
@@ -177,7 +219,11 @@ func New(config Config) (*Controller, error) {
 		zap.Int64("initial_memory_bytes_quota_per_query", c.InitialMemoryBytesQuotaPerQuery),
 		zap.Int64("memory_bytes_quota_per_query", c.MemoryBytesQuotaPerQuery),
 		zap.Int64("max_memory_bytes", c.MaxMemoryBytes),
-		zap.Int32("queue_size", c.QueueSize))
+		zap.Int32("queue_size", c.QueueSize),
+		zap.Int32("task_concurrency_quota", c.TaskConcurrencyQuota),
+		zap.Int32("background_concurrency_quota", c.BackgroundConcurrencyQuota),
+		zap.Int32("org_concurrency_quota", c.OrgConcurrencyQuota),
+		zap.Int64("org_memory_bytes_quota", c.OrgMemoryBytesQuota))
 
 	mm := &memoryManager{
 		initialBytesQuotaPerQuery: c.InitialMemoryBytesQuotaPerQuery,
@@ -188,15 +234,21 @@ func New(config Config) (*Controller, error) {
 	} else {
 		mm.unlimited = true
 	}
+	metrics := newControllerMetrics(c.MetricLabelKeys)
+	var quotas queueQuotas
+	quotas.class[query.PriorityTask] = int(c.TaskConcurrencyQuota)
+	quotas.class[query.PriorityBackground] = int(c.BackgroundConcurrencyQuota)
+	quotas.orgQuery = int(c.OrgConcurrencyQuota)
+	quotas.orgMemory = c.OrgMemoryBytesQuota
 	ctrl := &Controller{
 		config:       c,
 		queries:      make(map[QueryID]*Query),
-		queryQueue:   make(chan *Query, c.QueueSize),
+		queryQueue:   newQueryQueue(int(c.QueueSize), quotas, c.InitialMemoryBytesQuotaPerQuery, metrics.queueDepth),
 		done:         make(chan struct{}),
 		abort:        make(chan struct{}),
 		memory:       mm,
 		log:          logger,
-		metrics:      newControllerMetrics(c.MetricLabelKeys),
+		metrics:      metrics,
 		labelKeys:    c.MetricLabelKeys,
 		dependencies: c.ExecutorDependencies,
 	}
@@ -284,6 +336,12 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) (*Qu
 	}
 	compileLabelValues[len(compileLabelValues)-1] = string(ct)
 
+	var priority query.Priority
+	if req := query.RequestFromContext(ctx); req != nil {
+		priority = req.Priority
+	}
+	org, _ := ctx.Value(orgLabel).(string)
+
 	cctx, cancel := context.WithCancel(ctx)
 	parentSpan, parentCtx := tracing.StartSpanFromContextWithPromMetrics(
 		cctx,
@@ -295,6 +353,8 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) (*Qu
 		id:                 id,
 		labelValues:        labelValues,
 		compileLabelValues: compileLabelValues,
+		priority:           priority,
+		org:                org,
 		state:              Created,
 		c:                  c,
 		results:            make(chan flux.Result),
@@ -384,9 +444,7 @@ func (c *Controller) enqueueQuery(q *Query) error {
 		}
 	}
 
-	select {
-	case c.queryQueue <- q:
-	default:
+	if !c.queryQueue.push(q) {
 		return &flux.Error{
 			Code: codes.ResourceExhausted,
 			Msg:  "queue length exceeded",
@@ -398,11 +456,16 @@ func (c *Controller) enqueueQuery(q *Query) error {
 
 func (c *Controller) processQueryQueue() {
 	for {
+		if q := c.queryQueue.pop(); q != nil {
+			c.executeQuery(q)
+			c.queryQueue.release(q)
+			continue
+		}
+
 		select {
 		case <-c.done:
 			return
-		case q := <-c.queryQueue:
-			c.executeQuery(q)
+		case <-c.queryQueue.ready:
 		}
 	}
 }
@@ -545,6 +608,11 @@ type Query struct {
 	labelValues        []string
 	compileLabelValues []string
 
+	// priority and org determine when the query is dequeued.
+	priority query.Priority
+	org      string
+	orgState *orgState
+
 	c *Controller
 
 	// query state. The stateMu protects access for the group below.
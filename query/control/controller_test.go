@@ -824,6 +824,65 @@ func TestController_QueueSize(t *testing.T) {
 	}
 }
 
+func TestController_PriorityClasses(t *testing.T) {
+	config := config
+	config.ConcurrencyQuota = 1
+	config.QueueSize = 2
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+
+	// This channel blocks the first query until the others are queued.
+	unblock := make(chan struct{})
+	executing := make(chan string, 3)
+	newRequest := func(name string, priority query.Priority) *query.Request {
+		req := makeRequest(&mock.Compiler{
+			CompileFn: func(ctx context.Context) (flux.Program, error) {
+				return &mock.Program{
+					ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+						executing <- name
+						if name == "first" {
+							<-unblock
+						}
+					},
+				}, nil
+			},
+		})
+		req.Priority = priority
+		return req
+	}
+	run := func(req *query.Request) {
+		q, err := ctrl.Query(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			for range q.Results() {
+				// discard the results
+			}
+			q.Done()
+		}()
+	}
+
+	run(newRequest("first", query.PriorityTask))
+	if got := <-executing; got != "first" {
+		t.Fatalf("unexpected query executing: %s", got)
+	}
+
+	// The interactive query is queued last, but starts first.
+	run(newRequest("task", query.PriorityTask))
+	run(newRequest("interactive", query.PriorityInteractive))
+	close(unblock)
+
+	for _, want := range []string{"interactive", "task"} {
+		if got := <-executing; got != want {
+			t.Errorf("unexpected query executing: got %s want %s", got, want)
+		}
+	}
+}
+
 // Test that rapidly starting and canceling the query and then calling done will correctly
 // cancel the query and not result in a race condition.
 func TestController_CancelDone(t *testing.T) {
@@ -40,13 +40,21 @@ func (m *memoryManager) addUnusedMemoryBytes(amount int64) int64 {
 	return atomic.AddInt64(&m.unusedMemoryBytes, amount)
 }
 
+// errOrgMemoryLimit is returned when the queries of an organization
+// hit the memory quota of the organization.
+var errOrgMemoryLimit = errors.New("organization hit memory limit")
+
 // createAllocator will construct an allocator and memory manager
 // for the given query.
 func (c *Controller) createAllocator(q *Query) {
 	q.memoryManager = &queryMemoryManager{
 		m:     c.memory,
+		org:   &q.orgState.memory,
 		limit: c.memory.initialBytesQuotaPerQuery,
 	}
+	// The initial memory was checked against the quota of the
+	// organization when the query was dequeued.
+	q.memoryManager.org.add(q.memoryManager.limit)
 	q.alloc = &memory.Allocator{
 		// Use an anonymous function to ensure the value is copied.
 		Limit:   func(v int64) *int64 { return &v }(q.memoryManager.limit),
@@ -57,6 +65,7 @@ func (c *Controller) createAllocator(q *Query) {
 // queryMemoryManager is a memory manager for a specific query.
 type queryMemoryManager struct {
 	m     *memoryManager
+	org   *orgMemory
 	limit int64
 	given int64
 }
@@ -94,11 +103,19 @@ func (q *queryMemoryManager) RequestMemory(want int64) (got int64, err error) {
 		// this method.
 		given := q.giveMemory(want, unused)
 
+		// The organization may not have as much memory left as
+		// we would like to give.
+		given, err = q.org.reserve(want, given)
+		if err != nil {
+			return 0, err
+		}
+
 		// Reserve this memory for our own use.
 		if !q.m.unlimited {
 			if !q.m.trySetUnusedMemoryBytes(unused, unused-given) {
 				// The unused value has changed so someone may have taken
 				// the memory that we wanted. Retry.
+				q.org.add(-given)
 				continue
 			}
 		}
@@ -152,6 +169,7 @@ func (q *queryMemoryManager) Release() {
 	if !q.m.unlimited {
 		q.m.addUnusedMemoryBytes(q.given)
 	}
+	q.org.add(-q.limit)
 	q.limit = q.m.initialBytesQuotaPerQuery
 	q.given = 0
 }
@@ -0,0 +1,247 @@
+package control
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// numPriorities is the number of priority classes of the queue.
+const numPriorities = int(query.PriorityBackground) + 1
+
+// priorityClass returns the index of the class of the priority. Unknown
+// priorities are scheduled with the background queries.
+func priorityClass(p query.Priority) int {
+	if p < query.PriorityInteractive || p > query.PriorityBackground {
+		return int(query.PriorityBackground)
+	}
+	return int(p)
+}
+
+// queryQueue holds the queries awaiting execution and decides which one
+// runs next.
+//
+// A query of a priority class is only started when no query of a higher
+// class can be. Within a class, organizations take turns, so the queries
+// of a single organization don't delay the queries of the others. A query
+// can't start while its class or its organization is at its concurrency
+// quota, or while its organization is at its memory quota.
+type queryQueue struct {
+	// ready is signaled when a query may be ready to start.
+	ready chan struct{}
+
+	mu       sync.Mutex
+	size     int
+	maxSize  int
+	classes  [numPriorities]classQueue
+	running  [numPriorities]int
+	orgs     map[string]*orgState
+	quotas   queueQuotas
+	initMem  int64
+	depthVec *prometheus.GaugeVec
+}
+
+// queueQuotas are the concurrency and memory quotas of the queue. A zero
+// quota is unlimited.
+type queueQuotas struct {
+	class     [numPriorities]int
+	orgQuery  int
+	orgMemory int64
+}
+
+// classQueue holds the queued queries of a priority class per organization.
+type classQueue struct {
+	// orgs are the organizations with queued queries, in turn order.
+	orgs   []string
+	next   int
+	queued map[string][]*Query
+}
+
+// orgState tracks the queries and memory of an organization.
+type orgState struct {
+	queued  int
+	running int
+	memory  orgMemory
+}
+
+func newQueryQueue(maxSize int, quotas queueQuotas, initMem int64, depth *prometheus.GaugeVec) *queryQueue {
+	qq := &queryQueue{
+		ready:    make(chan struct{}, 1),
+		maxSize:  maxSize,
+		orgs:     make(map[string]*orgState),
+		quotas:   quotas,
+		initMem:  initMem,
+		depthVec: depth,
+	}
+	for i := range qq.classes {
+		qq.classes[i].queued = make(map[string][]*Query)
+	}
+	return qq
+}
+
+// push adds a query to the queue. It returns false when the queue is full.
+func (qq *queryQueue) push(q *Query) bool {
+	qq.mu.Lock()
+	defer qq.mu.Unlock()
+
+	if qq.size >= qq.maxSize {
+		return false
+	}
+
+	st, ok := qq.orgs[q.org]
+	if !ok {
+		st = &orgState{memory: orgMemory{quota: qq.quotas.orgMemory}}
+		qq.orgs[q.org] = st
+	}
+	st.queued++
+	q.orgState = st
+
+	class := priorityClass(q.priority)
+	cq := &qq.classes[class]
+	if len(cq.queued[q.org]) == 0 {
+		cq.orgs = append(cq.orgs, q.org)
+	}
+	cq.queued[q.org] = append(cq.queued[q.org], q)
+	qq.size++
+	qq.setDepth(class, q.org)
+
+	qq.signal()
+	return true
+}
+
+// pop removes the next query to start from the queue, and counts it as
+// running until release is called. It returns nil when no query can start.
+func (qq *queryQueue) pop() *Query {
+	qq.mu.Lock()
+	defer qq.mu.Unlock()
+
+	for class := range qq.classes {
+		if quota := qq.quotas.class[class]; quota > 0 && qq.running[class] >= quota {
+			continue
+		}
+
+		cq := &qq.classes[class]
+		for i := 0; i < len(cq.orgs); i++ {
+			idx := (cq.next + i) % len(cq.orgs)
+			org := cq.orgs[idx]
+			st := qq.orgs[org]
+			if !qq.canStart(st) {
+				continue
+			}
+
+			queued := cq.queued[org]
+			q := queued[0]
+			queued[0] = nil
+			if len(queued) == 1 {
+				delete(cq.queued, org)
+				cq.orgs = append(cq.orgs[:idx], cq.orgs[idx+1:]...)
+				cq.next = idx
+			} else {
+				cq.queued[org] = queued[1:]
+				cq.next = idx + 1
+			}
+			if cq.next >= len(cq.orgs) {
+				cq.next = 0
+			}
+
+			qq.size--
+			qq.running[class]++
+			st.queued--
+			st.running++
+			qq.setDepth(class, org)
+
+			// Let another worker look for a query to start.
+			if qq.size > 0 {
+				qq.signal()
+			}
+			return q
+		}
+	}
+	return nil
+}
+
+// release marks a query returned by pop as no longer running.
+func (qq *queryQueue) release(q *Query) {
+	qq.mu.Lock()
+	defer qq.mu.Unlock()
+
+	qq.running[priorityClass(q.priority)]--
+	st := q.orgState
+	st.running--
+	if st.running == 0 && st.queued == 0 && st.memory.usedBytes() == 0 && qq.orgs[q.org] == st {
+		delete(qq.orgs, q.org)
+	}
+
+	if qq.size > 0 {
+		qq.signal()
+	}
+}
+
+// canStart reports if a query of the organization can start without
+// exceeding the quotas of the organization.
+func (qq *queryQueue) canStart(st *orgState) bool {
+	if quota := qq.quotas.orgQuery; quota > 0 && st.running >= quota {
+		return false
+	}
+	if quota := qq.quotas.orgMemory; quota > 0 && st.memory.usedBytes()+qq.initMem > quota {
+		return false
+	}
+	return true
+}
+
+func (qq *queryQueue) signal() {
+	select {
+	case qq.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (qq *queryQueue) setDepth(class int, org string) {
+	if qq.depthVec == nil {
+		return
+	}
+	depth := len(qq.classes[class].queued[org])
+	qq.depthVec.WithLabelValues(query.Priority(class).String(), org).Set(float64(depth))
+}
+
+// orgMemory is the memory allocated to the running queries of an
+// organization.
+type orgMemory struct {
+	// quota is the maximum number of bytes. Zero is unlimited.
+	quota int64
+	used  int64
+}
+
+func (m *orgMemory) usedBytes() int64 {
+	return atomic.LoadInt64(&m.used)
+}
+
+// reserve reserves between want and given bytes, as much as the quota
+// allows. It returns an error if not even want bytes are available.
+func (m *orgMemory) reserve(want, given int64) (int64, error) {
+	if m.quota <= 0 {
+		m.add(given)
+		return given, nil
+	}
+	for {
+		used := m.usedBytes()
+		available := m.quota - used
+		if available < want {
+			return 0, errOrgMemoryLimit
+		}
+		if given > available {
+			given = available
+		}
+		if atomic.CompareAndSwapInt64(&m.used, used, used+given) {
+			return given, nil
+		}
+	}
+}
+
+// add adds bytes to the used memory. Memory is freed with a negative
+// number of bytes.
+func (m *orgMemory) add(bytes int64) {
+	atomic.AddInt64(&m.used, bytes)
+}
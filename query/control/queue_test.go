@@ -0,0 +1,210 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func newTestQuery(id QueryID, org string, priority query.Priority) *Query {
+	return &Query{id: id, org: org, priority: priority}
+}
+
+// popIDs pops queries until none can start, and returns their IDs.
+func popIDs(qq *queryQueue) []QueryID {
+	var ids []QueryID
+	for q := qq.pop(); q != nil; q = qq.pop() {
+		ids = append(ids, q.id)
+	}
+	return ids
+}
+
+func equalIDs(a, b []QueryID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQueryQueue_Priority(t *testing.T) {
+	qq := newQueryQueue(10, queueQuotas{}, 0, nil)
+	for _, q := range []*Query{
+		newTestQuery(1, "a", query.PriorityBackground),
+		newTestQuery(2, "a", query.PriorityTask),
+		newTestQuery(3, "a", query.PriorityInteractive),
+		newTestQuery(4, "a", query.PriorityTask),
+		newTestQuery(5, "a", query.Priority(7)),
+	} {
+		if !qq.push(q) {
+			t.Fatalf("unable to push query %d", q.id)
+		}
+	}
+
+	if got, want := popIDs(qq), []QueryID{3, 2, 4, 1, 5}; !equalIDs(got, want) {
+		t.Errorf("unexpected order: got %v want %v", got, want)
+	}
+}
+
+func TestQueryQueue_OrgFairness(t *testing.T) {
+	qq := newQueryQueue(10, queueQuotas{}, 0, nil)
+	for _, q := range []*Query{
+		newTestQuery(1, "a", query.PriorityInteractive),
+		newTestQuery(2, "a", query.PriorityInteractive),
+		newTestQuery(3, "a", query.PriorityInteractive),
+		newTestQuery(4, "b", query.PriorityInteractive),
+		newTestQuery(5, "c", query.PriorityInteractive),
+		newTestQuery(6, "b", query.PriorityInteractive),
+	} {
+		qq.push(q)
+	}
+
+	if got, want := popIDs(qq), []QueryID{1, 4, 5, 2, 6, 3}; !equalIDs(got, want) {
+		t.Errorf("unexpected order: got %v want %v", got, want)
+	}
+}
+
+func TestQueryQueue_QueueSize(t *testing.T) {
+	qq := newQueryQueue(2, queueQuotas{}, 0, nil)
+	if !qq.push(newTestQuery(1, "a", query.PriorityInteractive)) {
+		t.Fatal("unable to push query 1")
+	}
+	if !qq.push(newTestQuery(2, "b", query.PriorityTask)) {
+		t.Fatal("unable to push query 2")
+	}
+	if qq.push(newTestQuery(3, "c", query.PriorityInteractive)) {
+		t.Fatal("expected the queue to be full")
+	}
+
+	// Running queries don't count against the queue size.
+	qq.pop()
+	if !qq.push(newTestQuery(3, "c", query.PriorityInteractive)) {
+		t.Fatal("unable to push query 3")
+	}
+}
+
+func TestQueryQueue_ClassQuota(t *testing.T) {
+	var quotas queueQuotas
+	quotas.class[query.PriorityTask] = 1
+	qq := newQueryQueue(10, quotas, 0, nil)
+	for _, q := range []*Query{
+		newTestQuery(1, "a", query.PriorityTask),
+		newTestQuery(2, "b", query.PriorityTask),
+		newTestQuery(3, "a", query.PriorityBackground),
+	} {
+		qq.push(q)
+	}
+
+	// The second task query waits for the first one, but doesn't
+	// hold back the background query.
+	first := qq.pop()
+	if got, want := popIDs(qq), []QueryID{3}; first.id != 1 || !equalIDs(got, want) {
+		t.Fatalf("unexpected queries: got %d, %v want 1, %v", first.id, got, want)
+	}
+
+	qq.release(first)
+	if got, want := popIDs(qq), []QueryID{2}; !equalIDs(got, want) {
+		t.Errorf("unexpected queries after release: got %v want %v", got, want)
+	}
+}
+
+func TestQueryQueue_OrgQuota(t *testing.T) {
+	qq := newQueryQueue(10, queueQuotas{orgQuery: 1}, 0, nil)
+	for _, q := range []*Query{
+		newTestQuery(1, "a", query.PriorityInteractive),
+		newTestQuery(2, "a", query.PriorityInteractive),
+		newTestQuery(3, "a", query.PriorityTask),
+		newTestQuery(4, "b", query.PriorityTask),
+	} {
+		qq.push(q)
+	}
+
+	// Organization a is at its quota after its first query, so the
+	// task query of organization b starts before its other queries.
+	first := qq.pop()
+	if got, want := popIDs(qq), []QueryID{4}; first.id != 1 || !equalIDs(got, want) {
+		t.Fatalf("unexpected queries: got %d, %v want 1, %v", first.id, got, want)
+	}
+
+	qq.release(first)
+	if got, want := popIDs(qq), []QueryID{2}; !equalIDs(got, want) {
+		t.Errorf("unexpected queries after release: got %v want %v", got, want)
+	}
+}
+
+func TestQueryQueue_OrgMemoryQuota(t *testing.T) {
+	qq := newQueryQueue(10, queueQuotas{orgMemory: 100}, 40, nil)
+	for _, q := range []*Query{
+		newTestQuery(1, "a", query.PriorityInteractive),
+		newTestQuery(2, "a", query.PriorityInteractive),
+		newTestQuery(3, "a", query.PriorityInteractive),
+	} {
+		qq.push(q)
+	}
+
+	first := qq.pop()
+	first.orgState.memory.add(40)
+	second := qq.pop()
+	second.orgState.memory.add(40)
+	if first.id != 1 || second.id != 2 {
+		t.Fatalf("unexpected queries: got %d, %d want 1, 2", first.id, second.id)
+	}
+
+	// 80 of the 100 bytes are used, so there is no room for another query.
+	if q := qq.pop(); q != nil {
+		t.Fatalf("expected no query to start, got %d", q.id)
+	}
+
+	first.orgState.memory.add(-40)
+	qq.release(first)
+	if got, want := popIDs(qq), []QueryID{3}; !equalIDs(got, want) {
+		t.Errorf("unexpected queries after release: got %v want %v", got, want)
+	}
+}
+
+func TestQueryQueue_Depth(t *testing.T) {
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "queue_depth"}, []string{"priority", orgLabel})
+	qq := newQueryQueue(10, queueQuotas{}, 0, depth)
+	qq.push(newTestQuery(1, "a", query.PriorityTask))
+	qq.push(newTestQuery(2, "a", query.PriorityTask))
+	qq.push(newTestQuery(3, "b", query.PriorityInteractive))
+	qq.pop()
+
+	for _, tt := range []struct {
+		priority, org string
+		want          float64
+	}{
+		{priority: "interactive", org: "b", want: 0},
+		{priority: "task", org: "a", want: 2},
+	} {
+		var m dto.Metric
+		if err := depth.WithLabelValues(tt.priority, tt.org).Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetGauge().GetValue(); got != tt.want {
+			t.Errorf("unexpected depth of %s queries of %s: got %v want %v", tt.priority, tt.org, got, tt.want)
+		}
+	}
+}
+
+func TestOrgMemory_Reserve(t *testing.T) {
+	m := orgMemory{quota: 100}
+	if got, err := m.reserve(10, 60); err != nil || got != 60 {
+		t.Fatalf("unexpected reservation: got %d, %v want 60", got, err)
+	}
+	if got, err := m.reserve(10, 60); err != nil || got != 40 {
+		t.Fatalf("unexpected reservation: got %d, %v want 40", got, err)
+	}
+	if _, err := m.reserve(10, 10); err != errOrgMemoryLimit {
+		t.Fatalf("expected errOrgMemoryLimit, got %v", err)
+	}
+	if got := m.usedBytes(); got != 100 {
+		t.Errorf("unexpected used bytes: got %d want 100", got)
+	}
+}
@@ -0,0 +1,334 @@
+package influxql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxql"
+)
+
+// TranspileContinuousQuery converts a continuous query into the script of a task
+// that periodically writes the results of the query into the target of the query.
+//
+// The task runs every RESAMPLE EVERY interval, or every GROUP BY time interval when
+// it is not set, and recomputes the RESAMPLE FOR duration of data, which defaults
+// to the GROUP BY time interval, that ends at the last GROUP BY time boundary
+// before the task runs. The offset of the GROUP BY time clause becomes the offset
+// of the task.
+func (t *Transpiler) TranspileContinuousQuery(ctx context.Context, stmt *influxql.CreateContinuousQueryStatement, orgID influxdb.ID) (*ast.Package, error) {
+	interval, err := stmt.Source.GroupByInterval()
+	if err != nil {
+		return nil, err
+	} else if interval <= 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "unable to transpile: continuous query must have a GROUP BY time clause",
+		}
+	}
+	offset, err := stmt.Source.GroupByOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	every, resampleFor := interval, interval
+	if stmt.ResampleEvery > 0 {
+		every = stmt.ResampleEvery
+	}
+	if stmt.ResampleFor > 0 {
+		resampleFor = stmt.ResampleFor
+	}
+
+	target := stmt.Source.Target
+	if target == nil || target.Measurement == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "unable to transpile: continuous query must have an INTO clause",
+		}
+	}
+	targetDB := target.Measurement.Database
+	if targetDB == "" {
+		targetDB = stmt.Database
+	}
+	bucketID, err := t.targetBucketID(ctx, orgID, targetDB, target.Measurement.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	config := *t.Config
+	config.DefaultDatabase = stmt.Database
+	state := newTranspilerState(t.dbrpMappingSvc, &config)
+
+	// The target is written by the task itself and the time range is
+	// determined by the schedule of the task.
+	src := stmt.Source.Clone()
+	src.Target = nil
+	cur, err := state.transpileSelect(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	start, stop := &ast.Identifier{Name: "start"}, &ast.Identifier{Name: "stop"}
+	for _, s := range state.file.Body {
+		ast.Walk(rangeRewriter{start: start, stop: stop}, s)
+	}
+	expr := cur.Expr()
+	ast.Walk(rangeRewriter{start: start, stop: stop}, expr)
+
+	// The results are grouped by field, but experimental.to expects pivoted
+	// data with a column for each field.
+	expr = &ast.PipeExpression{
+		Argument: expr,
+		Call: &ast.CallExpression{
+			Callee: &ast.Identifier{Name: "drop"},
+			Arguments: []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{{
+						Key: &ast.Identifier{Name: "fn"},
+						Value: &ast.FunctionExpression{
+							Params: []*ast.Property{{
+								Key: &ast.Identifier{Name: "column"},
+							}},
+							Body: &ast.BinaryExpression{
+								Operator: ast.EqualOperator,
+								Left:     &ast.Identifier{Name: "column"},
+								Right:    &ast.StringLiteral{Value: "_field"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	// An empty measurement name is the :MEASUREMENT back reference, which
+	// keeps the name of the source measurement.
+	if name := target.Measurement.Name; name != "" {
+		expr = &ast.PipeExpression{
+			Argument: expr,
+			Call: &ast.CallExpression{
+				Callee: &ast.Identifier{Name: "set"},
+				Arguments: []ast.Expression{
+					&ast.ObjectExpression{
+						Properties: []*ast.Property{
+							{
+								Key:   &ast.Identifier{Name: "key"},
+								Value: &ast.StringLiteral{Value: "_measurement"},
+							},
+							{
+								Key:   &ast.Identifier{Name: "value"},
+								Value: &ast.StringLiteral{Value: name},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	experimental := state.requireImport("experimental")
+	expr = &ast.PipeExpression{
+		Argument: expr,
+		Call: &ast.CallExpression{
+			Callee: &ast.MemberExpression{
+				Object:   experimental,
+				Property: &ast.Identifier{Name: "to"},
+			},
+			Arguments: []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{
+						{
+							Key:   &ast.Identifier{Name: "bucketID"},
+							Value: &ast.StringLiteral{Value: bucketID.String()},
+						},
+						{
+							Key:   &ast.Identifier{Name: "orgID"},
+							Value: &ast.StringLiteral{Value: orgID.String()},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	options := []*ast.Property{
+		{
+			Key:   &ast.Identifier{Name: "name"},
+			Value: &ast.StringLiteral{Value: stmt.Name},
+		},
+		{
+			Key:   &ast.Identifier{Name: "every"},
+			Value: &ast.DurationLiteral{Values: durationLiteral(every)},
+		},
+	}
+	if offset > 0 {
+		options = append(options, &ast.Property{
+			Key:   &ast.Identifier{Name: "offset"},
+			Value: &ast.DurationLiteral{Values: durationLiteral(offset)},
+		})
+	}
+
+	body := make([]ast.Statement, 0, len(state.file.Body)+4)
+	body = append(body, &ast.OptionStatement{
+		Assignment: &ast.VariableAssignment{
+			ID:   &ast.Identifier{Name: "task"},
+			Init: &ast.ObjectExpression{Properties: options},
+		},
+	})
+	body = append(body,
+		&ast.VariableAssignment{
+			ID:   stop,
+			Init: state.groupByBoundary(interval, offset),
+		},
+		&ast.VariableAssignment{
+			ID:   start,
+			Init: state.subDuration(resampleFor, stop),
+		},
+	)
+	body = append(body, state.file.Body...)
+	body = append(body, &ast.ExpressionStatement{Expression: expr})
+	state.file.Body = body
+
+	return &ast.Package{
+		Package: "main",
+		Files: []*ast.File{
+			state.file,
+		},
+	}, nil
+}
+
+// targetBucketID returns the bucket mapped to the database and retention
+// policy the continuous query writes into.
+func (t *Transpiler) targetBucketID(ctx context.Context, orgID influxdb.ID, db, rp string) (influxdb.ID, error) {
+	if t.dbrpMappingSvc == nil {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "unable to transpile: db and rp mappings need to be created by some way",
+		}
+	}
+
+	filter := influxdb.DBRPMappingFilterV2{
+		OrgID:    &orgID,
+		Database: &db,
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	} else {
+		isDefault := true
+		filter.Default = &isDefault
+	}
+	mappings, _, err := t.dbrpMappingSvc.FindMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	} else if len(mappings) == 0 {
+		return 0, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("unable to transpile: no bucket mapped to target database %q and retention policy %q", db, rp),
+		}
+	}
+	return mappings[0].BucketID, nil
+}
+
+// groupByBoundary returns the boundary of the GROUP BY time intervals the
+// task runs at, as the continuous queries of 1.x do. now() is the scheduled
+// time of the run, which doesn't include the offset of the task.
+func (t *transpilerState) groupByBoundary(interval, offset time.Duration) ast.Expression {
+	now := &ast.CallExpression{
+		Callee: &ast.Identifier{Name: "now"},
+	}
+
+	date := t.requireImport("date")
+	var boundary ast.Expression = &ast.CallExpression{
+		Callee: &ast.MemberExpression{
+			Object:   date,
+			Property: &ast.Identifier{Name: "truncate"},
+		},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: []*ast.Property{
+					{
+						Key:   &ast.Identifier{Name: "t"},
+						Value: now,
+					},
+					{
+						Key:   &ast.Identifier{Name: "unit"},
+						Value: &ast.DurationLiteral{Values: durationLiteral(interval)},
+					},
+				},
+			},
+		},
+	}
+	if offset > 0 {
+		boundary = t.addDuration(offset, boundary)
+	}
+	return boundary
+}
+
+// subDuration returns the time d before the time of expr.
+func (t *transpilerState) subDuration(d time.Duration, expr ast.Expression) ast.Expression {
+	return t.durationCall("subDuration", "from", d, expr)
+}
+
+// addDuration returns the time d after the time of expr.
+func (t *transpilerState) addDuration(d time.Duration, expr ast.Expression) ast.Expression {
+	return t.durationCall("addDuration", "to", d, expr)
+}
+
+func (t *transpilerState) durationCall(fn, arg string, d time.Duration, expr ast.Expression) ast.Expression {
+	experimental := t.requireImport("experimental")
+	return &ast.CallExpression{
+		Callee: &ast.MemberExpression{
+			Object:   experimental,
+			Property: &ast.Identifier{Name: fn},
+		},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: []*ast.Property{
+					{
+						Key:   &ast.Identifier{Name: "d"},
+						Value: &ast.DurationLiteral{Values: durationLiteral(d)},
+					},
+					{
+						Key:   &ast.Identifier{Name: arg},
+						Value: expr,
+					},
+				},
+			},
+		},
+	}
+}
+
+// rangeRewriter replaces the arguments of every call to range
+// with the start and stop of the data the task recomputes.
+type rangeRewriter struct {
+	start ast.Expression
+	stop  ast.Expression
+}
+
+func (v rangeRewriter) Visit(node ast.Node) ast.Visitor {
+	call, ok := node.(*ast.CallExpression)
+	if !ok {
+		return v
+	}
+	if ident, ok := call.Callee.(*ast.Identifier); !ok || ident.Name != "range" {
+		return v
+	}
+	call.Arguments = []ast.Expression{
+		&ast.ObjectExpression{
+			Properties: []*ast.Property{
+				{
+					Key:   &ast.Identifier{Name: "start"},
+					Value: v.start,
+				},
+				{
+					Key:   &ast.Identifier{Name: "stop"},
+					Value: v.stop,
+				},
+			},
+		},
+	}
+	return v
+}
+
+func (v rangeRewriter) Done(node ast.Node) {}
@@ -0,0 +1,96 @@
+package influxql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	platformtesting "github.com/influxdata/influxdb/v2/testing"
+	iql "github.com/influxdata/influxql"
+)
+
+func TestTranspiler_TranspileContinuousQuery(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    string
+		want string
+		err  string
+	}{
+		{
+			name: "group by interval",
+			s:    `CREATE CONTINUOUS QUERY cq0 ON db0 BEGIN SELECT mean(value) INTO cpu_1h FROM cpu GROUP BY time(1h), host END`,
+			want: `package main
+import experimental "experimental"
+import date "date"
+
+option task = {name: "cq0", every: 1h}
+
+stop = date.truncate(t: now(), unit: 1h)
+start = experimental.subDuration(d: 1h, from: stop)
+
+from(bucketID: "bbbbbbbbbbbbbbbb")
+	|> range(start: start, stop: stop)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu" and r._field == "value"))
+	|> group(columns: ["_measurement", "_start", "_stop", "_field", "host"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "host", "_time", "_value"])
+	|> window(every: 1h)
+	|> mean()
+	|> map(fn: (r) =>
+		({r with _time: r._start}))
+	|> window(every: inf)
+	|> rename(columns: {_value: "mean"})
+	|> drop(fn: (column) =>
+		(column == "_field"))
+	|> set(key: "_measurement", value: "cpu_1h")
+	|> experimental.to(bucketID: "bbbbbbbbbbbbbbbb", orgID: "aaaaaaaaaaaaaaaa")`,
+		},
+		{
+			name: "resample",
+			s:    `CREATE CONTINUOUS QUERY cq0 ON db0 RESAMPLE EVERY 5m FOR 2h BEGIN SELECT max(value) INTO db0.autogen.:MEASUREMENT FROM cpu GROUP BY time(1h, 15m) END`,
+			want: `package main
+import experimental "experimental"
+import date "date"
+
+option task = {name: "cq0", every: 5m, offset: 15m}
+
+stop = experimental.addDuration(d: 15m, to: date.truncate(t: now(), unit: 1h))
+start = experimental.subDuration(d: 2h, from: stop)
+
+from(bucketID: "bbbbbbbbbbbbbbbb")
+	|> range(start: start, stop: stop)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu" and r._field == "value"))
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> window(every: 1h, start: 1970-01-01T00:15:00Z)
+	|> max()
+	|> drop(columns: ["_time"])
+	|> map(fn: (r) =>
+		({r with _time: r._start}))
+	|> window(every: inf)
+	|> rename(columns: {_value: "max"})
+	|> drop(fn: (column) =>
+		(column == "_field"))
+	|> experimental.to(bucketID: "bbbbbbbbbbbbbbbb", orgID: "aaaaaaaaaaaaaaaa")`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := iql.ParseStatement(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			transpiler := influxql.NewTranspiler(dbrpMappingSvc)
+			pkg, err := transpiler.TranspileContinuousQuery(context.Background(), stmt.(*iql.CreateContinuousQueryStatement), platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ast.Format(pkg); got != tt.want {
+				t.Fatalf("unexpected script -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -105,7 +105,9 @@ func (s *LoggingProxyQueryService) Query(ctx context.Context, w io.Writer, req *
 			Statistics:     stats,
 			Error:          err,
 		}
-		s.queryLogger.Log(log)
+		if lerr := s.queryLogger.Log(log); lerr != nil {
+			s.log.Info("Failed to log query", zap.Error(lerr))
+		}
 	}()
 
 	wc := &iocounter.Writer{Writer: w}
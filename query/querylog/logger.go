@@ -0,0 +1,159 @@
+// Package querylog keeps a log of the executed queries in a bucket, so
+// expensive queries can be found with Flux.
+package querylog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/metadata"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/storage"
+)
+
+// Measurement is the measurement of the query log points.
+const Measurement = "queries"
+
+// Values of the status tag of the query log points.
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Metadata keys of the scanned data in the query statistics.
+const (
+	scannedBytesKey  = "influxdb/scanned-bytes"
+	scannedValuesKey = "influxdb/scanned-values"
+)
+
+// BucketLogger is a query.Logger which writes a point for each query into
+// a bucket of the organization of the query, such as its monitoring
+// system bucket.
+type BucketLogger struct {
+	// Wrapped points writer the query logs are written to.
+	PointsWriter storage.PointsWriter
+
+	// Service used to look up the log bucket.
+	BucketFinder storage.BucketFinder
+
+	// Name of the bucket to log to.
+	BucketName string
+
+	// SlowQueryThreshold is the total duration from which queries are
+	// logged. Failed queries are logged regardless. If this is unset,
+	// every query is logged.
+	SlowQueryThreshold time.Duration
+}
+
+var _ query.Logger = (*BucketLogger)(nil)
+
+// Log writes the query log into the log bucket of the organization of the
+// query, unless the query is faster than the slow query threshold.
+func (l *BucketLogger) Log(ql query.Log) error {
+	if !ql.OrganizationID.Valid() {
+		return nil
+	}
+	failed := ql.Error != nil || len(ql.Statistics.RuntimeErrors) > 0
+	if !failed && ql.Statistics.TotalDuration < l.SlowQueryThreshold {
+		return nil
+	}
+
+	ctx := context.Background()
+	orgID := ql.OrganizationID
+	bkts, n, err := l.BucketFinder.FindBuckets(ctx, influxdb.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &l.BucketName,
+	})
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("query log bucket not found: %q", l.BucketName)
+	}
+
+	pt, err := NewPoint(ql)
+	if err != nil {
+		return err
+	}
+	return l.PointsWriter.WritePoints(ctx, orgID, bkts[0].ID, []models.Point{pt})
+}
+
+// NewPoint returns the point of a query log.
+func NewPoint(ql query.Log) (models.Point, error) {
+	stats := ql.Statistics
+	tags := models.NewTags(map[string]string{
+		"status": StatusSuccess,
+	})
+	fields := models.Fields{
+		"compileDuration": int64(stats.CompileDuration),
+		"queueDuration":   int64(stats.QueueDuration),
+		"executeDuration": int64(stats.ExecuteDuration),
+		"totalDuration":   int64(stats.TotalDuration),
+		"responseSize":    ql.ResponseSize,
+		"scannedBytes":    sumMetadata(stats.Metadata, scannedBytesKey),
+		"scannedValues":   sumMetadata(stats.Metadata, scannedValuesKey),
+	}
+
+	if ql.ProxyRequest != nil {
+		req := ql.ProxyRequest.Request
+		if text := queryText(req.Compiler); text != "" {
+			fields["query"] = text
+		}
+		if req.Authorization != nil && req.Authorization.ID.Valid() {
+			fields["tokenID"] = req.Authorization.ID.String()
+		}
+	}
+	if ql.TraceID != "" {
+		fields["traceID"] = ql.TraceID
+	}
+
+	var errs []string
+	if ql.Error != nil {
+		errs = append(errs, ql.Error.Error())
+	}
+	errs = append(errs, stats.RuntimeErrors...)
+	if len(errs) > 0 {
+		tags.Set([]byte("status"), []byte(StatusFailed))
+		fields["error"] = strings.Join(errs, "; ")
+	}
+
+	return models.NewPoint(Measurement, tags, fields, ql.Time)
+}
+
+// queryText returns the text of the query of the compiler, if it has one.
+func queryText(c interface{}) string {
+	switch c := c.(type) {
+	case lang.FluxCompiler:
+		return c.Query
+	case *lang.FluxCompiler:
+		return c.Query
+	case lang.ASTCompiler:
+		return string(c.AST)
+	case *lang.ASTCompiler:
+		return string(c.AST)
+	case *influxql.Compiler:
+		return c.Query
+	default:
+		return ""
+	}
+}
+
+// sumMetadata returns the sum of the integer values of a metadata key,
+// one of which is added by each source of the query.
+func sumMetadata(md metadata.Metadata, key string) int64 {
+	var sum int64
+	for _, v := range md[key] {
+		switch v := v.(type) {
+		case int:
+			sum += int64(v)
+		case int64:
+			sum += v
+		}
+	}
+	return sum
+}
@@ -0,0 +1,146 @@
+package querylog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/metadata"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/querylog"
+)
+
+var (
+	orgID    = influxdb.ID(10)
+	bucketID = influxdb.ID(20)
+	tokenID  = influxdb.ID(30)
+	logTime  = time.Unix(100, 0).UTC()
+)
+
+func newLog(total time.Duration, err error) query.Log {
+	md := make(metadata.Metadata)
+	md.Add("influxdb/scanned-bytes", 100)
+	md.Add("influxdb/scanned-bytes", 20)
+	md.Add("influxdb/scanned-values", 7)
+	return query.Log{
+		OrganizationID: orgID,
+		TraceID:        "trace",
+		Time:           logTime,
+		Error:          err,
+		ResponseSize:   42,
+		ProxyRequest: &query.ProxyRequest{
+			Request: query.Request{
+				Authorization:  &influxdb.Authorization{ID: tokenID},
+				OrganizationID: orgID,
+				Compiler:       lang.FluxCompiler{Query: `from(bucket: "b")`},
+			},
+		},
+		Statistics: flux.Statistics{
+			TotalDuration:   total,
+			CompileDuration: 1,
+			QueueDuration:   2,
+			ExecuteDuration: 3,
+			Metadata:        md,
+		},
+	}
+}
+
+func newLogger(threshold time.Duration) (*querylog.BucketLogger, *mock.PointsWriter) {
+	pw := &mock.PointsWriter{}
+	bs := mock.NewBucketService()
+	bs.FindBucketsFn = func(_ context.Context, f influxdb.BucketFilter, _ ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		if *f.OrganizationID != orgID || *f.Name != influxdb.MonitoringSystemBucketName {
+			return nil, 0, nil
+		}
+		return []*influxdb.Bucket{{ID: bucketID}}, 1, nil
+	}
+	return &querylog.BucketLogger{
+		PointsWriter:       pw,
+		BucketFinder:       bs,
+		BucketName:         influxdb.MonitoringSystemBucketName,
+		SlowQueryThreshold: threshold,
+	}, pw
+}
+
+func TestBucketLogger_Log(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		threshold time.Duration
+		log       query.Log
+		want      string
+	}{
+		{
+			name: "every query without threshold",
+			log:  newLog(time.Millisecond, nil),
+			want: `queries,status=success compileDuration=1i,executeDuration=3i,query="from(bucket: \"b\")",queueDuration=2i,responseSize=42i,scannedBytes=120i,scannedValues=7i,tokenID="000000000000001e",totalDuration=1000000i,traceID="trace" 100000000000`,
+		},
+		{
+			name:      "fast query below threshold",
+			threshold: time.Second,
+			log:       newLog(time.Millisecond, nil),
+		},
+		{
+			name:      "slow query",
+			threshold: time.Second,
+			log:       newLog(2*time.Second, nil),
+			want:      `queries,status=success compileDuration=1i,executeDuration=3i,query="from(bucket: \"b\")",queueDuration=2i,responseSize=42i,scannedBytes=120i,scannedValues=7i,tokenID="000000000000001e",totalDuration=2000000000i,traceID="trace" 100000000000`,
+		},
+		{
+			name:      "failed query below threshold",
+			threshold: time.Second,
+			log:       newLog(time.Millisecond, errors.New("oops")),
+			want:      `queries,status=failed compileDuration=1i,error="oops",executeDuration=3i,query="from(bucket: \"b\")",queueDuration=2i,responseSize=42i,scannedBytes=120i,scannedValues=7i,tokenID="000000000000001e",totalDuration=1000000i,traceID="trace" 100000000000`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l, pw := newLogger(tt.threshold)
+			if err := l.Log(tt.log); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want == "" {
+				if n := pw.WritePointsCalled(); n != 0 {
+					t.Fatalf("expected no points to be written, got %d writes", n)
+				}
+				return
+			}
+			pts := pw.Points
+			if len(pts) != 1 {
+				t.Fatalf("expected one point, got %d", len(pts))
+			}
+			if got := pts[0].String(); got != tt.want {
+				t.Errorf("unexpected point:\ngot  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketLogger_Log_BucketNotFound(t *testing.T) {
+	l, pw := newLogger(0)
+	l.BucketName = "missing"
+	if err := l.Log(newLog(time.Millisecond, nil)); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := pw.WritePointsCalled(); n != 0 {
+		t.Errorf("expected no points to be written, got %d writes", n)
+	}
+}
+
+func TestNewPoint_RuntimeErrors(t *testing.T) {
+	ql := newLog(time.Millisecond, nil)
+	ql.ProxyRequest = nil
+	ql.Statistics.RuntimeErrors = []string{"a", "b"}
+	pt, err := querylog.NewPoint(ql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `queries,status=failed compileDuration=1i,error="a; b",executeDuration=3i,queueDuration=2i,responseSize=42i,scannedBytes=120i,scannedValues=7i,totalDuration=1000000i,traceID="trace" 100000000000`
+	if got := pt.String(); got != want {
+		t.Errorf("unexpected point:\ngot  %s\nwant %s", got, want)
+	}
+}
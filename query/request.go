@@ -33,12 +33,43 @@ type Request struct {
 	// Source represents the ultimate source of the request.
 	Source string `json:"source"`
 
+	// Priority is the scheduling class of the query in the query controller.
+	Priority Priority `json:"priority,omitempty"`
+
 	// compilerMappings maps compiler types to creation methods
 	compilerMappings flux.CompilerMappings
 
 	options []RequestHeaderOption
 }
 
+// Priority is the scheduling class of a query. When queries are queued,
+// the query controller starts the queries of higher priority classes first.
+type Priority int
+
+const (
+	// PriorityInteractive is the class of queries waited on by a user,
+	// such as the queries of dashboards. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityTask is the class of queries run by the task executor.
+	PriorityTask
+	// PriorityBackground is the class of queries nobody is waiting on,
+	// which may be delayed by all other queries.
+	PriorityBackground
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityInteractive:
+		return "interactive"
+	case PriorityTask:
+		return "task"
+	case PriorityBackground:
+		return "background"
+	default:
+		return "unknown"
+	}
+}
+
 // SetReturnNoContent sets the header for a Request to return no content.
 func SetReturnNoContent(header http.Header, withError bool) {
 	if withError {
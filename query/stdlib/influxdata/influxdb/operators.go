@@ -118,6 +118,10 @@ type ReadWindowAggregatePhysSpec struct {
 	Aggregates  []plan.ProcedureKind
 	CreateEmpty bool
 	TimeColumn  string
+
+	// Quantile and Compression are the arguments of the quantile aggregate.
+	Quantile    float64
+	Compression float64
 }
 
 func (s *ReadWindowAggregatePhysSpec) PlanDetails() string {
@@ -137,6 +141,8 @@ func (s *ReadWindowAggregatePhysSpec) Copy() plan.ProcedureSpec {
 	ns.Aggregates = s.Aggregates
 	ns.CreateEmpty = s.CreateEmpty
 	ns.TimeColumn = s.TimeColumn
+	ns.Quantile = s.Quantile
+	ns.Compression = s.Compression
 
 	return ns
 }
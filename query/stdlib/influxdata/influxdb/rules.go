@@ -30,7 +30,7 @@ func init() {
 		PushDownWindowAggregateByTimeRule{},
 		PushDownBareAggregateRule{},
 		GroupWindowAggregateTransposeRule{},
-		// PushDownGroupAggregateRule{},
+		PushDownGroupAggregateRule{},
 	)
 	plan.RegisterLogicalRules(
 		MergeFiltersRule{},
@@ -653,7 +653,7 @@ func (SortedPivotRule) Rewrite(ctx context.Context, pn plan.Node) (plan.Node, bo
 
 //
 // Push Down of window aggregates.
-// ReadRangePhys |> window |> { min, max, mean, count, sum }
+// ReadRangePhys |> window |> { min, max, mean, count, sum, first, last, spread, stddev, quantile }
 //
 type PushDownWindowAggregateRule struct{}
 
@@ -669,6 +669,9 @@ var windowPushableAggs = []plan.ProcedureKind{
 	universe.MeanKind,
 	universe.FirstKind,
 	universe.LastKind,
+	universe.SpreadKind,
+	universe.StddevKind,
+	universe.QuantileKind,
 }
 
 func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
@@ -704,10 +707,37 @@ func canPushWindowedAggregate(ctx context.Context, fnNode plan.Node) bool {
 	case universe.LastKind:
 		lastSpec := fnNode.ProcedureSpec().(*universe.LastProcedureSpec)
 		return lastSpec.Column == execute.DefaultValueColLabel
+	case universe.SpreadKind:
+		spreadSpec := fnNode.ProcedureSpec().(*universe.SpreadProcedureSpec)
+		return len(spreadSpec.Columns) == 1 &&
+			spreadSpec.Columns[0] == execute.DefaultValueColLabel
+	case universe.StddevKind:
+		// Storage only computes the sample standard deviation.
+		stddevSpec := fnNode.ProcedureSpec().(*universe.StddevProcedureSpec)
+		return stddevSpec.Mode == "sample" &&
+			len(stddevSpec.Columns) == 1 &&
+			stddevSpec.Columns[0] == execute.DefaultValueColLabel
+	case universe.QuantileKind:
+		// Only the estimated quantile uses this kind; the exact
+		// methods are planned as different procedures.
+		quantileSpec, ok := fnNode.ProcedureSpec().(*universe.TDigestQuantileProcedureSpec)
+		return ok &&
+			len(quantileSpec.Columns) == 1 &&
+			quantileSpec.Columns[0] == execute.DefaultValueColLabel
 	}
 	return true
 }
 
+// withAggregateArgs copies the arguments of the aggregate function
+// that storage needs to compute it to the window aggregate spec.
+func withAggregateArgs(spec *ReadWindowAggregatePhysSpec, fnNode plan.Node) *ReadWindowAggregatePhysSpec {
+	if quantileSpec, ok := fnNode.ProcedureSpec().(*universe.TDigestQuantileProcedureSpec); ok {
+		spec.Quantile = quantileSpec.Quantile
+		spec.Compression = quantileSpec.Compression
+	}
+	return spec
+}
+
 func isPushableWindow(windowSpec *universe.WindowProcedureSpec) bool {
 	// every and period must be equal
 	// every.isNegative must be false
@@ -741,13 +771,13 @@ func (PushDownWindowAggregateRule) Rewrite(ctx context.Context, pn plan.Node) (p
 	}
 
 	// Rule passes.
-	return plan.CreateUniquePhysicalNode(ctx, "ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+	return plan.CreateUniquePhysicalNode(ctx, "ReadWindowAggregate", withAggregateArgs(&ReadWindowAggregatePhysSpec{
 		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
 		Aggregates:        []plan.ProcedureKind{fnNode.Kind()},
 		WindowEvery:       windowSpec.Window.Every,
 		Offset:            windowSpec.Window.Offset,
 		CreateEmpty:       windowSpec.CreateEmpty,
-	}), true, nil
+	}, fnNode)), true, nil
 }
 
 // PushDownWindowAggregateWithTimeRule will match the given pattern.
@@ -836,11 +866,11 @@ func (p PushDownBareAggregateRule) Rewrite(ctx context.Context, pn plan.Node) (p
 	fromNode := fnNode.Predecessors()[0]
 	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
 
-	return plan.CreateUniquePhysicalNode(ctx, "ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+	return plan.CreateUniquePhysicalNode(ctx, "ReadWindowAggregate", withAggregateArgs(&ReadWindowAggregatePhysSpec{
 		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
 		Aggregates:        []plan.ProcedureKind{fnNode.Kind()},
 		WindowEvery:       flux.ConvertDuration(math.MaxInt64 * time.Nanosecond),
-	}), true, nil
+	}, fnNode)), true, nil
 }
 
 // GroupWindowAggregateTransposeRule will match the given pattern.
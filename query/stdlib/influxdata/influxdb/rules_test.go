@@ -1150,6 +1150,24 @@ func lastProcedureSpec() *universe.LastProcedureSpec {
 		SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel},
 	}
 }
+func spreadProcedureSpec() *universe.SpreadProcedureSpec {
+	return &universe.SpreadProcedureSpec{
+		AggregateConfig: execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}},
+	}
+}
+func stddevProcedureSpec() *universe.StddevProcedureSpec {
+	return &universe.StddevProcedureSpec{
+		Mode:            "sample",
+		AggregateConfig: execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}},
+	}
+}
+func quantileProcedureSpec() *universe.TDigestQuantileProcedureSpec {
+	return &universe.TDigestQuantileProcedureSpec{
+		Quantile:        0.5,
+		Compression:     1000,
+		AggregateConfig: execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}},
+	}
+}
 func meanProcedureSpec() *universe.MeanProcedureSpec {
 	return &universe.MeanProcedureSpec{
 		AggregateConfig: execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}},
@@ -1299,6 +1317,43 @@ func TestPushDownWindowAggregateRule(t *testing.T) {
 		After:   simpleResult(universe.LastKind, false),
 	})
 
+	// ReadRange -> window -> spread => ReadWindowAggregate
+	tests = append(tests, plantest.RuleTestCase{
+		Context: context.Background(),
+		Name:    "SimplePassSpread",
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before:  simplePlanWithWindowAgg(window1m, universe.SpreadKind, spreadProcedureSpec()),
+		After:   simpleResult(universe.SpreadKind, false),
+	})
+
+	// ReadRange -> window -> stddev => ReadWindowAggregate
+	tests = append(tests, plantest.RuleTestCase{
+		Context: context.Background(),
+		Name:    "SimplePassStddev",
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before:  simplePlanWithWindowAgg(window1m, universe.StddevKind, stddevProcedureSpec()),
+		After:   simpleResult(universe.StddevKind, false),
+	})
+
+	// ReadRange -> window -> quantile => ReadWindowAggregate
+	tests = append(tests, plantest.RuleTestCase{
+		Context: context.Background(),
+		Name:    "SimplePassQuantile",
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before:  simplePlanWithWindowAgg(window1m, universe.QuantileKind, quantileProcedureSpec()),
+		After: &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+					ReadRangePhysSpec: readRange,
+					Aggregates:        []plan.ProcedureKind{universe.QuantileKind},
+					WindowEvery:       flux.ConvertDuration(60000000000 * time.Nanosecond),
+					Quantile:          0.5,
+					Compression:       1000,
+				}),
+			},
+		},
+	})
+
 	// Rewrite with successors
 	// ReadRange -> window -> min -> count {2} => ReadWindowAggregate -> count {2}
 	tests = append(tests, plantest.RuleTestCase{
@@ -1540,6 +1595,45 @@ func TestPushDownWindowAggregateRule(t *testing.T) {
 		NoChange: true,
 	})
 
+	// Bad spread column
+	// ReadRange -> window -> spread => NO-CHANGE
+	tests = append(tests, plantest.RuleTestCase{
+		Name:    "BadSpreadCol",
+		Context: context.Background(),
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before: simplePlanWithWindowAgg(window1m, "spread", &universe.SpreadProcedureSpec{
+			AggregateConfig: execute.AggregateConfig{Columns: []string{"_valmoo"}},
+		}),
+		NoChange: true,
+	})
+
+	// Population standard deviation is not computed by storage
+	// ReadRange -> window -> stddev => NO-CHANGE
+	tests = append(tests, plantest.RuleTestCase{
+		Name:    "PopulationStddev",
+		Context: context.Background(),
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before: simplePlanWithWindowAgg(window1m, "stddev", &universe.StddevProcedureSpec{
+			Mode:            "population",
+			AggregateConfig: execute.AggregateConfig{Columns: []string{"_value"}},
+		}),
+		NoChange: true,
+	})
+
+	// Bad quantile column
+	// ReadRange -> window -> quantile => NO-CHANGE
+	tests = append(tests, plantest.RuleTestCase{
+		Name:    "BadQuantileCol",
+		Context: context.Background(),
+		Rules:   []plan.Rule{influxdb.PushDownWindowAggregateRule{}},
+		Before: simplePlanWithWindowAgg(window1m, "quantile", &universe.TDigestQuantileProcedureSpec{
+			Quantile:        0.5,
+			Compression:     1000,
+			AggregateConfig: execute.AggregateConfig{Columns: []string{"_valmoo"}},
+		}),
+		NoChange: true,
+	})
+
 	// No match due to a collapsed node having a successor
 	// ReadRange -> window -> min
 	//                    \-> min
@@ -357,6 +357,8 @@ func createReadWindowAggregateSource(s plan.ProcedureSpec, id execute.DatasetID,
 			Aggregates:  spec.Aggregates,
 			CreateEmpty: spec.CreateEmpty,
 			TimeColumn:  spec.TimeColumn,
+			Quantile:    spec.Quantile,
+			Compression: spec.Compression,
 		},
 		a,
 	), nil
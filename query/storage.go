@@ -65,6 +65,10 @@ type ReadWindowAggregateSpec struct {
 	CreateEmpty bool
 	TimeColumn  string
 	Window      execute.Window
+
+	// Quantile and Compression are the arguments of the quantile aggregate.
+	Quantile    float64
+	Compression float64
 }
 
 func (spec *ReadWindowAggregateSpec) Name() string {
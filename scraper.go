@@ -2,6 +2,8 @@ package influxdb
 
 import (
 	"context"
+	"fmt"
+	"path"
 )
 
 // ErrScraperTargetNotFound is the error msg for a missing scraper target.
@@ -25,6 +27,147 @@ type ScraperTarget struct {
 	OrgID         ID          `json:"orgID,omitempty"`
 	BucketID      ID          `json:"bucketID,omitempty"`
 	AllowInsecure bool        `json:"allowInsecure,omitempty"`
+
+	// Interval overrides the default time between two scrapes of the target.
+	Interval *Duration `json:"interval,omitempty"`
+	// Timeout bounds each scrape request made to the target.
+	Timeout *Duration `json:"timeout,omitempty"`
+	// Headers are added to each scrape request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Auth holds the credentials sent with each scrape request.
+	Auth *ScraperAuth `json:"auth,omitempty"`
+	// Labels are added as tags to each metric scraped from the target.
+	Labels map[string]string `json:"labels,omitempty"`
+	// MetricInclude and MetricExclude are glob patterns matched against
+	// the names of the scraped metrics. A metric is kept when it matches
+	// any include pattern, or when there are none, and no exclude pattern.
+	MetricInclude []string `json:"metricInclude,omitempty"`
+	MetricExclude []string `json:"metricExclude,omitempty"`
+}
+
+// Scraper auth methods
+const (
+	ScraperAuthBasic  = "basic"
+	ScraperAuthBearer = "bearer"
+)
+
+const (
+	scraperTokenSuffix    = "-token"
+	scraperUsernameSuffix = "-username"
+	scraperPasswordSuffix = "-password"
+)
+
+// ScraperAuth is the authentication of a scraper target. The credentials
+// are stored in the secret service.
+type ScraperAuth struct {
+	Method   string      `json:"method"`
+	Token    SecretField `json:"token,omitempty"`
+	Username SecretField `json:"username,omitempty"`
+	Password SecretField `json:"password,omitempty"`
+}
+
+// BackfillSecretKeys fill back the secret field keys of the target auth
+// if the value of that secret field is not nil.
+func (t *ScraperTarget) BackfillSecretKeys() {
+	if t.Auth == nil {
+		return
+	}
+	if t.Auth.Token.Key == "" && t.Auth.Token.Value != nil {
+		t.Auth.Token.Key = t.ID.String() + scraperTokenSuffix
+	}
+	if t.Auth.Username.Key == "" && t.Auth.Username.Value != nil {
+		t.Auth.Username.Key = t.ID.String() + scraperUsernameSuffix
+	}
+	if t.Auth.Password.Key == "" && t.Auth.Password.Value != nil {
+		t.Auth.Password.Key = t.ID.String() + scraperPasswordSuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (t ScraperTarget) SecretFields() []SecretField {
+	arr := make([]SecretField, 0)
+	if t.Auth == nil {
+		return arr
+	}
+	if t.Auth.Token.Key != "" {
+		arr = append(arr, t.Auth.Token)
+	}
+	if t.Auth.Username.Key != "" {
+		arr = append(arr, t.Auth.Username)
+	}
+	if t.Auth.Password.Key != "" {
+		arr = append(arr, t.Auth.Password)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid.
+func (t ScraperTarget) Valid() error {
+	if t.Interval != nil && t.Interval.Duration <= 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target interval must be positive",
+		}
+	}
+	if t.Timeout != nil && t.Timeout.Duration <= 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target timeout must be positive",
+		}
+	}
+	if t.Auth != nil {
+		switch t.Auth.Method {
+		case ScraperAuthBasic:
+			if t.Auth.Username.Key == "" || t.Auth.Password.Key == "" {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "invalid scraper target username/password for basic auth",
+				}
+			}
+		case ScraperAuthBearer:
+			if t.Auth.Token.Key == "" {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "invalid scraper target token for bearer auth",
+				}
+			}
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid scraper target auth method %q", t.Auth.Method),
+			}
+		}
+	}
+	for _, patterns := range [][]string{t.MetricInclude, t.MetricExclude} {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return &Error{
+					Code: EInvalid,
+					Msg:  fmt.Sprintf("invalid scraper target metric pattern %q", p),
+					Err:  err,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MatchMetric returns true if the metric name passes the include and
+// exclude filters of the target.
+func (t ScraperTarget) MatchMetric(name string) bool {
+	if len(t.MetricInclude) > 0 && !matchAny(t.MetricInclude, name) {
+		return false
+	}
+	return !matchAny(t.MetricExclude, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -0,0 +1,108 @@
+package influxdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScraperTargetValid(t *testing.T) {
+	cases := []struct {
+		name   string
+		target ScraperTarget
+		valid  bool
+	}{
+		{name: "empty", valid: true},
+		{
+			name:   "interval",
+			target: ScraperTarget{Interval: &Duration{Duration: 10 * time.Second}},
+			valid:  true,
+		},
+		{
+			name:   "negative interval",
+			target: ScraperTarget{Interval: &Duration{Duration: -time.Second}},
+		},
+		{
+			name:   "zero timeout",
+			target: ScraperTarget{Timeout: &Duration{}},
+		},
+		{
+			name: "bearer auth",
+			target: ScraperTarget{Auth: &ScraperAuth{
+				Method: ScraperAuthBearer,
+				Token:  SecretField{Key: "key"},
+			}},
+			valid: true,
+		},
+		{
+			name:   "bearer auth without token",
+			target: ScraperTarget{Auth: &ScraperAuth{Method: ScraperAuthBearer}},
+		},
+		{
+			name: "basic auth without password",
+			target: ScraperTarget{Auth: &ScraperAuth{
+				Method:   ScraperAuthBasic,
+				Username: SecretField{Key: "key"},
+			}},
+		},
+		{
+			name:   "unknown auth method",
+			target: ScraperTarget{Auth: &ScraperAuth{Method: "digest"}},
+		},
+		{
+			name:   "bad metric pattern",
+			target: ScraperTarget{MetricExclude: []string{"go_["}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.target.Valid()
+			if c.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !c.valid && err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestScraperTargetBackfillSecretKeys(t *testing.T) {
+	target := ScraperTarget{
+		ID: 1,
+		Auth: &ScraperAuth{
+			Method:   ScraperAuthBasic,
+			Username: SecretField{Value: strPtr("user")},
+			Password: SecretField{Key: "existing"},
+		},
+	}
+	target.BackfillSecretKeys()
+
+	if got, want := target.Auth.Username.Key, "0000000000000001-username"; got != want {
+		t.Fatalf("unexpected username key: want %q, got %q", want, got)
+	}
+	if got, want := target.Auth.Password.Key, "existing"; got != want {
+		t.Fatalf("unexpected password key: want %q, got %q", want, got)
+	}
+	if got := len(target.SecretFields()); got != 2 {
+		t.Fatalf("unexpected number of secret fields: %d", got)
+	}
+}
+
+func TestScraperTargetMatchMetric(t *testing.T) {
+	target := ScraperTarget{
+		MetricInclude: []string{"go_*"},
+		MetricExclude: []string{"go_memstats_*"},
+	}
+	for name, want := range map[string]bool{
+		"go_goroutines":           true,
+		"go_memstats_alloc_bytes": false,
+		"process_cpu_seconds":     false,
+	} {
+		if got := target.MatchMetric(name); got != want {
+			t.Errorf("MatchMetric(%q): want %v, got %v", name, want, got)
+		}
+	}
+	if !(ScraperTarget{}).MatchMetric("anything") {
+		t.Error("expected target without filters to match all metrics")
+	}
+}
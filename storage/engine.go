@@ -12,6 +12,7 @@ import (
 	"github.com/influxdata/influxdb/v2/influxql/query"
 	"github.com/influxdata/influxdb/v2/kit/tracing"
 	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/pkg/estimator"
 	"github.com/influxdata/influxdb/v2/tsdb"
 	_ "github.com/influxdata/influxdb/v2/tsdb/engine"
 	_ "github.com/influxdata/influxdb/v2/tsdb/index/tsi1"
@@ -45,6 +46,7 @@ type Engine struct {
 	metaClient   MetaClient
 	pointsWriter interface {
 		WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error
+		Statistics(tags map[string]string) []models.Statistic
 		Close() error
 	}
 
@@ -52,6 +54,7 @@ type Engine struct {
 	precreatorService *precreator.Service
 
 	defaultMetricLabels prometheus.Labels
+	writeMetrics        *writeMetrics
 
 	writePointsValidationEnabled bool
 
@@ -87,7 +90,14 @@ type MetaClient interface {
 type TSDBStore interface {
 	DeleteMeasurement(database, name string) error
 	DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error
+	DeleteShard(id uint64) error
+	FieldKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error)
 	MeasurementNames(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error)
+	MeasurementNamesByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error)
+	MeasurementsSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
+	SeriesCardinalityByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.SeriesCardinality, error)
+	SeriesKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error)
+	SeriesSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
 	ShardGroup(ids []uint64) tsdb.ShardGroup
 	Shards(ids []uint64) []*tsdb.Shard
 	TagKeys(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
@@ -116,6 +126,9 @@ func NewEngine(path string, c Config, options ...Option) *Engine {
 
 	e.tsdbStore.EngineOptions.Config = c.Data
 
+	e.writeMetrics = newWriteMetrics(e.defaultMetricLabels)
+	e.tsdbStore.EngineOptions.OnCardinalityLimit = e.writeMetrics.cardinalityLimit
+
 	// Copy TSDB configuration.
 	e.tsdbStore.EngineOptions.EngineVersion = c.Data.Engine
 	e.tsdbStore.EngineOptions.IndexVersion = c.Data.Index
@@ -135,6 +148,13 @@ func NewEngine(path string, c Config, options ...Option) *Engine {
 	return e
 }
 
+// Statistics returns statistics of the points writer and of the store, which
+// includes the statistics of the databases, shards, TSM engines, caches and WALs.
+func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
+	statistics := e.pointsWriter.Statistics(tags)
+	return append(statistics, e.tsdbStore.Statistics(tags)...)
+}
+
 // WithLogger sets the logger on the Store. It must be called before Open.
 func (e *Engine) WithLogger(log *zap.Logger) {
 	e.logger = log.With(zap.String("service", "storage-engine"))
@@ -156,7 +176,7 @@ func (e *Engine) WithLogger(log *zap.Logger) {
 // PrometheusCollectors returns all the prometheus collectors associated with
 // the engine and its components.
 func (e *Engine) PrometheusCollectors() []prometheus.Collector {
-	return nil
+	return e.writeMetrics.PrometheusCollectors()
 }
 
 // Open opens the store and all underlying resources. It returns an error if
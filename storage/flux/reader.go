@@ -650,7 +650,11 @@ func (wai *windowAggregateIterator) Do(f func(flux.Table) error) error {
 		if agg, err := determineAggregateMethod(string(aggKind)); err != nil {
 			return err
 		} else if agg != datatypes.AggregateTypeNone {
-			req.Aggregate[i] = &datatypes.Aggregate{Type: agg}
+			req.Aggregate[i] = &datatypes.Aggregate{
+				Type:        agg,
+				Quantile:    wai.spec.Quantile,
+				Compression: wai.spec.Compression,
+			}
 		}
 	}
 
@@ -743,25 +743,46 @@ func (t *floatGroupTable) advance() bool {
 		return false
 	}
 
-	ts, v := aggregate(arr.Timestamps, arr.Values)
+	// For selectors, the columns that are not part of the group key take
+	// their values from the series the selected point came from. Only the
+	// point selected so far is kept, along with the tags of its series,
+	// which are cloned when the selected point moves to another series.
+	isSelector := IsSelector(t.gc.Aggregate())
+	var selectedTags models.Tags
+	if isSelector {
+		selectedTags = t.gc.Tags().Clone()
+	}
+
+	ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 	timestamps, values := []int64{ts}, []float64{v}
+	seriesIdx, selectedIdx := 0, 0
 	for {
 		arr = t.cur.Next()
 		if arr.Len() > 0 {
-			ts, v := aggregate(arr.Timestamps, arr.Values)
+			ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 			timestamps = append(timestamps, ts)
 			values = append(values, v)
+			if isSelector {
+				ts, v, i := aggregate(timestamps, values)
+				if i == 1 && selectedIdx != seriesIdx {
+					selectedTags, selectedIdx = t.gc.Tags().Clone(), seriesIdx
+				}
+				timestamps, values = timestamps[:1], values[:1]
+				timestamps[0], values[0] = ts, v
+			}
 			continue
 		}
 
 		if !t.advanceCursor() {
 			break
 		}
+		seriesIdx++
 	}
-	timestamp, value := aggregate(timestamps, values)
+	timestamp, value, _ := aggregate(timestamps, values)
 
 	colReader := t.allocateBuffer(1)
-	if IsSelector(t.gc.Aggregate()) {
+	if isSelector {
+		t.readTags(selectedTags)
 		colReader.cols[timeColIdx] = arrow.NewInt([]int64{timestamp}, t.alloc)
 		colReader.cols[valueColIdx] = t.toArrowBuffer([]float64{value})
 	} else {
@@ -772,12 +793,13 @@ func (t *floatGroupTable) advance() bool {
 	return true
 }
 
-type floatAggregateMethod func([]int64, []float64) (int64, float64)
+type floatAggregateMethod func([]int64, []float64) (int64, float64, int)
 
 // determineFloatAggregateMethod returns the method for aggregating
 // returned points within the same group. The incoming points are the
 // ones returned for each series and the method returned here will
-// aggregate the aggregates.
+// aggregate the aggregates. Along with the result, the method returns
+// the index of the selected point, which is only meaningful for selectors.
 func determineFloatAggregateMethod(agg datatypes.Aggregate_AggregateType) (floatAggregateMethod, error) {
 	switch agg {
 	case datatypes.AggregateTypeFirst:
@@ -811,72 +833,80 @@ func determineFloatAggregateMethod(agg datatypes.Aggregate_AggregateType) (float
 	}
 }
 
-func aggregateMinGroupsFloat(timestamps []int64, values []float64) (int64, float64) {
+func aggregateMinGroupsFloat(timestamps []int64, values []float64) (int64, float64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value > values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateMaxGroupsFloat(timestamps []int64, values []float64) (int64, float64) {
+func aggregateMaxGroupsFloat(timestamps []int64, values []float64) (int64, float64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value < values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 // For group count and sum, the timestamp here is always math.MaxInt64.
 // their final result does not contain _time, so this timestamp value can be anything
 // and it won't matter.
 
-func aggregateSumGroupsFloat(_ []int64, values []float64) (int64, float64) {
+func aggregateSumGroupsFloat(_ []int64, values []float64) (int64, float64, int) {
 	var sum float64
 	for _, v := range values {
 		sum += v
 	}
-	return math.MaxInt64, sum
+	return math.MaxInt64, sum, 0
 }
 
-func aggregateFirstGroupsFloat(timestamps []int64, values []float64) (int64, float64) {
+func aggregateFirstGroupsFloat(timestamps []int64, values []float64) (int64, float64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp > timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateLastGroupsFloat(timestamps []int64, values []float64) (int64, float64) {
+func aggregateLastGroupsFloat(timestamps []int64, values []float64) (int64, float64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp < timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 func (t *floatGroupTable) advanceCursor() bool {
@@ -1639,25 +1669,46 @@ func (t *integerGroupTable) advance() bool {
 		return false
 	}
 
-	ts, v := aggregate(arr.Timestamps, arr.Values)
+	// For selectors, the columns that are not part of the group key take
+	// their values from the series the selected point came from. Only the
+	// point selected so far is kept, along with the tags of its series,
+	// which are cloned when the selected point moves to another series.
+	isSelector := IsSelector(t.gc.Aggregate())
+	var selectedTags models.Tags
+	if isSelector {
+		selectedTags = t.gc.Tags().Clone()
+	}
+
+	ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 	timestamps, values := []int64{ts}, []int64{v}
+	seriesIdx, selectedIdx := 0, 0
 	for {
 		arr = t.cur.Next()
 		if arr.Len() > 0 {
-			ts, v := aggregate(arr.Timestamps, arr.Values)
+			ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 			timestamps = append(timestamps, ts)
 			values = append(values, v)
+			if isSelector {
+				ts, v, i := aggregate(timestamps, values)
+				if i == 1 && selectedIdx != seriesIdx {
+					selectedTags, selectedIdx = t.gc.Tags().Clone(), seriesIdx
+				}
+				timestamps, values = timestamps[:1], values[:1]
+				timestamps[0], values[0] = ts, v
+			}
 			continue
 		}
 
 		if !t.advanceCursor() {
 			break
 		}
+		seriesIdx++
 	}
-	timestamp, value := aggregate(timestamps, values)
+	timestamp, value, _ := aggregate(timestamps, values)
 
 	colReader := t.allocateBuffer(1)
-	if IsSelector(t.gc.Aggregate()) {
+	if isSelector {
+		t.readTags(selectedTags)
 		colReader.cols[timeColIdx] = arrow.NewInt([]int64{timestamp}, t.alloc)
 		colReader.cols[valueColIdx] = t.toArrowBuffer([]int64{value})
 	} else {
@@ -1668,12 +1719,13 @@ func (t *integerGroupTable) advance() bool {
 	return true
 }
 
-type integerAggregateMethod func([]int64, []int64) (int64, int64)
+type integerAggregateMethod func([]int64, []int64) (int64, int64, int)
 
 // determineIntegerAggregateMethod returns the method for aggregating
 // returned points within the same group. The incoming points are the
 // ones returned for each series and the method returned here will
-// aggregate the aggregates.
+// aggregate the aggregates. Along with the result, the method returns
+// the index of the selected point, which is only meaningful for selectors.
 func determineIntegerAggregateMethod(agg datatypes.Aggregate_AggregateType) (integerAggregateMethod, error) {
 	switch agg {
 	case datatypes.AggregateTypeFirst:
@@ -1704,76 +1756,84 @@ func determineIntegerAggregateMethod(agg datatypes.Aggregate_AggregateType) (int
 	}
 }
 
-func aggregateMinGroupsInteger(timestamps []int64, values []int64) (int64, int64) {
+func aggregateMinGroupsInteger(timestamps []int64, values []int64) (int64, int64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value > values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateMaxGroupsInteger(timestamps []int64, values []int64) (int64, int64) {
+func aggregateMaxGroupsInteger(timestamps []int64, values []int64) (int64, int64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value < values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 // For group count and sum, the timestamp here is always math.MaxInt64.
 // their final result does not contain _time, so this timestamp value can be anything
 // and it won't matter.
 
-func aggregateCountGroupsInteger(timestamps []int64, values []int64) (int64, int64) {
+func aggregateCountGroupsInteger(timestamps []int64, values []int64) (int64, int64, int) {
 	return aggregateSumGroupsInteger(timestamps, values)
 }
 
-func aggregateSumGroupsInteger(_ []int64, values []int64) (int64, int64) {
+func aggregateSumGroupsInteger(_ []int64, values []int64) (int64, int64, int) {
 	var sum int64
 	for _, v := range values {
 		sum += v
 	}
-	return math.MaxInt64, sum
+	return math.MaxInt64, sum, 0
 }
 
-func aggregateFirstGroupsInteger(timestamps []int64, values []int64) (int64, int64) {
+func aggregateFirstGroupsInteger(timestamps []int64, values []int64) (int64, int64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp > timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateLastGroupsInteger(timestamps []int64, values []int64) (int64, int64) {
+func aggregateLastGroupsInteger(timestamps []int64, values []int64) (int64, int64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp < timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 func (t *integerGroupTable) advanceCursor() bool {
@@ -2534,25 +2594,46 @@ func (t *unsignedGroupTable) advance() bool {
 		return false
 	}
 
-	ts, v := aggregate(arr.Timestamps, arr.Values)
+	// For selectors, the columns that are not part of the group key take
+	// their values from the series the selected point came from. Only the
+	// point selected so far is kept, along with the tags of its series,
+	// which are cloned when the selected point moves to another series.
+	isSelector := IsSelector(t.gc.Aggregate())
+	var selectedTags models.Tags
+	if isSelector {
+		selectedTags = t.gc.Tags().Clone()
+	}
+
+	ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 	timestamps, values := []int64{ts}, []uint64{v}
+	seriesIdx, selectedIdx := 0, 0
 	for {
 		arr = t.cur.Next()
 		if arr.Len() > 0 {
-			ts, v := aggregate(arr.Timestamps, arr.Values)
+			ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 			timestamps = append(timestamps, ts)
 			values = append(values, v)
+			if isSelector {
+				ts, v, i := aggregate(timestamps, values)
+				if i == 1 && selectedIdx != seriesIdx {
+					selectedTags, selectedIdx = t.gc.Tags().Clone(), seriesIdx
+				}
+				timestamps, values = timestamps[:1], values[:1]
+				timestamps[0], values[0] = ts, v
+			}
 			continue
 		}
 
 		if !t.advanceCursor() {
 			break
 		}
+		seriesIdx++
 	}
-	timestamp, value := aggregate(timestamps, values)
+	timestamp, value, _ := aggregate(timestamps, values)
 
 	colReader := t.allocateBuffer(1)
-	if IsSelector(t.gc.Aggregate()) {
+	if isSelector {
+		t.readTags(selectedTags)
 		colReader.cols[timeColIdx] = arrow.NewInt([]int64{timestamp}, t.alloc)
 		colReader.cols[valueColIdx] = t.toArrowBuffer([]uint64{value})
 	} else {
@@ -2563,12 +2644,13 @@ func (t *unsignedGroupTable) advance() bool {
 	return true
 }
 
-type unsignedAggregateMethod func([]int64, []uint64) (int64, uint64)
+type unsignedAggregateMethod func([]int64, []uint64) (int64, uint64, int)
 
 // determineUnsignedAggregateMethod returns the method for aggregating
 // returned points within the same group. The incoming points are the
 // ones returned for each series and the method returned here will
-// aggregate the aggregates.
+// aggregate the aggregates. Along with the result, the method returns
+// the index of the selected point, which is only meaningful for selectors.
 func determineUnsignedAggregateMethod(agg datatypes.Aggregate_AggregateType) (unsignedAggregateMethod, error) {
 	switch agg {
 	case datatypes.AggregateTypeFirst:
@@ -2602,72 +2684,80 @@ func determineUnsignedAggregateMethod(agg datatypes.Aggregate_AggregateType) (un
 	}
 }
 
-func aggregateMinGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64) {
+func aggregateMinGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value > values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateMaxGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64) {
+func aggregateMaxGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if value < values[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 // For group count and sum, the timestamp here is always math.MaxInt64.
 // their final result does not contain _time, so this timestamp value can be anything
 // and it won't matter.
 
-func aggregateSumGroupsUnsigned(_ []int64, values []uint64) (int64, uint64) {
+func aggregateSumGroupsUnsigned(_ []int64, values []uint64) (int64, uint64, int) {
 	var sum uint64
 	for _, v := range values {
 		sum += v
 	}
-	return math.MaxInt64, sum
+	return math.MaxInt64, sum, 0
 }
 
-func aggregateFirstGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64) {
+func aggregateFirstGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp > timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateLastGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64) {
+func aggregateLastGroupsUnsigned(timestamps []int64, values []uint64) (int64, uint64, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp < timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 func (t *unsignedGroupTable) advanceCursor() bool {
@@ -3428,25 +3518,46 @@ func (t *stringGroupTable) advance() bool {
 		return false
 	}
 
-	ts, v := aggregate(arr.Timestamps, arr.Values)
+	// For selectors, the columns that are not part of the group key take
+	// their values from the series the selected point came from. Only the
+	// point selected so far is kept, along with the tags of its series,
+	// which are cloned when the selected point moves to another series.
+	isSelector := IsSelector(t.gc.Aggregate())
+	var selectedTags models.Tags
+	if isSelector {
+		selectedTags = t.gc.Tags().Clone()
+	}
+
+	ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 	timestamps, values := []int64{ts}, []string{v}
+	seriesIdx, selectedIdx := 0, 0
 	for {
 		arr = t.cur.Next()
 		if arr.Len() > 0 {
-			ts, v := aggregate(arr.Timestamps, arr.Values)
+			ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 			timestamps = append(timestamps, ts)
 			values = append(values, v)
+			if isSelector {
+				ts, v, i := aggregate(timestamps, values)
+				if i == 1 && selectedIdx != seriesIdx {
+					selectedTags, selectedIdx = t.gc.Tags().Clone(), seriesIdx
+				}
+				timestamps, values = timestamps[:1], values[:1]
+				timestamps[0], values[0] = ts, v
+			}
 			continue
 		}
 
 		if !t.advanceCursor() {
 			break
 		}
+		seriesIdx++
 	}
-	timestamp, value := aggregate(timestamps, values)
+	timestamp, value, _ := aggregate(timestamps, values)
 
 	colReader := t.allocateBuffer(1)
-	if IsSelector(t.gc.Aggregate()) {
+	if isSelector {
+		t.readTags(selectedTags)
 		colReader.cols[timeColIdx] = arrow.NewInt([]int64{timestamp}, t.alloc)
 		colReader.cols[valueColIdx] = t.toArrowBuffer([]string{value})
 	} else {
@@ -3457,12 +3568,13 @@ func (t *stringGroupTable) advance() bool {
 	return true
 }
 
-type stringAggregateMethod func([]int64, []string) (int64, string)
+type stringAggregateMethod func([]int64, []string) (int64, string, int)
 
 // determineStringAggregateMethod returns the method for aggregating
 // returned points within the same group. The incoming points are the
 // ones returned for each series and the method returned here will
-// aggregate the aggregates.
+// aggregate the aggregates. Along with the result, the method returns
+// the index of the selected point, which is only meaningful for selectors.
 func determineStringAggregateMethod(agg datatypes.Aggregate_AggregateType) (stringAggregateMethod, error) {
 	switch agg {
 	case datatypes.AggregateTypeFirst:
@@ -3509,32 +3621,36 @@ func determineStringAggregateMethod(agg datatypes.Aggregate_AggregateType) (stri
 // their final result does not contain _time, so this timestamp value can be anything
 // and it won't matter.
 
-func aggregateFirstGroupsString(timestamps []int64, values []string) (int64, string) {
+func aggregateFirstGroupsString(timestamps []int64, values []string) (int64, string, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp > timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateLastGroupsString(timestamps []int64, values []string) (int64, string) {
+func aggregateLastGroupsString(timestamps []int64, values []string) (int64, string, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp < timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 func (t *stringGroupTable) advanceCursor() bool {
@@ -4295,25 +4411,46 @@ func (t *booleanGroupTable) advance() bool {
 		return false
 	}
 
-	ts, v := aggregate(arr.Timestamps, arr.Values)
+	// For selectors, the columns that are not part of the group key take
+	// their values from the series the selected point came from. Only the
+	// point selected so far is kept, along with the tags of its series,
+	// which are cloned when the selected point moves to another series.
+	isSelector := IsSelector(t.gc.Aggregate())
+	var selectedTags models.Tags
+	if isSelector {
+		selectedTags = t.gc.Tags().Clone()
+	}
+
+	ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 	timestamps, values := []int64{ts}, []bool{v}
+	seriesIdx, selectedIdx := 0, 0
 	for {
 		arr = t.cur.Next()
 		if arr.Len() > 0 {
-			ts, v := aggregate(arr.Timestamps, arr.Values)
+			ts, v, _ := aggregate(arr.Timestamps, arr.Values)
 			timestamps = append(timestamps, ts)
 			values = append(values, v)
+			if isSelector {
+				ts, v, i := aggregate(timestamps, values)
+				if i == 1 && selectedIdx != seriesIdx {
+					selectedTags, selectedIdx = t.gc.Tags().Clone(), seriesIdx
+				}
+				timestamps, values = timestamps[:1], values[:1]
+				timestamps[0], values[0] = ts, v
+			}
 			continue
 		}
 
 		if !t.advanceCursor() {
 			break
 		}
+		seriesIdx++
 	}
-	timestamp, value := aggregate(timestamps, values)
+	timestamp, value, _ := aggregate(timestamps, values)
 
 	colReader := t.allocateBuffer(1)
-	if IsSelector(t.gc.Aggregate()) {
+	if isSelector {
+		t.readTags(selectedTags)
 		colReader.cols[timeColIdx] = arrow.NewInt([]int64{timestamp}, t.alloc)
 		colReader.cols[valueColIdx] = t.toArrowBuffer([]bool{value})
 	} else {
@@ -4324,12 +4461,13 @@ func (t *booleanGroupTable) advance() bool {
 	return true
 }
 
-type booleanAggregateMethod func([]int64, []bool) (int64, bool)
+type booleanAggregateMethod func([]int64, []bool) (int64, bool, int)
 
 // determineBooleanAggregateMethod returns the method for aggregating
 // returned points within the same group. The incoming points are the
 // ones returned for each series and the method returned here will
-// aggregate the aggregates.
+// aggregate the aggregates. Along with the result, the method returns
+// the index of the selected point, which is only meaningful for selectors.
 func determineBooleanAggregateMethod(agg datatypes.Aggregate_AggregateType) (booleanAggregateMethod, error) {
 	switch agg {
 	case datatypes.AggregateTypeFirst:
@@ -4376,32 +4514,36 @@ func determineBooleanAggregateMethod(agg datatypes.Aggregate_AggregateType) (boo
 // their final result does not contain _time, so this timestamp value can be anything
 // and it won't matter.
 
-func aggregateFirstGroupsBoolean(timestamps []int64, values []bool) (int64, bool) {
+func aggregateFirstGroupsBoolean(timestamps []int64, values []bool) (int64, bool, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp > timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
-func aggregateLastGroupsBoolean(timestamps []int64, values []bool) (int64, bool) {
+func aggregateLastGroupsBoolean(timestamps []int64, values []bool) (int64, bool, int) {
 	value := values[0]
 	timestamp := timestamps[0]
+	index := 0
 
 	for i := 1; i < len(values); i++ {
 		if timestamp < timestamps[i] {
 			value = values[i]
 			timestamp = timestamps[i]
+			index = i
 		}
 	}
 
-	return timestamp, value
+	return timestamp, value, index
 }
 
 func (t *booleanGroupTable) advanceCursor() bool {
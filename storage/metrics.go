@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// writeMetrics are the metrics of the writes to the engine.
+type writeMetrics struct {
+	cardinalityLimited *prometheus.CounterVec
+}
+
+func newWriteMetrics(labels prometheus.Labels) *writeMetrics {
+	const (
+		namespace = "storage"
+		subsystem = "writer"
+	)
+
+	return &writeMetrics{
+		cardinalityLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "cardinality_limited_points_total",
+			Help:        "Number of points dropped from writes for exceeding a cardinality limit of their bucket, split out by bucket and limit.",
+			ConstLabels: labels,
+		}, []string{"bucket", "limit"}),
+	}
+}
+
+// cardinalityLimit counts points of a write to the bucket dropped for
+// exceeding the limit. Its signature is the one of
+// tsdb.EngineOptions.OnCardinalityLimit.
+func (m *writeMetrics) cardinalityLimit(bucket, limit string, dropped int) {
+	m.cardinalityLimited.WithLabelValues(bucket, limit).Add(float64(dropped))
+}
+
+// PrometheusCollectors returns the collectors of the metrics.
+func (m *writeMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.cardinalityLimited,
+	}
+}
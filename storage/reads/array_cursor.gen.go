@@ -15,6 +15,7 @@ import (
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	"github.com/influxdata/tdigest"
 )
 
 const (
@@ -196,6 +197,66 @@ func newWindowMeanArrayCursor(cur cursors.Cursor, window interval.Window) (curso
 	}
 }
 
+func newWindowSpreadArrayCursor(cur cursors.Cursor, window interval.Window) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSpreadArrayCursor(cur, window), nil
+
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSpreadArrayCursor(cur, window), nil
+
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSpreadArrayCursor(cur, window), nil
+
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unsupported input type for spread aggregate: %s", arrayCursorType(cur)),
+		}
+	}
+}
+
+func newWindowStddevArrayCursor(cur cursors.Cursor, window interval.Window) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+
+	case cursors.FloatArrayCursor:
+		return newFloatWindowStddevArrayCursor(cur, window), nil
+
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowStddevArrayCursor(cur, window), nil
+
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowStddevArrayCursor(cur, window), nil
+
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unsupported input type for stddev aggregate: %s", arrayCursorType(cur)),
+		}
+	}
+}
+
+func newWindowQuantileArrayCursor(cur cursors.Cursor, window interval.Window, quantile, compression float64) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+
+	case cursors.FloatArrayCursor:
+		return newFloatWindowQuantileArrayCursor(cur, window, quantile, compression), nil
+
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowQuantileArrayCursor(cur, window, quantile, compression), nil
+
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowQuantileArrayCursor(cur, window, quantile, compression), nil
+
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unsupported input type for quantile aggregate: %s", arrayCursorType(cur)),
+		}
+	}
+}
+
 // ********************
 // Float Array Cursor
 
@@ -1064,70 +1125,94 @@ WINDOWS:
 	return c.res
 }
 
-type floatEmptyArrayCursor struct {
-	res cursors.FloatArray
-}
-
-var FloatEmptyArrayCursor cursors.FloatArrayCursor = &floatEmptyArrayCursor{}
-
-func (c *floatEmptyArrayCursor) Err() error                 { return nil }
-func (c *floatEmptyArrayCursor) Close()                     {}
-func (c *floatEmptyArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
-func (c *floatEmptyArrayCursor) Next() *cursors.FloatArray  { return &c.res }
-
-// ********************
-// Integer Array Cursor
-
-type integerArrayFilterCursor struct {
-	cursors.IntegerArrayCursor
-	cond expression
-	m    *singleValue
-	res  *cursors.IntegerArray
-	tmp  *cursors.IntegerArray
+type floatWindowSpreadArrayCursor struct {
+	cursors.FloatArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
+	window interval.Window
 }
 
-func newIntegerFilterArrayCursor(cond expression) *integerArrayFilterCursor {
-	return &integerArrayFilterCursor{
-		cond: cond,
-		m:    &singleValue{},
-		res:  cursors.NewIntegerArrayLen(MaxPointsPerBlock),
-		tmp:  &cursors.IntegerArray{},
+func newFloatWindowSpreadArrayCursor(cur cursors.FloatArrayCursor, window interval.Window) *floatWindowSpreadArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &floatWindowSpreadArrayCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(resLen),
+		tmp:              &cursors.FloatArray{},
+		window:           window,
 	}
 }
 
-func (c *integerArrayFilterCursor) reset(cur cursors.IntegerArrayCursor) {
-	c.IntegerArrayCursor = cur
-	c.tmp.Timestamps, c.tmp.Values = nil, nil
+func (c *floatWindowSpreadArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
 }
 
-func (c *integerArrayFilterCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
-
-func (c *integerArrayFilterCursor) Next() *cursors.IntegerArray {
+func (c *floatWindowSpreadArrayCursor) Next() *cursors.FloatArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-	var a *cursors.IntegerArray
-
+	var a *cursors.FloatArray
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
-		a = c.IntegerArrayCursor.Next()
+		a = c.FloatArrayCursor.Next()
 	}
 
-LOOP:
-	for len(a.Timestamps) > 0 {
-		for i, v := range a.Values {
-			c.m.v = v
-			if c.cond.EvalBool(c.m) {
-				c.res.Timestamps[pos] = a.Timestamps[i]
-				c.res.Values[pos] = v
-				pos++
-				if pos >= MaxPointsPerBlock {
-					c.tmp.Timestamps = a.Timestamps[i+1:]
-					c.tmp.Values = a.Values[i+1:]
-					break LOOP
+	if a.Len() == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	rowIdx := 0
+	var lo, hi float64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = hi - lo
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				lo = 0
+				hi = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				if !windowHasPoints || a.Values[rowIdx] < lo {
+					lo = a.Values[rowIdx]
 				}
+				if !windowHasPoints || a.Values[rowIdx] > hi {
+					hi = a.Values[rowIdx]
+				}
+				windowHasPoints = true
 			}
 		}
 
@@ -1136,7 +1221,19 @@ LOOP:
 		c.tmp.Timestamps = nil
 		c.tmp.Values = nil
 
-		a = c.IntegerArrayCursor.Next()
+		// get the next chunk
+		a = c.FloatArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = hi - lo
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
 	}
 
 	c.res.Timestamps = c.res.Timestamps[:pos]
@@ -1145,288 +1242,56 @@ LOOP:
 	return c.res
 }
 
-type integerMultiShardArrayCursor struct {
-	cursors.IntegerArrayCursor
-	cursorContext
-	filter *integerArrayFilterCursor
+type floatWindowStddevArrayCursor struct {
+	cursors.FloatArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
+	window interval.Window
 }
 
-func (c *integerMultiShardArrayCursor) reset(cur cursors.IntegerArrayCursor, itrs cursors.CursorIterators, cond expression) {
-	if cond != nil {
-		if c.filter == nil {
-			c.filter = newIntegerFilterArrayCursor(cond)
-		}
-		c.filter.reset(cur)
-		cur = c.filter
+func newFloatWindowStddevArrayCursor(cur cursors.FloatArrayCursor, window interval.Window) *floatWindowStddevArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &floatWindowStddevArrayCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(resLen),
+		tmp:              &cursors.FloatArray{},
+		window:           window,
 	}
-
-	c.IntegerArrayCursor = cur
-	c.itrs = itrs
-	c.err = nil
 }
 
-func (c *integerMultiShardArrayCursor) Err() error { return c.err }
-
-func (c *integerMultiShardArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
+func (c *floatWindowStddevArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
 }
 
-func (c *integerMultiShardArrayCursor) Next() *cursors.IntegerArray {
-	for {
-		a := c.IntegerArrayCursor.Next()
-		if a.Len() == 0 {
-			if c.nextArrayCursor() {
-				continue
-			}
-		}
-		return a
-	}
-}
+func (c *floatWindowStddevArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-func (c *integerMultiShardArrayCursor) nextArrayCursor() bool {
-	if len(c.itrs) == 0 {
-		return false
+	var a *cursors.FloatArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.FloatArrayCursor.Next()
 	}
 
-	c.IntegerArrayCursor.Close()
-
-	var itr cursors.CursorIterator
-	var cur cursors.Cursor
-	for cur == nil && len(c.itrs) > 0 {
-		itr, c.itrs = c.itrs[0], c.itrs[1:]
-		cur, _ = itr.Next(c.ctx, c.req)
+	if a.Len() == 0 {
+		return &cursors.FloatArray{}
 	}
 
-	var ok bool
-	if cur != nil {
-		var next cursors.IntegerArrayCursor
-		next, ok = cur.(cursors.IntegerArrayCursor)
-		if !ok {
-			cur.Close()
-			next = IntegerEmptyArrayCursor
-			c.err = errors.New("expected integer cursor")
-		} else {
-			if c.filter != nil {
-				c.filter.reset(next)
-				next = c.filter
-			}
-		}
-		c.IntegerArrayCursor = next
+	rowIdx := 0
+	var count, mean, m2 float64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
 	} else {
-		c.IntegerArrayCursor = IntegerEmptyArrayCursor
+		windowEnd = math.MaxInt64
 	}
-
-	return ok
-}
-
-type integerLimitArrayCursor struct {
-	cursors.IntegerArrayCursor
-	res  *cursors.IntegerArray
-	done bool
-}
-
-func newIntegerLimitArrayCursor(cur cursors.IntegerArrayCursor) *integerLimitArrayCursor {
-	return &integerLimitArrayCursor{
-		IntegerArrayCursor: cur,
-		res:                cursors.NewIntegerArrayLen(1),
-	}
-}
-
-func (c *integerLimitArrayCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
-
-func (c *integerLimitArrayCursor) Next() *cursors.IntegerArray {
-	if c.done {
-		return &cursors.IntegerArray{}
-	}
-	a := c.IntegerArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
-	c.done = true
-	c.res.Timestamps[0] = a.Timestamps[0]
-	c.res.Values[0] = a.Values[0]
-	return c.res
-}
-
-type integerWindowLastArrayCursor struct {
-	cursors.IntegerArrayCursor
-	windowEnd int64
-	res       *cursors.IntegerArray
-	tmp       *cursors.IntegerArray
-	window    interval.Window
-}
-
-// Window array cursors assume that every != 0 && every != MaxInt64.
-// Such a cursor will panic in the first case and possibly overflow in the second.
-func newIntegerWindowLastArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowLastArrayCursor {
-	return &integerWindowLastArrayCursor{
-		IntegerArrayCursor: cur,
-		windowEnd:          math.MinInt64,
-		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
-		tmp:                &cursors.IntegerArray{},
-		window:             window,
-	}
-}
-
-func (c *integerWindowLastArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
-}
-
-func (c *integerWindowLastArrayCursor) Next() *cursors.IntegerArray {
-	cur := -1
-
-NEXT:
-	var a *cursors.IntegerArray
-
-	if c.tmp.Len() > 0 {
-		a = c.tmp
-	} else {
-		a = c.IntegerArrayCursor.Next()
-	}
-
-	if a.Len() == 0 {
-		c.res.Timestamps = c.res.Timestamps[:cur+1]
-		c.res.Values = c.res.Values[:cur+1]
-		return c.res
-	}
-
-	for i, t := range a.Timestamps {
-		if t >= c.windowEnd {
-			cur++
-		}
-
-		if cur == MaxPointsPerBlock {
-			c.tmp.Timestamps = a.Timestamps[i:]
-			c.tmp.Values = a.Values[i:]
-			return c.res
-		}
-
-		c.res.Timestamps[cur] = t
-		c.res.Values[cur] = a.Values[i]
-
-		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
-	}
-
-	c.tmp.Timestamps = nil
-	c.tmp.Values = nil
-
-	goto NEXT
-}
-
-type integerWindowFirstArrayCursor struct {
-	cursors.IntegerArrayCursor
-	windowEnd int64
-	res       *cursors.IntegerArray
-	tmp       *cursors.IntegerArray
-	window    interval.Window
-}
-
-// Window array cursors assume that every != 0 && every != MaxInt64.
-// Such a cursor will panic in the first case and possibly overflow in the second.
-func newIntegerWindowFirstArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowFirstArrayCursor {
-	return &integerWindowFirstArrayCursor{
-		IntegerArrayCursor: cur,
-		windowEnd:          math.MinInt64,
-		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
-		tmp:                &cursors.IntegerArray{},
-		window:             window,
-	}
-}
-
-func (c *integerWindowFirstArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
-}
-
-func (c *integerWindowFirstArrayCursor) Next() *cursors.IntegerArray {
-	c.res.Timestamps = c.res.Timestamps[:0]
-	c.res.Values = c.res.Values[:0]
-
-NEXT:
-	var a *cursors.IntegerArray
-
-	if c.tmp.Len() > 0 {
-		a = c.tmp
-	} else {
-		a = c.IntegerArrayCursor.Next()
-	}
-
-	if a.Len() == 0 {
-		return c.res
-	}
-
-	for i, t := range a.Timestamps {
-		if t < c.windowEnd {
-			continue
-		}
-
-		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
-
-		c.res.Timestamps = append(c.res.Timestamps, t)
-		c.res.Values = append(c.res.Values, a.Values[i])
-
-		if c.res.Len() == MaxPointsPerBlock {
-			c.tmp.Timestamps = a.Timestamps[i+1:]
-			c.tmp.Values = a.Values[i+1:]
-			return c.res
-		}
-	}
-
-	c.tmp.Timestamps = nil
-	c.tmp.Values = nil
-
-	goto NEXT
-}
-
-type integerWindowCountArrayCursor struct {
-	cursors.IntegerArrayCursor
-	res    *cursors.IntegerArray
-	tmp    *cursors.IntegerArray
-	window interval.Window
-}
-
-func newIntegerWindowCountArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowCountArrayCursor {
-	resLen := MaxPointsPerBlock
-	if window.IsZero() {
-		resLen = 1
-	}
-	return &integerWindowCountArrayCursor{
-		IntegerArrayCursor: cur,
-		res:                cursors.NewIntegerArrayLen(resLen),
-		tmp:                &cursors.IntegerArray{},
-		window:             window,
-	}
-}
-
-func (c *integerWindowCountArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
-}
-
-func (c *integerWindowCountArrayCursor) Next() *cursors.IntegerArray {
-	pos := 0
-	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
-	c.res.Values = c.res.Values[:cap(c.res.Values)]
-
-	var a *cursors.IntegerArray
-	if c.tmp.Len() > 0 {
-		a = c.tmp
-	} else {
-		a = c.IntegerArrayCursor.Next()
-	}
-
-	if a.Len() == 0 {
-		return &cursors.IntegerArray{}
-	}
-
-	rowIdx := 0
-	var acc int64 = 0
-
-	var windowEnd int64
-	if !c.window.IsZero() {
-		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
-	} else {
-		windowEnd = math.MaxInt64
-	}
-	windowHasPoints := false
+	windowHasPoints := false
 
 	// enumerate windows
 WINDOWS:
@@ -1438,7 +1303,7 @@ WINDOWS:
 				// do not generate a point for empty windows
 				if windowHasPoints {
 					c.res.Timestamps[pos] = windowEnd
-					c.res.Values[pos] = acc
+					c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -1451,13 +1316,18 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = 0
+				count = 0
+				mean = 0
+				m2 = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				acc++
+				delta := float64(a.Values[rowIdx]) - mean
+				count++
+				mean += delta / count
+				m2 += delta * (float64(a.Values[rowIdx]) - mean)
 				windowHasPoints = true
 			}
 		}
@@ -1468,13 +1338,13 @@ WINDOWS:
 		c.tmp.Values = nil
 
 		// get the next chunk
-		a = c.IntegerArrayCursor.Next()
+		a = c.FloatArrayCursor.Next()
 		if a.Len() == 0 {
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
 				c.res.Timestamps[pos] = windowEnd
-				c.res.Values[pos] = acc
+				c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
 				pos++
 			}
 			break WINDOWS
@@ -1488,48 +1358,54 @@ WINDOWS:
 	return c.res
 }
 
-type integerWindowSumArrayCursor struct {
-	cursors.IntegerArrayCursor
-	res    *cursors.IntegerArray
-	tmp    *cursors.IntegerArray
+type floatWindowQuantileArrayCursor struct {
+	cursors.FloatArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
 	window interval.Window
+
+	quantile    float64
+	compression float64
 }
 
-func newIntegerWindowSumArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowSumArrayCursor {
+func newFloatWindowQuantileArrayCursor(cur cursors.FloatArrayCursor, window interval.Window, quantile, compression float64) *floatWindowQuantileArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &integerWindowSumArrayCursor{
-		IntegerArrayCursor: cur,
-		res:                cursors.NewIntegerArrayLen(resLen),
-		tmp:                &cursors.IntegerArray{},
-		window:             window,
+	return &floatWindowQuantileArrayCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(resLen),
+		tmp:              &cursors.FloatArray{},
+		window:           window,
+
+		quantile:    quantile,
+		compression: compression,
 	}
 }
 
-func (c *integerWindowSumArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
+func (c *floatWindowQuantileArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
 }
 
-func (c *integerWindowSumArrayCursor) Next() *cursors.IntegerArray {
+func (c *floatWindowQuantileArrayCursor) Next() *cursors.FloatArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-	var a *cursors.IntegerArray
+	var a *cursors.FloatArray
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
-		a = c.IntegerArrayCursor.Next()
+		a = c.FloatArrayCursor.Next()
 	}
 
 	if a.Len() == 0 {
-		return &cursors.IntegerArray{}
+		return &cursors.FloatArray{}
 	}
 
 	rowIdx := 0
-	var acc int64 = 0
+	td := tdigest.NewWithCompression(c.compression)
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -1549,7 +1425,7 @@ WINDOWS:
 				// do not generate a point for empty windows
 				if windowHasPoints {
 					c.res.Timestamps[pos] = windowEnd
-					c.res.Values[pos] = acc
+					c.res.Values[pos] = td.Quantile(c.quantile)
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -1562,13 +1438,13 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = 0
+				td = tdigest.NewWithCompression(c.compression)
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				acc += a.Values[rowIdx]
+				td.Add(a.Values[rowIdx], 1)
 				windowHasPoints = true
 			}
 		}
@@ -1579,13 +1455,13 @@ WINDOWS:
 		c.tmp.Values = nil
 
 		// get the next chunk
-		a = c.IntegerArrayCursor.Next()
+		a = c.FloatArrayCursor.Next()
 		if a.Len() == 0 {
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
 				c.res.Timestamps[pos] = windowEnd
-				c.res.Values[pos] = acc
+				c.res.Values[pos] = td.Quantile(c.quantile)
 				pos++
 			}
 			break WINDOWS
@@ -1599,92 +1475,70 @@ WINDOWS:
 	return c.res
 }
 
-type integerWindowMinArrayCursor struct {
-	cursors.IntegerArrayCursor
-	res    *cursors.IntegerArray
-	tmp    *cursors.IntegerArray
-	window interval.Window
+type floatEmptyArrayCursor struct {
+	res cursors.FloatArray
 }
 
-func newIntegerWindowMinArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMinArrayCursor {
-	resLen := MaxPointsPerBlock
-	if window.IsZero() {
-		resLen = 1
-	}
-	return &integerWindowMinArrayCursor{
-		IntegerArrayCursor: cur,
-		res:                cursors.NewIntegerArrayLen(resLen),
-		tmp:                &cursors.IntegerArray{},
-		window:             window,
+var FloatEmptyArrayCursor cursors.FloatArrayCursor = &floatEmptyArrayCursor{}
+
+func (c *floatEmptyArrayCursor) Err() error                 { return nil }
+func (c *floatEmptyArrayCursor) Close()                     {}
+func (c *floatEmptyArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+func (c *floatEmptyArrayCursor) Next() *cursors.FloatArray  { return &c.res }
+
+// ********************
+// Integer Array Cursor
+
+type integerArrayFilterCursor struct {
+	cursors.IntegerArrayCursor
+	cond expression
+	m    *singleValue
+	res  *cursors.IntegerArray
+	tmp  *cursors.IntegerArray
+}
+
+func newIntegerFilterArrayCursor(cond expression) *integerArrayFilterCursor {
+	return &integerArrayFilterCursor{
+		cond: cond,
+		m:    &singleValue{},
+		res:  cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:  &cursors.IntegerArray{},
 	}
 }
 
-func (c *integerWindowMinArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
+func (c *integerArrayFilterCursor) reset(cur cursors.IntegerArrayCursor) {
+	c.IntegerArrayCursor = cur
+	c.tmp.Timestamps, c.tmp.Values = nil, nil
 }
 
-func (c *integerWindowMinArrayCursor) Next() *cursors.IntegerArray {
+func (c *integerArrayFilterCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayFilterCursor) Next() *cursors.IntegerArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
 	var a *cursors.IntegerArray
+
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
 		a = c.IntegerArrayCursor.Next()
 	}
 
-	if a.Len() == 0 {
-		return &cursors.IntegerArray{}
-	}
-
-	rowIdx := 0
-	var acc int64 = math.MaxInt64
-	var tsAcc int64
-
-	var windowEnd int64
-	if !c.window.IsZero() {
-		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
-	} else {
-		windowEnd = math.MaxInt64
-	}
-	windowHasPoints := false
-
-	// enumerate windows
-WINDOWS:
-	for {
-		for ; rowIdx < a.Len(); rowIdx++ {
-			ts := a.Timestamps[rowIdx]
-			if !c.window.IsZero() && ts >= windowEnd {
-				// new window detected, close the current window
-				// do not generate a point for empty windows
-				if windowHasPoints {
-					c.res.Timestamps[pos] = tsAcc
-					c.res.Values[pos] = acc
-					pos++
-					if pos >= MaxPointsPerBlock {
-						// the output array is full,
-						// save the remaining points in the input array in tmp.
-						// they will be processed in the next call to Next()
-						c.tmp.Timestamps = a.Timestamps[rowIdx:]
-						c.tmp.Values = a.Values[rowIdx:]
-						break WINDOWS
-					}
-				}
-
-				// start the new window
-				acc = math.MaxInt64
-				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
-				windowHasPoints = false
-
-				continue WINDOWS
-			} else {
-				if !windowHasPoints || a.Values[rowIdx] < acc {
-					acc = a.Values[rowIdx]
-					tsAcc = a.Timestamps[rowIdx]
+LOOP:
+	for len(a.Timestamps) > 0 {
+		for i, v := range a.Values {
+			c.m.v = v
+			if c.cond.EvalBool(c.m) {
+				c.res.Timestamps[pos] = a.Timestamps[i]
+				c.res.Values[pos] = v
+				pos++
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i+1:]
+					c.tmp.Values = a.Values[i+1:]
+					break LOOP
 				}
-				windowHasPoints = true
 			}
 		}
 
@@ -1693,19 +1547,7 @@ WINDOWS:
 		c.tmp.Timestamps = nil
 		c.tmp.Values = nil
 
-		// get the next chunk
 		a = c.IntegerArrayCursor.Next()
-		if a.Len() == 0 {
-			// write the final point
-			// do not generate a point for empty windows
-			if windowHasPoints {
-				c.res.Timestamps[pos] = tsAcc
-				c.res.Values[pos] = acc
-				pos++
-			}
-			break WINDOWS
-		}
-		rowIdx = 0
 	}
 
 	c.res.Timestamps = c.res.Timestamps[:pos]
@@ -1714,36 +1556,139 @@ WINDOWS:
 	return c.res
 }
 
-type integerWindowMaxArrayCursor struct {
+type integerMultiShardArrayCursor struct {
 	cursors.IntegerArrayCursor
-	res    *cursors.IntegerArray
-	tmp    *cursors.IntegerArray
-	window interval.Window
+	cursorContext
+	filter *integerArrayFilterCursor
 }
 
-func newIntegerWindowMaxArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMaxArrayCursor {
-	resLen := MaxPointsPerBlock
-	if window.IsZero() {
-		resLen = 1
+func (c *integerMultiShardArrayCursor) reset(cur cursors.IntegerArrayCursor, itrs cursors.CursorIterators, cond expression) {
+	if cond != nil {
+		if c.filter == nil {
+			c.filter = newIntegerFilterArrayCursor(cond)
+		}
+		c.filter.reset(cur)
+		cur = c.filter
 	}
-	return &integerWindowMaxArrayCursor{
+
+	c.IntegerArrayCursor = cur
+	c.itrs = itrs
+	c.err = nil
+}
+
+func (c *integerMultiShardArrayCursor) Err() error { return c.err }
+
+func (c *integerMultiShardArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerMultiShardArrayCursor) Next() *cursors.IntegerArray {
+	for {
+		a := c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			if c.nextArrayCursor() {
+				continue
+			}
+		}
+		return a
+	}
+}
+
+func (c *integerMultiShardArrayCursor) nextArrayCursor() bool {
+	if len(c.itrs) == 0 {
+		return false
+	}
+
+	c.IntegerArrayCursor.Close()
+
+	var itr cursors.CursorIterator
+	var cur cursors.Cursor
+	for cur == nil && len(c.itrs) > 0 {
+		itr, c.itrs = c.itrs[0], c.itrs[1:]
+		cur, _ = itr.Next(c.ctx, c.req)
+	}
+
+	var ok bool
+	if cur != nil {
+		var next cursors.IntegerArrayCursor
+		next, ok = cur.(cursors.IntegerArrayCursor)
+		if !ok {
+			cur.Close()
+			next = IntegerEmptyArrayCursor
+			c.err = errors.New("expected integer cursor")
+		} else {
+			if c.filter != nil {
+				c.filter.reset(next)
+				next = c.filter
+			}
+		}
+		c.IntegerArrayCursor = next
+	} else {
+		c.IntegerArrayCursor = IntegerEmptyArrayCursor
+	}
+
+	return ok
+}
+
+type integerLimitArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res  *cursors.IntegerArray
+	done bool
+}
+
+func newIntegerLimitArrayCursor(cur cursors.IntegerArrayCursor) *integerLimitArrayCursor {
+	return &integerLimitArrayCursor{
 		IntegerArrayCursor: cur,
-		res:                cursors.NewIntegerArrayLen(resLen),
+		res:                cursors.NewIntegerArrayLen(1),
+	}
+}
+
+func (c *integerLimitArrayCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerLimitArrayCursor) Next() *cursors.IntegerArray {
+	if c.done {
+		return &cursors.IntegerArray{}
+	}
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+type integerWindowLastArrayCursor struct {
+	cursors.IntegerArrayCursor
+	windowEnd int64
+	res       *cursors.IntegerArray
+	tmp       *cursors.IntegerArray
+	window    interval.Window
+}
+
+// Window array cursors assume that every != 0 && every != MaxInt64.
+// Such a cursor will panic in the first case and possibly overflow in the second.
+func newIntegerWindowLastArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowLastArrayCursor {
+	return &integerWindowLastArrayCursor{
+		IntegerArrayCursor: cur,
+		windowEnd:          math.MinInt64,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
 		tmp:                &cursors.IntegerArray{},
 		window:             window,
 	}
 }
 
-func (c *integerWindowMaxArrayCursor) Stats() cursors.CursorStats {
+func (c *integerWindowLastArrayCursor) Stats() cursors.CursorStats {
 	return c.IntegerArrayCursor.Stats()
 }
 
-func (c *integerWindowMaxArrayCursor) Next() *cursors.IntegerArray {
-	pos := 0
-	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
-	c.res.Values = c.res.Values[:cap(c.res.Values)]
+func (c *integerWindowLastArrayCursor) Next() *cursors.IntegerArray {
+	cur := -1
 
+NEXT:
 	var a *cursors.IntegerArray
+
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
@@ -1751,12 +1696,140 @@ func (c *integerWindowMaxArrayCursor) Next() *cursors.IntegerArray {
 	}
 
 	if a.Len() == 0 {
-		return &cursors.IntegerArray{}
+		c.res.Timestamps = c.res.Timestamps[:cur+1]
+		c.res.Values = c.res.Values[:cur+1]
+		return c.res
 	}
 
-	rowIdx := 0
-	var acc int64 = math.MinInt64
-	var tsAcc int64
+	for i, t := range a.Timestamps {
+		if t >= c.windowEnd {
+			cur++
+		}
+
+		if cur == MaxPointsPerBlock {
+			c.tmp.Timestamps = a.Timestamps[i:]
+			c.tmp.Values = a.Values[i:]
+			return c.res
+		}
+
+		c.res.Timestamps[cur] = t
+		c.res.Values[cur] = a.Values[i]
+
+		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+	}
+
+	c.tmp.Timestamps = nil
+	c.tmp.Values = nil
+
+	goto NEXT
+}
+
+type integerWindowFirstArrayCursor struct {
+	cursors.IntegerArrayCursor
+	windowEnd int64
+	res       *cursors.IntegerArray
+	tmp       *cursors.IntegerArray
+	window    interval.Window
+}
+
+// Window array cursors assume that every != 0 && every != MaxInt64.
+// Such a cursor will panic in the first case and possibly overflow in the second.
+func newIntegerWindowFirstArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowFirstArrayCursor {
+	return &integerWindowFirstArrayCursor{
+		IntegerArrayCursor: cur,
+		windowEnd:          math.MinInt64,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowFirstArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+NEXT:
+	var a *cursors.IntegerArray
+
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return c.res
+	}
+
+	for i, t := range a.Timestamps {
+		if t < c.windowEnd {
+			continue
+		}
+
+		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, a.Values[i])
+
+		if c.res.Len() == MaxPointsPerBlock {
+			c.tmp.Timestamps = a.Timestamps[i+1:]
+			c.tmp.Values = a.Values[i+1:]
+			return c.res
+		}
+	}
+
+	c.tmp.Timestamps = nil
+	c.tmp.Values = nil
+
+	goto NEXT
+}
+
+type integerWindowCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+}
+
+func newIntegerWindowCountArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowCountArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowCountArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	rowIdx := 0
+	var acc int64 = 0
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -1775,7 +1848,7 @@ WINDOWS:
 				// new window detected, close the current window
 				// do not generate a point for empty windows
 				if windowHasPoints {
-					c.res.Timestamps[pos] = tsAcc
+					c.res.Timestamps[pos] = windowEnd
 					c.res.Values[pos] = acc
 					pos++
 					if pos >= MaxPointsPerBlock {
@@ -1789,16 +1862,13 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = math.MinInt64
+				acc = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				if !windowHasPoints || a.Values[rowIdx] > acc {
-					acc = a.Values[rowIdx]
-					tsAcc = a.Timestamps[rowIdx]
-				}
+				acc++
 				windowHasPoints = true
 			}
 		}
@@ -1814,7 +1884,7 @@ WINDOWS:
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
-				c.res.Timestamps[pos] = tsAcc
+				c.res.Timestamps[pos] = windowEnd
 				c.res.Values[pos] = acc
 				pos++
 			}
@@ -1829,31 +1899,31 @@ WINDOWS:
 	return c.res
 }
 
-type integerWindowMeanArrayCursor struct {
+type integerWindowSumArrayCursor struct {
 	cursors.IntegerArrayCursor
-	res    *cursors.FloatArray
+	res    *cursors.IntegerArray
 	tmp    *cursors.IntegerArray
 	window interval.Window
 }
 
-func newIntegerWindowMeanArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMeanArrayCursor {
+func newIntegerWindowSumArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowSumArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &integerWindowMeanArrayCursor{
+	return &integerWindowSumArrayCursor{
 		IntegerArrayCursor: cur,
-		res:                cursors.NewFloatArrayLen(resLen),
+		res:                cursors.NewIntegerArrayLen(resLen),
 		tmp:                &cursors.IntegerArray{},
 		window:             window,
 	}
 }
 
-func (c *integerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+func (c *integerWindowSumArrayCursor) Stats() cursors.CursorStats {
 	return c.IntegerArrayCursor.Stats()
 }
 
-func (c *integerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+func (c *integerWindowSumArrayCursor) Next() *cursors.IntegerArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -1866,12 +1936,11 @@ func (c *integerWindowMeanArrayCursor) Next() *cursors.FloatArray {
 	}
 
 	if a.Len() == 0 {
-		return &cursors.FloatArray{}
+		return &cursors.IntegerArray{}
 	}
 
 	rowIdx := 0
-	var sum int64
-	var count int64
+	var acc int64 = 0
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -1891,7 +1960,7 @@ WINDOWS:
 				// do not generate a point for empty windows
 				if windowHasPoints {
 					c.res.Timestamps[pos] = windowEnd
-					c.res.Values[pos] = float64(sum) / float64(count)
+					c.res.Values[pos] = acc
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -1904,15 +1973,13 @@ WINDOWS:
 				}
 
 				// start the new window
-				sum = 0
-				count = 0
+				acc = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				sum += a.Values[rowIdx]
-				count++
+				acc += a.Values[rowIdx]
 				windowHasPoints = true
 			}
 		}
@@ -1929,7 +1996,7 @@ WINDOWS:
 			// do not generate a point for empty windows
 			if windowHasPoints {
 				c.res.Timestamps[pos] = windowEnd
-				c.res.Values[pos] = float64(sum) / float64(count)
+				c.res.Values[pos] = acc
 				pos++
 			}
 			break WINDOWS
@@ -1943,220 +2010,1154 @@ WINDOWS:
 	return c.res
 }
 
-type integerEmptyArrayCursor struct {
-	res cursors.IntegerArray
-}
-
-var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
-
-func (c *integerEmptyArrayCursor) Err() error                  { return nil }
-func (c *integerEmptyArrayCursor) Close()                      {}
-func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
-func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
-
-// ********************
-// Unsigned Array Cursor
-
-type unsignedArrayFilterCursor struct {
-	cursors.UnsignedArrayCursor
-	cond expression
-	m    *singleValue
-	res  *cursors.UnsignedArray
-	tmp  *cursors.UnsignedArray
+type integerWindowMinArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
 }
 
-func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
-	return &unsignedArrayFilterCursor{
-		cond: cond,
-		m:    &singleValue{},
-		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
-		tmp:  &cursors.UnsignedArray{},
+func newIntegerWindowMinArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMinArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowMinArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
 	}
 }
 
-func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
-	c.UnsignedArrayCursor = cur
-	c.tmp.Timestamps, c.tmp.Values = nil, nil
+func (c *integerWindowMinArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
 }
 
-func (c *unsignedArrayFilterCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
-
-func (c *unsignedArrayFilterCursor) Next() *cursors.UnsignedArray {
+func (c *integerWindowMinArrayCursor) Next() *cursors.IntegerArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-	var a *cursors.UnsignedArray
-
+	var a *cursors.IntegerArray
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
-		a = c.UnsignedArrayCursor.Next()
+		a = c.IntegerArrayCursor.Next()
 	}
 
-LOOP:
-	for len(a.Timestamps) > 0 {
-		for i, v := range a.Values {
-			c.m.v = v
-			if c.cond.EvalBool(c.m) {
-				c.res.Timestamps[pos] = a.Timestamps[i]
-				c.res.Values[pos] = v
-				pos++
-				if pos >= MaxPointsPerBlock {
-					c.tmp.Timestamps = a.Timestamps[i+1:]
-					c.tmp.Values = a.Values[i+1:]
-					break LOOP
-				}
-			}
-		}
-
-		// Clear buffered timestamps & values if we make it through a cursor.
-		// The break above will skip this if a cursor is partially read.
-		c.tmp.Timestamps = nil
-		c.tmp.Values = nil
-
-		a = c.UnsignedArrayCursor.Next()
+	if a.Len() == 0 {
+		return &cursors.IntegerArray{}
 	}
 
-	c.res.Timestamps = c.res.Timestamps[:pos]
-	c.res.Values = c.res.Values[:pos]
+	rowIdx := 0
+	var acc int64 = math.MaxInt64
+	var tsAcc int64
 
-	return c.res
-}
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = tsAcc
+					c.res.Values[pos] = acc
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				acc = math.MaxInt64
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				if !windowHasPoints || a.Values[rowIdx] < acc {
+					acc = a.Values[rowIdx]
+					tsAcc = a.Timestamps[rowIdx]
+				}
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = tsAcc
+				c.res.Values[pos] = acc
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerWindowMaxArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+}
+
+func newIntegerWindowMaxArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMaxArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowMaxArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowMaxArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMaxArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	rowIdx := 0
+	var acc int64 = math.MinInt64
+	var tsAcc int64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = tsAcc
+					c.res.Values[pos] = acc
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				acc = math.MinInt64
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				if !windowHasPoints || a.Values[rowIdx] > acc {
+					acc = a.Values[rowIdx]
+					tsAcc = a.Timestamps[rowIdx]
+				}
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = tsAcc
+				c.res.Values[pos] = acc
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerWindowMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+}
+
+func newIntegerWindowMeanArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowMeanArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowMeanArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewFloatArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	rowIdx := 0
+	var sum int64
+	var count int64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = float64(sum) / float64(count)
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				sum = 0
+				count = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				sum += a.Values[rowIdx]
+				count++
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = float64(sum) / float64(count)
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerWindowSpreadArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+}
+
+func newIntegerWindowSpreadArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowSpreadArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowSpreadArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowSpreadArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSpreadArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	rowIdx := 0
+	var lo, hi int64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = hi - lo
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				lo = 0
+				hi = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				if !windowHasPoints || a.Values[rowIdx] < lo {
+					lo = a.Values[rowIdx]
+				}
+				if !windowHasPoints || a.Values[rowIdx] > hi {
+					hi = a.Values[rowIdx]
+				}
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = hi - lo
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerWindowStddevArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+}
+
+func newIntegerWindowStddevArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window) *integerWindowStddevArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowStddevArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewFloatArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+	}
+}
+
+func (c *integerWindowStddevArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowStddevArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	rowIdx := 0
+	var count, mean, m2 float64
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				count = 0
+				mean = 0
+				m2 = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				delta := float64(a.Values[rowIdx]) - mean
+				count++
+				mean += delta / count
+				m2 += delta * (float64(a.Values[rowIdx]) - mean)
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerWindowQuantileArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res    *cursors.FloatArray
+	tmp    *cursors.IntegerArray
+	window interval.Window
+
+	quantile    float64
+	compression float64
+}
+
+func newIntegerWindowQuantileArrayCursor(cur cursors.IntegerArrayCursor, window interval.Window, quantile, compression float64) *integerWindowQuantileArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &integerWindowQuantileArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewFloatArrayLen(resLen),
+		tmp:                &cursors.IntegerArray{},
+		window:             window,
+
+		quantile:    quantile,
+		compression: compression,
+	}
+}
+
+func (c *integerWindowQuantileArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowQuantileArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	rowIdx := 0
+	td := tdigest.NewWithCompression(c.compression)
+
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
+
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = td.Quantile(c.quantile)
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				td = tdigest.NewWithCompression(c.compression)
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				td.Add(float64(a.Values[rowIdx]), 1)
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = td.Quantile(c.quantile)
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerEmptyArrayCursor struct {
+	res cursors.IntegerArray
+}
+
+var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
+
+func (c *integerEmptyArrayCursor) Err() error                  { return nil }
+func (c *integerEmptyArrayCursor) Close()                      {}
+func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
+func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+
+// ********************
+// Unsigned Array Cursor
+
+type unsignedArrayFilterCursor struct {
+	cursors.UnsignedArrayCursor
+	cond expression
+	m    *singleValue
+	res  *cursors.UnsignedArray
+	tmp  *cursors.UnsignedArray
+}
+
+func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
+	return &unsignedArrayFilterCursor{
+		cond: cond,
+		m:    &singleValue{},
+		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:  &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
+	c.UnsignedArrayCursor = cur
+	c.tmp.Timestamps, c.tmp.Values = nil, nil
+}
+
+func (c *unsignedArrayFilterCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayFilterCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+LOOP:
+	for len(a.Timestamps) > 0 {
+		for i, v := range a.Values {
+			c.m.v = v
+			if c.cond.EvalBool(c.m) {
+				c.res.Timestamps[pos] = a.Timestamps[i]
+				c.res.Values[pos] = v
+				pos++
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i+1:]
+					c.tmp.Values = a.Values[i+1:]
+					break LOOP
+				}
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type unsignedMultiShardArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	cursorContext
+	filter *unsignedArrayFilterCursor
+}
+
+func (c *unsignedMultiShardArrayCursor) reset(cur cursors.UnsignedArrayCursor, itrs cursors.CursorIterators, cond expression) {
+	if cond != nil {
+		if c.filter == nil {
+			c.filter = newUnsignedFilterArrayCursor(cond)
+		}
+		c.filter.reset(cur)
+		cur = c.filter
+	}
+
+	c.UnsignedArrayCursor = cur
+	c.itrs = itrs
+	c.err = nil
+}
+
+func (c *unsignedMultiShardArrayCursor) Err() error { return c.err }
+
+func (c *unsignedMultiShardArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedMultiShardArrayCursor) Next() *cursors.UnsignedArray {
+	for {
+		a := c.UnsignedArrayCursor.Next()
+		if a.Len() == 0 {
+			if c.nextArrayCursor() {
+				continue
+			}
+		}
+		return a
+	}
+}
+
+func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
+	if len(c.itrs) == 0 {
+		return false
+	}
+
+	c.UnsignedArrayCursor.Close()
+
+	var itr cursors.CursorIterator
+	var cur cursors.Cursor
+	for cur == nil && len(c.itrs) > 0 {
+		itr, c.itrs = c.itrs[0], c.itrs[1:]
+		cur, _ = itr.Next(c.ctx, c.req)
+	}
+
+	var ok bool
+	if cur != nil {
+		var next cursors.UnsignedArrayCursor
+		next, ok = cur.(cursors.UnsignedArrayCursor)
+		if !ok {
+			cur.Close()
+			next = UnsignedEmptyArrayCursor
+			c.err = errors.New("expected unsigned cursor")
+		} else {
+			if c.filter != nil {
+				c.filter.reset(next)
+				next = c.filter
+			}
+		}
+		c.UnsignedArrayCursor = next
+	} else {
+		c.UnsignedArrayCursor = UnsignedEmptyArrayCursor
+	}
+
+	return ok
+}
+
+type unsignedLimitArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	res  *cursors.UnsignedArray
+	done bool
+}
+
+func newUnsignedLimitArrayCursor(cur cursors.UnsignedArrayCursor) *unsignedLimitArrayCursor {
+	return &unsignedLimitArrayCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedLimitArrayCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedLimitArrayCursor) Next() *cursors.UnsignedArray {
+	if c.done {
+		return &cursors.UnsignedArray{}
+	}
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+type unsignedWindowLastArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	windowEnd int64
+	res       *cursors.UnsignedArray
+	tmp       *cursors.UnsignedArray
+	window    interval.Window
+}
+
+// Window array cursors assume that every != 0 && every != MaxInt64.
+// Such a cursor will panic in the first case and possibly overflow in the second.
+func newUnsignedWindowLastArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowLastArrayCursor {
+	return &unsignedWindowLastArrayCursor{
+		UnsignedArrayCursor: cur,
+		windowEnd:           math.MinInt64,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+		window:              window,
+	}
+}
+
+func (c *unsignedWindowLastArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowLastArrayCursor) Next() *cursors.UnsignedArray {
+	cur := -1
+
+NEXT:
+	var a *cursors.UnsignedArray
+
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		c.res.Timestamps = c.res.Timestamps[:cur+1]
+		c.res.Values = c.res.Values[:cur+1]
+		return c.res
+	}
+
+	for i, t := range a.Timestamps {
+		if t >= c.windowEnd {
+			cur++
+		}
+
+		if cur == MaxPointsPerBlock {
+			c.tmp.Timestamps = a.Timestamps[i:]
+			c.tmp.Values = a.Values[i:]
+			return c.res
+		}
+
+		c.res.Timestamps[cur] = t
+		c.res.Values[cur] = a.Values[i]
+
+		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+	}
+
+	c.tmp.Timestamps = nil
+	c.tmp.Values = nil
+
+	goto NEXT
+}
+
+type unsignedWindowFirstArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	windowEnd int64
+	res       *cursors.UnsignedArray
+	tmp       *cursors.UnsignedArray
+	window    interval.Window
+}
+
+// Window array cursors assume that every != 0 && every != MaxInt64.
+// Such a cursor will panic in the first case and possibly overflow in the second.
+func newUnsignedWindowFirstArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowFirstArrayCursor {
+	return &unsignedWindowFirstArrayCursor{
+		UnsignedArrayCursor: cur,
+		windowEnd:           math.MinInt64,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+		window:              window,
+	}
+}
+
+func (c *unsignedWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowFirstArrayCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+NEXT:
+	var a *cursors.UnsignedArray
+
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if a.Len() == 0 {
+		return c.res
+	}
+
+	for i, t := range a.Timestamps {
+		if t < c.windowEnd {
+			continue
+		}
+
+		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, a.Values[i])
+
+		if c.res.Len() == MaxPointsPerBlock {
+			c.tmp.Timestamps = a.Timestamps[i+1:]
+			c.tmp.Values = a.Values[i+1:]
+			return c.res
+		}
+	}
+
+	c.tmp.Timestamps = nil
+	c.tmp.Values = nil
 
-type unsignedMultiShardArrayCursor struct {
+	goto NEXT
+}
+
+type unsignedWindowCountArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	cursorContext
-	filter *unsignedArrayFilterCursor
+	res    *cursors.IntegerArray
+	tmp    *cursors.UnsignedArray
+	window interval.Window
 }
 
-func (c *unsignedMultiShardArrayCursor) reset(cur cursors.UnsignedArrayCursor, itrs cursors.CursorIterators, cond expression) {
-	if cond != nil {
-		if c.filter == nil {
-			c.filter = newUnsignedFilterArrayCursor(cond)
-		}
-		c.filter.reset(cur)
-		cur = c.filter
+func newUnsignedWindowCountArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowCountArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &unsignedWindowCountArrayCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewIntegerArrayLen(resLen),
+		tmp:                 &cursors.UnsignedArray{},
+		window:              window,
 	}
-
-	c.UnsignedArrayCursor = cur
-	c.itrs = itrs
-	c.err = nil
 }
 
-func (c *unsignedMultiShardArrayCursor) Err() error { return c.err }
-
-func (c *unsignedMultiShardArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedMultiShardArrayCursor) Next() *cursors.UnsignedArray {
-	for {
-		a := c.UnsignedArrayCursor.Next()
-		if a.Len() == 0 {
-			if c.nextArrayCursor() {
-				continue
-			}
-		}
-		return a
+func (c *unsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
 	}
-}
 
-func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
-	if len(c.itrs) == 0 {
-		return false
+	if a.Len() == 0 {
+		return &cursors.IntegerArray{}
 	}
 
-	c.UnsignedArrayCursor.Close()
+	rowIdx := 0
+	var acc int64 = 0
 
-	var itr cursors.CursorIterator
-	var cur cursors.Cursor
-	for cur == nil && len(c.itrs) > 0 {
-		itr, c.itrs = c.itrs[0], c.itrs[1:]
-		cur, _ = itr.Next(c.ctx, c.req)
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
 	}
+	windowHasPoints := false
 
-	var ok bool
-	if cur != nil {
-		var next cursors.UnsignedArrayCursor
-		next, ok = cur.(cursors.UnsignedArrayCursor)
-		if !ok {
-			cur.Close()
-			next = UnsignedEmptyArrayCursor
-			c.err = errors.New("expected unsigned cursor")
-		} else {
-			if c.filter != nil {
-				c.filter.reset(next)
-				next = c.filter
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = acc
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				acc = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				acc++
+				windowHasPoints = true
 			}
 		}
-		c.UnsignedArrayCursor = next
-	} else {
-		c.UnsignedArrayCursor = UnsignedEmptyArrayCursor
-	}
-
-	return ok
-}
 
-type unsignedLimitArrayCursor struct {
-	cursors.UnsignedArrayCursor
-	res  *cursors.UnsignedArray
-	done bool
-}
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
 
-func newUnsignedLimitArrayCursor(cur cursors.UnsignedArrayCursor) *unsignedLimitArrayCursor {
-	return &unsignedLimitArrayCursor{
-		UnsignedArrayCursor: cur,
-		res:                 cursors.NewUnsignedArrayLen(1),
+		// get the next chunk
+		a = c.UnsignedArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = acc
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
 	}
-}
 
-func (c *unsignedLimitArrayCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
 
-func (c *unsignedLimitArrayCursor) Next() *cursors.UnsignedArray {
-	if c.done {
-		return &cursors.UnsignedArray{}
-	}
-	a := c.UnsignedArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
-	c.done = true
-	c.res.Timestamps[0] = a.Timestamps[0]
-	c.res.Values[0] = a.Values[0]
 	return c.res
 }
 
-type unsignedWindowLastArrayCursor struct {
+type unsignedWindowSumArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	windowEnd int64
-	res       *cursors.UnsignedArray
-	tmp       *cursors.UnsignedArray
-	window    interval.Window
+	res    *cursors.UnsignedArray
+	tmp    *cursors.UnsignedArray
+	window interval.Window
 }
 
-// Window array cursors assume that every != 0 && every != MaxInt64.
-// Such a cursor will panic in the first case and possibly overflow in the second.
-func newUnsignedWindowLastArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowLastArrayCursor {
-	return &unsignedWindowLastArrayCursor{
+func newUnsignedWindowSumArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowSumArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &unsignedWindowSumArrayCursor{
 		UnsignedArrayCursor: cur,
-		windowEnd:           math.MinInt64,
-		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		res:                 cursors.NewUnsignedArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
 	}
 }
 
-func (c *unsignedWindowLastArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowSumArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowLastArrayCursor) Next() *cursors.UnsignedArray {
-	cur := -1
+func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-NEXT:
 	var a *cursors.UnsignedArray
-
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
@@ -2164,65 +3165,110 @@ NEXT:
 	}
 
 	if a.Len() == 0 {
-		c.res.Timestamps = c.res.Timestamps[:cur+1]
-		c.res.Values = c.res.Values[:cur+1]
-		return c.res
+		return &cursors.UnsignedArray{}
 	}
 
-	for i, t := range a.Timestamps {
-		if t >= c.windowEnd {
-			cur++
-		}
+	rowIdx := 0
+	var acc uint64 = 0
 
-		if cur == MaxPointsPerBlock {
-			c.tmp.Timestamps = a.Timestamps[i:]
-			c.tmp.Values = a.Values[i:]
-			return c.res
-		}
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
 
-		c.res.Timestamps[cur] = t
-		c.res.Values[cur] = a.Values[i]
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = acc
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
+
+				// start the new window
+				acc = 0
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				acc += a.Values[rowIdx]
+				windowHasPoints = true
+			}
+		}
 
-		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.UnsignedArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = acc
+				pos++
+			}
+			break WINDOWS
+		}
+		rowIdx = 0
 	}
 
-	c.tmp.Timestamps = nil
-	c.tmp.Values = nil
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
 
-	goto NEXT
+	return c.res
 }
 
-type unsignedWindowFirstArrayCursor struct {
+type unsignedWindowMinArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	windowEnd int64
-	res       *cursors.UnsignedArray
-	tmp       *cursors.UnsignedArray
-	window    interval.Window
+	res    *cursors.UnsignedArray
+	tmp    *cursors.UnsignedArray
+	window interval.Window
 }
 
-// Window array cursors assume that every != 0 && every != MaxInt64.
-// Such a cursor will panic in the first case and possibly overflow in the second.
-func newUnsignedWindowFirstArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowFirstArrayCursor {
-	return &unsignedWindowFirstArrayCursor{
+func newUnsignedWindowMinArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMinArrayCursor {
+	resLen := MaxPointsPerBlock
+	if window.IsZero() {
+		resLen = 1
+	}
+	return &unsignedWindowMinArrayCursor{
 		UnsignedArrayCursor: cur,
-		windowEnd:           math.MinInt64,
-		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		res:                 cursors.NewUnsignedArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
 	}
 }
 
-func (c *unsignedWindowFirstArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowMinArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowFirstArrayCursor) Next() *cursors.UnsignedArray {
-	c.res.Timestamps = c.res.Timestamps[:0]
-	c.res.Values = c.res.Values[:0]
+func (c *unsignedWindowMinArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
 
-NEXT:
 	var a *cursors.UnsignedArray
-
 	if c.tmp.Len() > 0 {
 		a = c.tmp
 	} else {
@@ -2230,57 +3276,109 @@ NEXT:
 	}
 
 	if a.Len() == 0 {
-		return c.res
+		return &cursors.UnsignedArray{}
 	}
 
-	for i, t := range a.Timestamps {
-		if t < c.windowEnd {
-			continue
-		}
+	rowIdx := 0
+	var acc uint64 = math.MaxUint64
+	var tsAcc int64
 
-		c.windowEnd = int64(c.window.GetLatestBounds(values.Time(t)).Stop())
+	var windowEnd int64
+	if !c.window.IsZero() {
+		windowEnd = int64(c.window.GetLatestBounds(values.Time(a.Timestamps[rowIdx])).Stop())
+	} else {
+		windowEnd = math.MaxInt64
+	}
+	windowHasPoints := false
 
-		c.res.Timestamps = append(c.res.Timestamps, t)
-		c.res.Values = append(c.res.Values, a.Values[i])
+	// enumerate windows
+WINDOWS:
+	for {
+		for ; rowIdx < a.Len(); rowIdx++ {
+			ts := a.Timestamps[rowIdx]
+			if !c.window.IsZero() && ts >= windowEnd {
+				// new window detected, close the current window
+				// do not generate a point for empty windows
+				if windowHasPoints {
+					c.res.Timestamps[pos] = tsAcc
+					c.res.Values[pos] = acc
+					pos++
+					if pos >= MaxPointsPerBlock {
+						// the output array is full,
+						// save the remaining points in the input array in tmp.
+						// they will be processed in the next call to Next()
+						c.tmp.Timestamps = a.Timestamps[rowIdx:]
+						c.tmp.Values = a.Values[rowIdx:]
+						break WINDOWS
+					}
+				}
 
-		if c.res.Len() == MaxPointsPerBlock {
-			c.tmp.Timestamps = a.Timestamps[i+1:]
-			c.tmp.Values = a.Values[i+1:]
-			return c.res
+				// start the new window
+				acc = math.MaxUint64
+				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
+				windowHasPoints = false
+
+				continue WINDOWS
+			} else {
+				if !windowHasPoints || a.Values[rowIdx] < acc {
+					acc = a.Values[rowIdx]
+					tsAcc = a.Timestamps[rowIdx]
+				}
+				windowHasPoints = true
+			}
+		}
+
+		// Clear buffered timestamps & values if we make it through a cursor.
+		// The break above will skip this if a cursor is partially read.
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+
+		// get the next chunk
+		a = c.UnsignedArrayCursor.Next()
+		if a.Len() == 0 {
+			// write the final point
+			// do not generate a point for empty windows
+			if windowHasPoints {
+				c.res.Timestamps[pos] = tsAcc
+				c.res.Values[pos] = acc
+				pos++
+			}
+			break WINDOWS
 		}
+		rowIdx = 0
 	}
 
-	c.tmp.Timestamps = nil
-	c.tmp.Values = nil
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
 
-	goto NEXT
+	return c.res
 }
 
-type unsignedWindowCountArrayCursor struct {
+type unsignedWindowMaxArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	res    *cursors.IntegerArray
+	res    *cursors.UnsignedArray
 	tmp    *cursors.UnsignedArray
 	window interval.Window
 }
 
-func newUnsignedWindowCountArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowCountArrayCursor {
+func newUnsignedWindowMaxArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMaxArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &unsignedWindowCountArrayCursor{
+	return &unsignedWindowMaxArrayCursor{
 		UnsignedArrayCursor: cur,
-		res:                 cursors.NewIntegerArrayLen(resLen),
+		res:                 cursors.NewUnsignedArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
 	}
 }
 
-func (c *unsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowMaxArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+func (c *unsignedWindowMaxArrayCursor) Next() *cursors.UnsignedArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -2293,11 +3391,12 @@ func (c *unsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 
 	if a.Len() == 0 {
-		return &cursors.IntegerArray{}
+		return &cursors.UnsignedArray{}
 	}
 
 	rowIdx := 0
-	var acc int64 = 0
+	var acc uint64 = 0
+	var tsAcc int64
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -2316,7 +3415,7 @@ WINDOWS:
 				// new window detected, close the current window
 				// do not generate a point for empty windows
 				if windowHasPoints {
-					c.res.Timestamps[pos] = windowEnd
+					c.res.Timestamps[pos] = tsAcc
 					c.res.Values[pos] = acc
 					pos++
 					if pos >= MaxPointsPerBlock {
@@ -2336,7 +3435,10 @@ WINDOWS:
 
 				continue WINDOWS
 			} else {
-				acc++
+				if !windowHasPoints || a.Values[rowIdx] > acc {
+					acc = a.Values[rowIdx]
+					tsAcc = a.Timestamps[rowIdx]
+				}
 				windowHasPoints = true
 			}
 		}
@@ -2352,7 +3454,7 @@ WINDOWS:
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
-				c.res.Timestamps[pos] = windowEnd
+				c.res.Timestamps[pos] = tsAcc
 				c.res.Values[pos] = acc
 				pos++
 			}
@@ -2367,31 +3469,31 @@ WINDOWS:
 	return c.res
 }
 
-type unsignedWindowSumArrayCursor struct {
+type unsignedWindowMeanArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	res    *cursors.UnsignedArray
+	res    *cursors.FloatArray
 	tmp    *cursors.UnsignedArray
 	window interval.Window
 }
 
-func newUnsignedWindowSumArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowSumArrayCursor {
+func newUnsignedWindowMeanArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMeanArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &unsignedWindowSumArrayCursor{
+	return &unsignedWindowMeanArrayCursor{
 		UnsignedArrayCursor: cur,
-		res:                 cursors.NewUnsignedArrayLen(resLen),
+		res:                 cursors.NewFloatArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
 	}
 }
 
-func (c *unsignedWindowSumArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
+func (c *unsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -2404,11 +3506,12 @@ func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
 	}
 
 	if a.Len() == 0 {
-		return &cursors.UnsignedArray{}
+		return &cursors.FloatArray{}
 	}
 
 	rowIdx := 0
-	var acc uint64 = 0
+	var sum uint64
+	var count int64
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -2428,7 +3531,7 @@ WINDOWS:
 				// do not generate a point for empty windows
 				if windowHasPoints {
 					c.res.Timestamps[pos] = windowEnd
-					c.res.Values[pos] = acc
+					c.res.Values[pos] = float64(sum) / float64(count)
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -2441,13 +3544,15 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = 0
+				sum = 0
+				count = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				acc += a.Values[rowIdx]
+				sum += a.Values[rowIdx]
+				count++
 				windowHasPoints = true
 			}
 		}
@@ -2464,7 +3569,7 @@ WINDOWS:
 			// do not generate a point for empty windows
 			if windowHasPoints {
 				c.res.Timestamps[pos] = windowEnd
-				c.res.Values[pos] = acc
+				c.res.Values[pos] = float64(sum) / float64(count)
 				pos++
 			}
 			break WINDOWS
@@ -2478,19 +3583,19 @@ WINDOWS:
 	return c.res
 }
 
-type unsignedWindowMinArrayCursor struct {
+type unsignedWindowSpreadArrayCursor struct {
 	cursors.UnsignedArrayCursor
 	res    *cursors.UnsignedArray
 	tmp    *cursors.UnsignedArray
 	window interval.Window
 }
 
-func newUnsignedWindowMinArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMinArrayCursor {
+func newUnsignedWindowSpreadArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowSpreadArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &unsignedWindowMinArrayCursor{
+	return &unsignedWindowSpreadArrayCursor{
 		UnsignedArrayCursor: cur,
 		res:                 cursors.NewUnsignedArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
@@ -2498,11 +3603,11 @@ func newUnsignedWindowMinArrayCursor(cur cursors.UnsignedArrayCursor, window int
 	}
 }
 
-func (c *unsignedWindowMinArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowSpreadArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowMinArrayCursor) Next() *cursors.UnsignedArray {
+func (c *unsignedWindowSpreadArrayCursor) Next() *cursors.UnsignedArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -2519,8 +3624,7 @@ func (c *unsignedWindowMinArrayCursor) Next() *cursors.UnsignedArray {
 	}
 
 	rowIdx := 0
-	var acc uint64 = math.MaxUint64
-	var tsAcc int64
+	var lo, hi uint64
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -2539,8 +3643,8 @@ WINDOWS:
 				// new window detected, close the current window
 				// do not generate a point for empty windows
 				if windowHasPoints {
-					c.res.Timestamps[pos] = tsAcc
-					c.res.Values[pos] = acc
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = hi - lo
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -2553,15 +3657,18 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = math.MaxUint64
+				lo = 0
+				hi = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				if !windowHasPoints || a.Values[rowIdx] < acc {
-					acc = a.Values[rowIdx]
-					tsAcc = a.Timestamps[rowIdx]
+				if !windowHasPoints || a.Values[rowIdx] < lo {
+					lo = a.Values[rowIdx]
+				}
+				if !windowHasPoints || a.Values[rowIdx] > hi {
+					hi = a.Values[rowIdx]
 				}
 				windowHasPoints = true
 			}
@@ -2578,8 +3685,8 @@ WINDOWS:
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
-				c.res.Timestamps[pos] = tsAcc
-				c.res.Values[pos] = acc
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = hi - lo
 				pos++
 			}
 			break WINDOWS
@@ -2593,31 +3700,31 @@ WINDOWS:
 	return c.res
 }
 
-type unsignedWindowMaxArrayCursor struct {
+type unsignedWindowStddevArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	res    *cursors.UnsignedArray
+	res    *cursors.FloatArray
 	tmp    *cursors.UnsignedArray
 	window interval.Window
 }
 
-func newUnsignedWindowMaxArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMaxArrayCursor {
+func newUnsignedWindowStddevArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowStddevArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &unsignedWindowMaxArrayCursor{
+	return &unsignedWindowStddevArrayCursor{
 		UnsignedArrayCursor: cur,
-		res:                 cursors.NewUnsignedArrayLen(resLen),
+		res:                 cursors.NewFloatArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
 	}
 }
 
-func (c *unsignedWindowMaxArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowStddevArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowMaxArrayCursor) Next() *cursors.UnsignedArray {
+func (c *unsignedWindowStddevArrayCursor) Next() *cursors.FloatArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -2630,12 +3737,11 @@ func (c *unsignedWindowMaxArrayCursor) Next() *cursors.UnsignedArray {
 	}
 
 	if a.Len() == 0 {
-		return &cursors.UnsignedArray{}
+		return &cursors.FloatArray{}
 	}
 
 	rowIdx := 0
-	var acc uint64 = 0
-	var tsAcc int64
+	var count, mean, m2 float64
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -2654,8 +3760,8 @@ WINDOWS:
 				// new window detected, close the current window
 				// do not generate a point for empty windows
 				if windowHasPoints {
-					c.res.Timestamps[pos] = tsAcc
-					c.res.Values[pos] = acc
+					c.res.Timestamps[pos] = windowEnd
+					c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -2668,16 +3774,18 @@ WINDOWS:
 				}
 
 				// start the new window
-				acc = 0
+				count = 0
+				mean = 0
+				m2 = 0
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				if !windowHasPoints || a.Values[rowIdx] > acc {
-					acc = a.Values[rowIdx]
-					tsAcc = a.Timestamps[rowIdx]
-				}
+				delta := float64(a.Values[rowIdx]) - mean
+				count++
+				mean += delta / count
+				m2 += delta * (float64(a.Values[rowIdx]) - mean)
 				windowHasPoints = true
 			}
 		}
@@ -2693,8 +3801,8 @@ WINDOWS:
 			// write the final point
 			// do not generate a point for empty windows
 			if windowHasPoints {
-				c.res.Timestamps[pos] = tsAcc
-				c.res.Values[pos] = acc
+				c.res.Timestamps[pos] = windowEnd
+				c.res.Values[pos] = math.Sqrt(m2 / (count - 1))
 				pos++
 			}
 			break WINDOWS
@@ -2708,31 +3816,37 @@ WINDOWS:
 	return c.res
 }
 
-type unsignedWindowMeanArrayCursor struct {
+type unsignedWindowQuantileArrayCursor struct {
 	cursors.UnsignedArrayCursor
 	res    *cursors.FloatArray
 	tmp    *cursors.UnsignedArray
 	window interval.Window
+
+	quantile    float64
+	compression float64
 }
 
-func newUnsignedWindowMeanArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window) *unsignedWindowMeanArrayCursor {
+func newUnsignedWindowQuantileArrayCursor(cur cursors.UnsignedArrayCursor, window interval.Window, quantile, compression float64) *unsignedWindowQuantileArrayCursor {
 	resLen := MaxPointsPerBlock
 	if window.IsZero() {
 		resLen = 1
 	}
-	return &unsignedWindowMeanArrayCursor{
+	return &unsignedWindowQuantileArrayCursor{
 		UnsignedArrayCursor: cur,
 		res:                 cursors.NewFloatArrayLen(resLen),
 		tmp:                 &cursors.UnsignedArray{},
 		window:              window,
+
+		quantile:    quantile,
+		compression: compression,
 	}
 }
 
-func (c *unsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
+func (c *unsignedWindowQuantileArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *unsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
+func (c *unsignedWindowQuantileArrayCursor) Next() *cursors.FloatArray {
 	pos := 0
 	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
 	c.res.Values = c.res.Values[:cap(c.res.Values)]
@@ -2749,8 +3863,7 @@ func (c *unsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
 	}
 
 	rowIdx := 0
-	var sum uint64
-	var count int64
+	td := tdigest.NewWithCompression(c.compression)
 
 	var windowEnd int64
 	if !c.window.IsZero() {
@@ -2770,7 +3883,7 @@ WINDOWS:
 				// do not generate a point for empty windows
 				if windowHasPoints {
 					c.res.Timestamps[pos] = windowEnd
-					c.res.Values[pos] = float64(sum) / float64(count)
+					c.res.Values[pos] = td.Quantile(c.quantile)
 					pos++
 					if pos >= MaxPointsPerBlock {
 						// the output array is full,
@@ -2783,15 +3896,13 @@ WINDOWS:
 				}
 
 				// start the new window
-				sum = 0
-				count = 0
+				td = tdigest.NewWithCompression(c.compression)
 				windowEnd = int64(c.window.GetLatestBounds(values.Time(ts)).Stop())
 				windowHasPoints = false
 
 				continue WINDOWS
 			} else {
-				sum += a.Values[rowIdx]
-				count++
+				td.Add(float64(a.Values[rowIdx]), 1)
 				windowHasPoints = true
 			}
 		}
@@ -2808,7 +3919,7 @@ WINDOWS:
 			// do not generate a point for empty windows
 			if windowHasPoints {
 				c.res.Timestamps[pos] = windowEnd
-				c.res.Values[pos] = float64(sum) / float64(count)
+				c.res.Values[pos] = td.Quantile(c.quantile)
 				pos++
 			}
 			break WINDOWS
@@ -45,6 +45,12 @@ func newWindowAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate
 		return newWindowMaxArrayCursor(cursor, window), nil
 	case datatypes.AggregateTypeMean:
 		return newWindowMeanArrayCursor(cursor, window)
+	case datatypes.AggregateTypeSpread:
+		return newWindowSpreadArrayCursor(cursor, window)
+	case datatypes.AggregateTypeStddev:
+		return newWindowStddevArrayCursor(cursor, window)
+	case datatypes.AggregateTypeQuantile:
+		return newWindowQuantileArrayCursor(cursor, window, agg.Quantile, agg.Compression)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
@@ -11,6 +11,7 @@ import (
 	"github.com/influxdata/flux/interval"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/v2/tsdb/cursors"
 	"github.com/influxdata/influxdb/v2/tsdb/cursors/mock"
 	"github.com/stretchr/testify/require"
@@ -2169,6 +2170,248 @@ func TestWindowMeanArrayCursor(t *testing.T) {
 	}
 }
 
+func TestWindowSpreadArrayCursor(t *testing.T) {
+	maxTimestamp := time.Unix(0, math.MaxInt64)
+
+	testcases := []aggArrayCursorTest{
+		{
+			name:  "no window",
+			every: 0,
+			inputArrays: []*cursors.IntegerArray{
+				makeIntegerArray(
+					5,
+					mustParseTime("2010-01-01T00:00:00Z"), time.Minute,
+					func(i int64) int64 { return []int64{3, -2, 5, 1, 4}[i] },
+				),
+			},
+			wantIntegers: []*cursors.IntegerArray{
+				makeIntegerArray(1, maxTimestamp, 0, func(int64) int64 { return 7 }),
+			},
+		},
+		{
+			name:         "no window empty",
+			every:        0,
+			inputArrays:  []*cursors.IntegerArray{},
+			wantIntegers: []*cursors.IntegerArray{},
+		},
+		{
+			name:  "window",
+			every: 30 * time.Minute,
+			inputArrays: []*cursors.IntegerArray{
+				makeIntegerArray(
+					8,
+					mustParseTime("2010-01-01T00:00:00Z"), 15*time.Minute,
+					func(i int64) int64 { return i * i },
+				),
+			},
+			wantIntegers: []*cursors.IntegerArray{
+				makeIntegerArray(4, mustParseTime("2010-01-01T00:30:00Z"), 30*time.Minute,
+					func(i int64) int64 { return 4*i + 1 }),
+			},
+		},
+		{
+			name:  "single point windows",
+			every: 15 * time.Minute,
+			inputArrays: []*cursors.IntegerArray{
+				makeIntegerArray(
+					2,
+					mustParseTime("2010-01-01T00:05:00Z"), 30*time.Minute,
+					func(i int64) int64 { return 100 + i },
+				),
+			},
+			wantIntegers: []*cursors.IntegerArray{
+				makeIntegerArray(2, mustParseTime("2010-01-01T00:15:00Z"), 30*time.Minute,
+					func(int64) int64 { return 0 }),
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc.createCursorFn = func(cur cursors.IntegerArrayCursor, every, offset int64, window interval.Window) cursors.Cursor {
+			if every != 0 || offset != 0 {
+				window, _ = interval.NewWindow(
+					values.MakeDuration(every, 0, false),
+					values.MakeDuration(every, 0, false),
+					values.MakeDuration(offset, 0, false),
+				)
+			}
+			return newIntegerWindowSpreadArrayCursor(cur, window)
+		}
+		tc.run(t)
+	}
+}
+
+func TestWindowStddevArrayCursor(t *testing.T) {
+	maxTimestamp := time.Unix(0, math.MaxInt64)
+
+	testcases := []aggArrayCursorTest{
+		{
+			name:  "no window",
+			every: 0,
+			inputArrays: []*cursors.IntegerArray{
+				makeIntegerArray(
+					5,
+					mustParseTime("2010-01-01T00:00:00Z"), time.Minute,
+					func(i int64) int64 { return i + 1 },
+				),
+			},
+			wantFloats: []*cursors.FloatArray{
+				makeFloatArray(1, maxTimestamp, 0, func(int64) float64 { return math.Sqrt(2.5) }),
+			},
+		},
+		{
+			name:        "no window empty",
+			every:       0,
+			inputArrays: []*cursors.IntegerArray{},
+			wantFloats:  []*cursors.FloatArray{},
+		},
+		{
+			name:  "window",
+			every: 30 * time.Minute,
+			inputArrays: []*cursors.IntegerArray{
+				makeIntegerArray(
+					8,
+					mustParseTime("2010-01-01T00:00:00Z"), 15*time.Minute,
+					func(i int64) int64 { return i * i },
+				),
+			},
+			wantFloats: []*cursors.FloatArray{
+				// the sample standard deviation of two points is
+				// their difference over the square root of two.
+				makeFloatArray(4, mustParseTime("2010-01-01T00:30:00Z"), 30*time.Minute,
+					func(i int64) float64 {
+						d := float64(4*i + 1)
+						return math.Sqrt(d * d / 2)
+					}),
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc.createCursorFn = func(cur cursors.IntegerArrayCursor, every, offset int64, window interval.Window) cursors.Cursor {
+			if every != 0 || offset != 0 {
+				window, _ = interval.NewWindow(
+					values.MakeDuration(every, 0, false),
+					values.MakeDuration(every, 0, false),
+					values.MakeDuration(offset, 0, false),
+				)
+			}
+			return newIntegerWindowStddevArrayCursor(cur, window)
+		}
+		tc.run(t)
+	}
+}
+
+func TestWindowStddevArrayCursor_SinglePoint(t *testing.T) {
+	// A window with a single point has no sample standard deviation.
+	cur := newIntegerWindowStddevArrayCursor(&MockIntegerArrayCursor{
+		CloseFunc: func() {},
+		ErrFunc:   func() error { return nil },
+		StatsFunc: func() cursors.CursorStats { return cursors.CursorStats{} },
+		NextFunc: func() func() *cursors.IntegerArray {
+			arrs := []*cursors.IntegerArray{
+				makeIntegerArray(1, mustParseTime("2010-01-01T00:00:00Z"), 0, func(int64) int64 { return 3 }),
+			}
+			return func() *cursors.IntegerArray {
+				if len(arrs) == 0 {
+					return &cursors.IntegerArray{}
+				}
+				a := arrs[0]
+				arrs = arrs[1:]
+				return a
+			}
+		}(),
+	}, interval.Window{})
+
+	got := cur.Next()
+	require.Equal(t, 1, got.Len())
+	require.True(t, math.IsNaN(got.Values[0]))
+}
+
+func TestWindowQuantileArrayCursor(t *testing.T) {
+	newCursor := func(window interval.Window, q float64, arrs ...*cursors.FloatArray) cursors.FloatArrayCursor {
+		return newFloatWindowQuantileArrayCursor(&MockFloatArrayCursor{
+			CloseFunc: func() {},
+			ErrFunc:   func() error { return nil },
+			StatsFunc: func() cursors.CursorStats { return cursors.CursorStats{} },
+			NextFunc: func() *cursors.FloatArray {
+				if len(arrs) == 0 {
+					return &cursors.FloatArray{}
+				}
+				a := arrs[0]
+				arrs = arrs[1:]
+				return a
+			},
+		}, window, q, 1000)
+	}
+
+	t.Run("no window", func(t *testing.T) {
+		cur := newCursor(interval.Window{}, 0.5,
+			makeFloatArray(3, mustParseTime("2010-01-01T00:00:00Z"), time.Minute,
+				func(i int64) float64 { return []float64{5, 1, 4}[i] }),
+			makeFloatArray(2, mustParseTime("2010-01-01T00:03:00Z"), time.Minute,
+				func(i int64) float64 { return []float64{2, 3}[i] }),
+		)
+		got := cur.Next()
+		require.Equal(t, []int64{math.MaxInt64}, got.Timestamps)
+		require.Equal(t, []float64{3}, got.Values)
+		require.Equal(t, 0, cur.Next().Len())
+	})
+
+	t.Run("window", func(t *testing.T) {
+		window, _ := interval.NewWindow(
+			values.MakeDuration(int64(30*time.Minute), 0, false),
+			values.MakeDuration(int64(30*time.Minute), 0, false),
+			values.MakeDuration(0, 0, false),
+		)
+		cur := newCursor(window, 0,
+			makeFloatArray(6, mustParseTime("2010-01-01T00:00:00Z"), 10*time.Minute,
+				func(i int64) float64 { return []float64{7, 2, 9, 8, 3, 6}[i] }),
+		)
+		got := cur.Next()
+		require.Equal(t, []int64{
+			mustParseTime("2010-01-01T00:30:00Z").UnixNano(),
+			mustParseTime("2010-01-01T01:00:00Z").UnixNano(),
+		}, got.Timestamps)
+		require.Equal(t, []float64{2, 3}, got.Values)
+	})
+
+	t.Run("integer", func(t *testing.T) {
+		window, _ := interval.NewWindow(
+			values.MakeDuration(int64(30*time.Minute), 0, false),
+			values.MakeDuration(int64(30*time.Minute), 0, false),
+			values.MakeDuration(0, 0, false),
+		)
+		arrs := []*cursors.IntegerArray{
+			makeIntegerArray(6, mustParseTime("2010-01-01T00:00:00Z"), 10*time.Minute,
+				func(i int64) int64 { return []int64{7, 2, 9, 8, 3, 6}[i] }),
+		}
+		cur, err := newWindowAggregateArrayCursor(context.Background(), &datatypes.Aggregate{
+			Type:        datatypes.AggregateTypeQuantile,
+			Quantile:    0,
+			Compression: 1000,
+		}, window, &MockIntegerArrayCursor{
+			CloseFunc: func() {},
+			ErrFunc:   func() error { return nil },
+			StatsFunc: func() cursors.CursorStats { return cursors.CursorStats{} },
+			NextFunc: func() *cursors.IntegerArray {
+				if len(arrs) == 0 {
+					return &cursors.IntegerArray{}
+				}
+				a := arrs[0]
+				arrs = arrs[1:]
+				return a
+			},
+		})
+		require.NoError(t, err)
+
+		got := cur.(cursors.FloatArrayCursor).Next()
+		require.Equal(t, []int64{
+			mustParseTime("2010-01-01T00:30:00Z").UnixNano(),
+			mustParseTime("2010-01-01T01:00:00Z").UnixNano(),
+		}, got.Timestamps)
+		require.Equal(t, []float64{2, 3}, got.Values)
+	})
+}
+
 // This test replicates GitHub issue
 // https://github.com/influxdata/influxdb/issues/20035
 func TestMultiShardArrayCursor(t *testing.T) {
@@ -92,36 +92,45 @@ func (ReadGroupRequest_HintFlags) EnumDescriptor() ([]byte, []int) {
 type Aggregate_AggregateType int32
 
 const (
-	AggregateTypeNone  Aggregate_AggregateType = 0
-	AggregateTypeSum   Aggregate_AggregateType = 1
-	AggregateTypeCount Aggregate_AggregateType = 2
-	AggregateTypeMin   Aggregate_AggregateType = 3
-	AggregateTypeMax   Aggregate_AggregateType = 4
-	AggregateTypeFirst Aggregate_AggregateType = 5
-	AggregateTypeLast  Aggregate_AggregateType = 6
-	AggregateTypeMean  Aggregate_AggregateType = 7
+	AggregateTypeNone     Aggregate_AggregateType = 0
+	AggregateTypeSum      Aggregate_AggregateType = 1
+	AggregateTypeCount    Aggregate_AggregateType = 2
+	AggregateTypeMin      Aggregate_AggregateType = 3
+	AggregateTypeMax      Aggregate_AggregateType = 4
+	AggregateTypeFirst    Aggregate_AggregateType = 5
+	AggregateTypeLast     Aggregate_AggregateType = 6
+	AggregateTypeMean     Aggregate_AggregateType = 7
+	AggregateTypeSpread   Aggregate_AggregateType = 8
+	AggregateTypeStddev   Aggregate_AggregateType = 9
+	AggregateTypeQuantile Aggregate_AggregateType = 10
 )
 
 var Aggregate_AggregateType_name = map[int32]string{
-	0: "NONE",
-	1: "SUM",
-	2: "COUNT",
-	3: "MIN",
-	4: "MAX",
-	5: "FIRST",
-	6: "LAST",
-	7: "MEAN",
+	0:  "NONE",
+	1:  "SUM",
+	2:  "COUNT",
+	3:  "MIN",
+	4:  "MAX",
+	5:  "FIRST",
+	6:  "LAST",
+	7:  "MEAN",
+	8:  "SPREAD",
+	9:  "STDDEV",
+	10: "QUANTILE",
 }
 
 var Aggregate_AggregateType_value = map[string]int32{
-	"NONE":  0,
-	"SUM":   1,
-	"COUNT": 2,
-	"MIN":   3,
-	"MAX":   4,
-	"FIRST": 5,
-	"LAST":  6,
-	"MEAN":  7,
+	"NONE":     0,
+	"SUM":      1,
+	"COUNT":    2,
+	"MIN":      3,
+	"MAX":      4,
+	"FIRST":    5,
+	"LAST":     6,
+	"MEAN":     7,
+	"SPREAD":   8,
+	"STDDEV":   9,
+	"QUANTILE": 10,
 }
 
 func (x Aggregate_AggregateType) String() string {
@@ -314,6 +323,12 @@ var xxx_messageInfo_ReadGroupRequest proto.InternalMessageInfo
 
 type Aggregate struct {
 	Type Aggregate_AggregateType `protobuf:"varint,1,opt,name=type,proto3,enum=influxdata.platform.storage.Aggregate_AggregateType" json:"type,omitempty"`
+	// quantile is the quantile to compute for the QUANTILE aggregate,
+	// in the range [0, 1].
+	Quantile float64 `protobuf:"fixed64,2,opt,name=quantile,proto3" json:"quantile,omitempty"`
+	// compression is the compression of the t-digest used to estimate
+	// the QUANTILE aggregate.
+	Compression float64 `protobuf:"fixed64,3,opt,name=compression,proto3" json:"compression,omitempty"`
 }
 
 func (m *Aggregate) Reset()         { *m = Aggregate{} }
@@ -1487,126 +1502,131 @@ func init() {
 func init() { proto.RegisterFile("storage_common.proto", fileDescriptor_715e4bf4cdf1f73d) }
 
 var fileDescriptor_715e4bf4cdf1f73d = []byte{
-	// 1891 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
-	0x15, 0xe7, 0xf2, 0x4b, 0xdc, 0x47, 0x8a, 0x5e, 0x4f, 0x54, 0x47, 0x5e, 0xc7, 0xe4, 0x86, 0x69,
-	0x12, 0x01, 0x75, 0x29, 0x40, 0x49, 0x81, 0xc0, 0xae, 0x81, 0x8a, 0x12, 0x25, 0xb1, 0x16, 0x49,
-	0x61, 0x48, 0xa5, 0x1f, 0x17, 0x76, 0x24, 0x0e, 0xd7, 0x8b, 0x90, 0xbb, 0xec, 0xee, 0xd2, 0x31,
-	0x81, 0x5e, 0x0a, 0xf4, 0x10, 0xf0, 0xd4, 0x02, 0xed, 0xa5, 0x05, 0x4f, 0x3d, 0xf6, 0xd0, 0x5b,
-	0xff, 0x06, 0x17, 0xe8, 0x21, 0xa7, 0xa2, 0x27, 0xa2, 0xa5, 0x81, 0x02, 0x3d, 0xf7, 0xd4, 0xf4,
-	0x52, 0xcc, 0xc7, 0x2e, 0x97, 0xb2, 0xaa, 0x88, 0x86, 0x0f, 0x81, 0x73, 0x9b, 0x79, 0xf3, 0xde,
-	0xef, 0xcd, 0x7b, 0xfb, 0xbe, 0x76, 0x60, 0xc3, 0xf3, 0x1d, 0x97, 0x98, 0xb4, 0x73, 0xee, 0x0c,
-	0x06, 0x8e, 0x5d, 0x1e, 0xba, 0x8e, 0xef, 0xa0, 0x3b, 0x96, 0xdd, 0xeb, 0x8f, 0x9e, 0x76, 0x89,
-	0x4f, 0xca, 0xc3, 0x3e, 0xf1, 0x7b, 0x8e, 0x3b, 0x28, 0x4b, 0x4e, 0x7d, 0xc3, 0x74, 0x4c, 0x87,
-	0xf3, 0x6d, 0xb3, 0x95, 0x10, 0xd1, 0x6f, 0x9b, 0x8e, 0x63, 0xf6, 0xe9, 0x36, 0xdf, 0x9d, 0x8d,
-	0x7a, 0xdb, 0xc4, 0x1e, 0xcb, 0xa3, 0x1b, 0x43, 0x97, 0x76, 0xad, 0x73, 0xe2, 0x53, 0x41, 0x28,
-	0xfd, 0x4b, 0x81, 0x9b, 0x98, 0x92, 0xee, 0x81, 0xd5, 0xf7, 0xa9, 0x8b, 0xe9, 0x4f, 0x47, 0xd4,
-	0xf3, 0x51, 0x15, 0xb2, 0x2e, 0x25, 0xdd, 0x8e, 0xe7, 0x8c, 0xdc, 0x73, 0xba, 0xa9, 0x18, 0xca,
-	0x56, 0x76, 0x67, 0xa3, 0x2c, 0x70, 0xcb, 0x01, 0x6e, 0x79, 0xd7, 0x1e, 0x57, 0xf2, 0xf3, 0x59,
-	0x11, 0x18, 0x42, 0x8b, 0xf3, 0x62, 0x70, 0xc3, 0x35, 0x3a, 0x84, 0x94, 0x4b, 0x6c, 0x93, 0x6e,
-	0xc6, 0x39, 0xc0, 0xb7, 0xca, 0x57, 0xd8, 0x52, 0x6e, 0x5b, 0x03, 0xea, 0xf9, 0x64, 0x30, 0xc4,
-	0x4c, 0xa4, 0x92, 0x7c, 0x36, 0x2b, 0xc6, 0xb0, 0x90, 0x47, 0xfb, 0xa0, 0x86, 0x17, 0xdf, 0x4c,
-	0x70, 0xb0, 0xf7, 0xae, 0x04, 0x3b, 0x09, 0xb8, 0xf1, 0x42, 0xb0, 0xf4, 0x97, 0x14, 0x68, 0xec,
-	0xa6, 0x87, 0xae, 0x33, 0x1a, 0xbe, 0xd6, 0xa6, 0xa2, 0x7b, 0x00, 0x26, 0xb3, 0xb2, 0xf3, 0x09,
-	0x1d, 0x7b, 0x9b, 0x49, 0x23, 0xb1, 0xa5, 0x56, 0xd6, 0xe7, 0xb3, 0xa2, 0xca, 0x6d, 0x7f, 0x44,
-	0xc7, 0x1e, 0x56, 0xcd, 0x60, 0x89, 0x6a, 0x90, 0xe2, 0x9b, 0xcd, 0x94, 0xa1, 0x6c, 0xe5, 0x77,
-	0x3e, 0xb8, 0x52, 0xdf, 0x45, 0x0f, 0x96, 0xc5, 0x46, 0x20, 0xb0, 0xeb, 0x13, 0xd3, 0x74, 0xa9,
-	0xc9, 0xae, 0x9f, 0xbe, 0xc6, 0xf5, 0x77, 0x03, 0x6e, 0xbc, 0x10, 0x44, 0xf7, 0x20, 0xf5, 0xd8,
-	0xb2, 0x7d, 0x6f, 0x73, 0xcd, 0x50, 0xb6, 0xd6, 0x2a, 0xb7, 0xe6, 0xb3, 0x62, 0xea, 0x88, 0x11,
-	0xbe, 0x98, 0x15, 0x55, 0xb6, 0x38, 0xe8, 0x13, 0xd3, 0xc3, 0x82, 0xa9, 0x74, 0x08, 0x29, 0x7e,
-	0x07, 0x74, 0x17, 0xe0, 0x10, 0x37, 0x4f, 0x4f, 0x3a, 0x8d, 0x66, 0xa3, 0xaa, 0xc5, 0xf4, 0xf5,
-	0xc9, 0xd4, 0x10, 0x16, 0x37, 0x1c, 0x9b, 0xa2, 0xdb, 0x90, 0x11, 0xc7, 0x95, 0x1f, 0x69, 0x71,
-	0x3d, 0x3b, 0x99, 0x1a, 0x6b, 0xfc, 0xb0, 0x32, 0xd6, 0x93, 0x9f, 0xfd, 0xbe, 0x10, 0x2b, 0xfd,
-	0x41, 0x81, 0x05, 0x3a, 0xba, 0x03, 0xea, 0x51, 0xad, 0xd1, 0x0e, 0xc0, 0x72, 0x93, 0xa9, 0x91,
-	0x61, 0xa7, 0x1c, 0xeb, 0x9b, 0x90, 0x97, 0x87, 0x9d, 0x93, 0x66, 0xad, 0xd1, 0x6e, 0x69, 0x8a,
-	0xae, 0x4d, 0xa6, 0x46, 0x4e, 0x70, 0x9c, 0x38, 0xec, 0x66, 0x51, 0xae, 0x56, 0x15, 0xd7, 0xaa,
-	0x2d, 0x2d, 0x1e, 0xe5, 0x6a, 0x51, 0xd7, 0xa2, 0x1e, 0xda, 0x86, 0x0d, 0xce, 0xd5, 0xda, 0x3b,
-	0xaa, 0xd6, 0x77, 0x3b, 0xbb, 0xc7, 0xc7, 0x9d, 0x76, 0xad, 0x5e, 0xd5, 0x92, 0xfa, 0x37, 0x26,
-	0x53, 0xe3, 0x26, 0xe3, 0x6d, 0x9d, 0x3f, 0xa6, 0x03, 0xb2, 0xdb, 0xef, 0xb3, 0xd0, 0x91, 0xb7,
-	0xfd, 0x77, 0x1c, 0xd4, 0xd0, 0x7b, 0xe8, 0x08, 0x92, 0xfe, 0x78, 0x28, 0x02, 0x38, 0xbf, 0xf3,
-	0xe1, 0xf5, 0x7c, 0xbe, 0x58, 0xb5, 0xc7, 0x43, 0x8a, 0x39, 0x42, 0xe9, 0x77, 0x71, 0x58, 0x5f,
-	0xa2, 0xa3, 0x22, 0x24, 0xa5, 0x13, 0xf8, 0x85, 0x96, 0x0e, 0xb9, 0x37, 0xee, 0x42, 0xa2, 0x75,
-	0x5a, 0xd7, 0x14, 0x7d, 0x63, 0x32, 0x35, 0xb4, 0xa5, 0xf3, 0xd6, 0x68, 0x80, 0xde, 0x86, 0xd4,
-	0x5e, 0xf3, 0xb4, 0xd1, 0xd6, 0xe2, 0xfa, 0xad, 0xc9, 0xd4, 0x40, 0x4b, 0x0c, 0x7b, 0xce, 0xc8,
-	0xf6, 0x19, 0x42, 0xbd, 0xd6, 0xd0, 0x12, 0x97, 0x20, 0xd4, 0x2d, 0x9b, 0x1f, 0xef, 0xfe, 0x50,
-	0x4b, 0x5e, 0x76, 0x4c, 0x9e, 0x32, 0x05, 0x07, 0x35, 0xdc, 0x6a, 0x6b, 0xa9, 0x4b, 0x14, 0x1c,
-	0x58, 0xae, 0xe7, 0x33, 0x1b, 0x8e, 0x77, 0x5b, 0x6d, 0x2d, 0x7d, 0x89, 0x0d, 0xc7, 0x44, 0x30,
-	0xd4, 0xab, 0xbb, 0x0d, 0x6d, 0xed, 0x12, 0x86, 0x3a, 0x25, 0xb6, 0xf4, 0xfa, 0xb7, 0x21, 0xd1,
-	0x26, 0x26, 0xd2, 0x20, 0xf1, 0x09, 0x1d, 0x73, 0x6f, 0xe7, 0x30, 0x5b, 0xa2, 0x0d, 0x48, 0x3d,
-	0x21, 0xfd, 0x91, 0xa8, 0x00, 0x39, 0x2c, 0x36, 0xa5, 0x5f, 0xe5, 0x21, 0xc7, 0x32, 0x06, 0x53,
-	0x6f, 0xe8, 0xd8, 0x1e, 0x45, 0x75, 0x48, 0xf7, 0x5c, 0x32, 0xa0, 0xde, 0xa6, 0x62, 0x24, 0xb6,
-	0xb2, 0x3b, 0xdb, 0x5f, 0x9a, 0x6c, 0x81, 0x68, 0xf9, 0x80, 0xc9, 0xc9, 0x6a, 0x21, 0x41, 0xf4,
-	0xcf, 0xd2, 0x90, 0xe2, 0x74, 0x74, 0x1c, 0x24, 0xf1, 0x1a, 0xcf, 0xba, 0x0f, 0xaf, 0x8f, 0xcb,
-	0x93, 0x80, 0x83, 0x1c, 0xc5, 0x82, 0x3c, 0x6e, 0x42, 0xda, 0xe3, 0xd1, 0x29, 0x2b, 0xe2, 0x77,
-	0xae, 0x0f, 0x27, 0xa2, 0x3a, 0xc0, 0x93, 0x30, 0x68, 0x08, 0xb9, 0x5e, 0xdf, 0x21, 0x7e, 0x67,
-	0xc8, 0x53, 0x43, 0xd6, 0xc9, 0xfb, 0x2b, 0x58, 0xcf, 0xa4, 0x45, 0x5e, 0x09, 0x47, 0xdc, 0x98,
-	0xcf, 0x8a, 0xd9, 0x08, 0xf5, 0x28, 0x86, 0xb3, 0xbd, 0xc5, 0x16, 0x3d, 0x85, 0xbc, 0x65, 0xfb,
-	0xd4, 0xa4, 0x6e, 0xa0, 0x53, 0x94, 0xd3, 0xef, 0x5e, 0x5f, 0x67, 0x4d, 0xc8, 0x47, 0xb5, 0xde,
-	0x9c, 0xcf, 0x8a, 0xeb, 0x4b, 0xf4, 0xa3, 0x18, 0x5e, 0xb7, 0xa2, 0x04, 0xf4, 0x33, 0xb8, 0x31,
-	0xb2, 0x3d, 0xcb, 0xb4, 0x69, 0x37, 0x50, 0x9d, 0xe4, 0xaa, 0x1f, 0x5e, 0x5f, 0xf5, 0xa9, 0x04,
-	0x88, 0xea, 0x46, 0xf3, 0x59, 0x31, 0xbf, 0x7c, 0x70, 0x14, 0xc3, 0xf9, 0xd1, 0x12, 0x85, 0xd9,
-	0x7d, 0xe6, 0x38, 0x7d, 0x4a, 0xec, 0x40, 0x79, 0x6a, 0x55, 0xbb, 0x2b, 0x42, 0xfe, 0x05, 0xbb,
-	0x97, 0xe8, 0xcc, 0xee, 0xb3, 0x28, 0x01, 0xf9, 0xb0, 0xee, 0xf9, 0xae, 0x65, 0x9b, 0x81, 0x62,
-	0xd1, 0x00, 0x1e, 0xac, 0x10, 0x3b, 0x5c, 0x3c, 0xaa, 0x57, 0x9b, 0xcf, 0x8a, 0xb9, 0x28, 0xf9,
-	0x28, 0x86, 0x73, 0x5e, 0x64, 0x5f, 0x49, 0x43, 0x92, 0x21, 0xeb, 0x4f, 0x01, 0x16, 0x91, 0x8c,
-	0xde, 0x83, 0x8c, 0x4f, 0x4c, 0xd1, 0xff, 0x58, 0xa6, 0xe5, 0x2a, 0xd9, 0xf9, 0xac, 0xb8, 0xd6,
-	0x26, 0x26, 0xef, 0x7e, 0x6b, 0xbe, 0x58, 0xa0, 0x0a, 0xa0, 0x21, 0x71, 0x7d, 0xcb, 0xb7, 0x1c,
-	0x9b, 0x71, 0x77, 0x9e, 0x90, 0x3e, 0x8b, 0x4e, 0x26, 0xb1, 0x31, 0x9f, 0x15, 0xb5, 0x93, 0xe0,
-	0xf4, 0x11, 0x1d, 0x7f, 0x4c, 0xfa, 0x1e, 0xd6, 0x86, 0x17, 0x28, 0xfa, 0x6f, 0x15, 0xc8, 0x46,
-	0xa2, 0x1e, 0xdd, 0x87, 0xa4, 0x4f, 0xcc, 0x20, 0xc3, 0x8d, 0xab, 0x67, 0x01, 0x62, 0xca, 0x94,
-	0xe6, 0x32, 0xa8, 0x09, 0x2a, 0x63, 0xec, 0xf0, 0x62, 0x1e, 0xe7, 0xc5, 0x7c, 0xe7, 0xfa, 0xfe,
-	0xdb, 0x27, 0x3e, 0xe1, 0xa5, 0x3c, 0xd3, 0x95, 0x2b, 0xfd, 0xfb, 0xa0, 0x5d, 0x4c, 0x1d, 0x54,
-	0x00, 0xf0, 0x83, 0x19, 0x44, 0x5c, 0x53, 0xc3, 0x11, 0x0a, 0xba, 0x05, 0x69, 0x5e, 0xbe, 0x84,
-	0x23, 0x14, 0x2c, 0x77, 0xfa, 0x31, 0xa0, 0x17, 0x53, 0x62, 0x45, 0xb4, 0x44, 0x88, 0x56, 0x87,
-	0x37, 0x2e, 0x89, 0xf2, 0x15, 0xe1, 0x92, 0xd1, 0xcb, 0xbd, 0x18, 0xb7, 0x2b, 0xa2, 0x65, 0x42,
-	0xb4, 0x47, 0x70, 0xf3, 0x85, 0x60, 0x5c, 0x11, 0x4c, 0x0d, 0xc0, 0x4a, 0x2d, 0x50, 0x39, 0x80,
-	0xec, 0xa6, 0x69, 0x39, 0x0c, 0xc4, 0xf4, 0x37, 0x26, 0x53, 0xe3, 0x46, 0x78, 0x24, 0xe7, 0x81,
-	0x22, 0xa4, 0xc3, 0x99, 0x62, 0x99, 0x41, 0xdc, 0x45, 0x76, 0xa2, 0x3f, 0x29, 0x90, 0x09, 0xbe,
-	0x37, 0x7a, 0x0b, 0x52, 0x07, 0xc7, 0xcd, 0xdd, 0xb6, 0x16, 0xd3, 0x6f, 0x4e, 0xa6, 0xc6, 0x7a,
-	0x70, 0xc0, 0x3f, 0x3d, 0x32, 0x60, 0xad, 0xd6, 0x68, 0x57, 0x0f, 0xab, 0x38, 0x80, 0x0c, 0xce,
-	0xe5, 0xe7, 0x44, 0x25, 0xc8, 0x9c, 0x36, 0x5a, 0xb5, 0xc3, 0x46, 0x75, 0x5f, 0x8b, 0x8b, 0x2e,
-	0x1b, 0xb0, 0x04, 0xdf, 0x88, 0xa1, 0x54, 0x9a, 0xcd, 0x63, 0xd6, 0x24, 0x13, 0xcb, 0x28, 0xd2,
-	0xef, 0xa8, 0x00, 0xe9, 0x56, 0x1b, 0xd7, 0x1a, 0x87, 0x5a, 0x52, 0x47, 0x93, 0xa9, 0x91, 0x0f,
-	0x18, 0x84, 0x2b, 0xe5, 0xc5, 0xb7, 0x00, 0xf6, 0xc8, 0x90, 0x9c, 0x59, 0x7d, 0xcb, 0x1f, 0x23,
-	0x1d, 0x32, 0x3d, 0x4a, 0xfc, 0x91, 0x2b, 0x5b, 0xa2, 0x8a, 0xc3, 0x7d, 0xe9, 0xcf, 0x0a, 0x6c,
-	0x84, 0xac, 0x16, 0xf5, 0xc2, 0x2e, 0xda, 0x84, 0xe4, 0x39, 0x19, 0x06, 0x19, 0x76, 0x75, 0x81,
-	0xb9, 0x0c, 0x80, 0x11, 0xbd, 0xaa, 0xed, 0xbb, 0x63, 0xcc, 0x81, 0xf4, 0x9f, 0x80, 0x1a, 0x92,
-	0xa2, 0xcd, 0x5d, 0x15, 0xcd, 0xfd, 0x61, 0xb4, 0xb9, 0x67, 0x77, 0xde, 0xbf, 0x9e, 0xc2, 0xb1,
-	0x9c, 0x02, 0xee, 0xc7, 0x3f, 0x52, 0x4a, 0x1f, 0x41, 0x7e, 0x79, 0xee, 0x67, 0x13, 0x83, 0xe7,
-	0x13, 0xd7, 0xe7, 0x8a, 0x12, 0x58, 0x6c, 0x98, 0x72, 0x6a, 0x77, 0xb9, 0xa2, 0x04, 0x66, 0xcb,
-	0xd2, 0x3f, 0x15, 0xc8, 0x07, 0x75, 0x6b, 0xf1, 0xd7, 0xc2, 0xaa, 0xc5, 0xb5, 0xff, 0x5a, 0xda,
-	0xc4, 0xf4, 0x82, 0xbf, 0x16, 0x3f, 0x5c, 0x7f, 0xd5, 0x7e, 0xd0, 0x7e, 0x1e, 0x07, 0xad, 0x4d,
-	0xcc, 0x8f, 0x79, 0xd2, 0xbc, 0xd6, 0xa6, 0xa2, 0x37, 0x61, 0x4d, 0xb6, 0x27, 0x3e, 0x1a, 0xa8,
-	0x38, 0x2d, 0x1a, 0x52, 0xa9, 0x0c, 0x1b, 0x22, 0x59, 0x02, 0x2f, 0xc8, 0x88, 0x5f, 0x94, 0x16,
-	0xde, 0xcd, 0xc2, 0xd2, 0xf2, 0x57, 0x05, 0xde, 0xac, 0x53, 0xe2, 0x8d, 0x5c, 0x3a, 0xa0, 0xb6,
-	0xdf, 0x60, 0x53, 0x61, 0xe0, 0xba, 0x7b, 0x90, 0xfe, 0x72, 0xaf, 0x61, 0xc9, 0xf3, 0x55, 0x0b,
-	0x86, 0x2f, 0x14, 0xb8, 0x1d, 0x31, 0xec, 0x42, 0x02, 0xac, 0x66, 0x9a, 0x01, 0xd9, 0xc1, 0x02,
-	0x8a, 0x1b, 0xa8, 0xe2, 0x28, 0x69, 0x61, 0x7c, 0xe2, 0x55, 0x1a, 0x9f, 0x7c, 0x59, 0xe3, 0x7f,
-	0x13, 0x87, 0x3b, 0xcb, 0xc6, 0x2f, 0x27, 0xc5, 0xab, 0x36, 0x3f, 0x12, 0x8e, 0x89, 0x68, 0x38,
-	0x2e, 0xfc, 0x92, 0x7c, 0x95, 0x7e, 0x49, 0xbd, 0xac, 0x5f, 0xfe, 0xa3, 0xc0, 0x66, 0xc4, 0x2f,
-	0x07, 0x16, 0xed, 0x77, 0xbf, 0x2e, 0x31, 0xf1, 0xdf, 0xc4, 0x52, 0x42, 0x04, 0xb6, 0xcb, 0xfa,
-	0x40, 0x20, 0xdd, 0xe3, 0x14, 0xd9, 0x13, 0xf7, 0xae, 0x54, 0xf0, 0x7f, 0x71, 0xca, 0x75, 0xea,
-	0x79, 0xc4, 0xa4, 0x9c, 0x1a, 0xfe, 0x6b, 0x72, 0x16, 0xfd, 0xd7, 0x0a, 0xe4, 0xa2, 0xc7, 0x97,
-	0xf4, 0xc9, 0xb6, 0x7c, 0x85, 0x10, 0x83, 0xeb, 0xf7, 0x5e, 0xf2, 0x0e, 0x7c, 0xbb, 0x78, 0x91,
-	0x40, 0x6f, 0x81, 0x1a, 0x0e, 0x59, 0xfc, 0x63, 0x68, 0x78, 0x41, 0x28, 0x3d, 0x57, 0x40, 0x0d,
-	0x25, 0xd0, 0xdd, 0xc5, 0x20, 0xc4, 0x27, 0x90, 0xf0, 0x44, 0x4c, 0x42, 0x6f, 0x47, 0x27, 0x21,
-	0x3e, 0xe6, 0x84, 0x0c, 0xc1, 0x28, 0xf4, 0xce, 0xd2, 0x28, 0xc4, 0x1f, 0x03, 0x42, 0x9e, 0x70,
-	0x16, 0x2a, 0x86, 0x93, 0x8e, 0x1c, 0x85, 0x42, 0x16, 0x51, 0xbd, 0x99, 0xa2, 0x60, 0x58, 0x4a,
-	0x5e, 0x50, 0x14, 0x4c, 0x4b, 0xef, 0x82, 0x7a, 0xda, 0xd8, 0xaf, 0x1e, 0xd4, 0x98, 0x26, 0xf9,
-	0x72, 0x11, 0xd1, 0xd4, 0xa5, 0x3d, 0xcb, 0xa6, 0x5d, 0x39, 0x34, 0xfd, 0x31, 0x01, 0x3a, 0x1b,
-	0xf5, 0x7f, 0x60, 0xd9, 0x5d, 0xe7, 0xd3, 0xc5, 0xab, 0xd9, 0x6b, 0xfd, 0x8c, 0x69, 0x40, 0x56,
-	0xd8, 0x5b, 0x7d, 0x42, 0x5d, 0xd1, 0x29, 0x13, 0x38, 0x4a, 0x62, 0x6d, 0xb1, 0xd9, 0xeb, 0x79,
-	0xd4, 0xe7, 0xff, 0x9a, 0x09, 0x2c, 0x77, 0xcb, 0xef, 0x90, 0x29, 0x9e, 0x11, 0x2f, 0xf1, 0x0e,
-	0xf9, 0x00, 0xd2, 0x9f, 0x72, 0x65, 0xf2, 0x51, 0xe5, 0x9d, 0x2b, 0x21, 0xc4, 0xbd, 0xb0, 0x14,
-	0x29, 0xfd, 0x42, 0x81, 0xb4, 0x20, 0xa1, 0x07, 0x90, 0xa2, 0xdc, 0x02, 0xf1, 0x5d, 0xde, 0xbd,
-	0x12, 0x66, 0x7f, 0xe4, 0x12, 0xf6, 0x77, 0x89, 0x85, 0x0c, 0x7a, 0x08, 0x69, 0x47, 0x98, 0x18,
-	0x5f, 0x45, 0x5a, 0x0a, 0x95, 0xda, 0x90, 0x09, 0x68, 0x6c, 0xe2, 0xb4, 0x3d, 0x7a, 0xee, 0x05,
-	0x13, 0x27, 0xdf, 0x30, 0x1f, 0x0e, 0x1c, 0xdb, 0x7f, 0xec, 0xc9, 0xa1, 0x53, 0xee, 0xd8, 0x64,
-	0x6e, 0x33, 0x3f, 0x58, 0x4f, 0xc4, 0x27, 0xcc, 0xe0, 0x70, 0x5f, 0x79, 0xff, 0xd9, 0x3f, 0x0a,
-	0xb1, 0x67, 0xf3, 0x82, 0xf2, 0xf9, 0xbc, 0xa0, 0xfc, 0x7d, 0x5e, 0x50, 0x7e, 0xf9, 0xbc, 0x10,
-	0xfb, 0xfc, 0x79, 0x21, 0xf6, 0xb7, 0xe7, 0x85, 0xd8, 0x8f, 0xf9, 0x2f, 0x2c, 0x4b, 0x5d, 0xef,
-	0x2c, 0xcd, 0x63, 0xef, 0x83, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x73, 0xf6, 0x75, 0xc6, 0xde,
-	0x18, 0x00, 0x00,
+	// 1981 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
+	0xf1, 0xe7, 0xf0, 0x25, 0xb2, 0x48, 0xd1, 0xe3, 0xb6, 0xd6, 0x96, 0xc7, 0x6b, 0x72, 0x4c, 0xff,
+	0x77, 0x2d, 0xe0, 0xef, 0xd0, 0x80, 0x76, 0x03, 0x2c, 0xec, 0x18, 0x08, 0x69, 0x51, 0x12, 0x63,
+	0x91, 0xd4, 0x36, 0x29, 0xe7, 0x71, 0x61, 0xda, 0x62, 0x73, 0x3c, 0x58, 0x72, 0x86, 0x3b, 0x33,
+	0xd4, 0x9a, 0x40, 0x2e, 0x01, 0x72, 0x58, 0xf0, 0x10, 0x24, 0x40, 0x72, 0x09, 0xc0, 0x53, 0x72,
+	0xcb, 0x21, 0xb7, 0x7c, 0x06, 0x07, 0xc8, 0x61, 0x4f, 0x41, 0x4e, 0x44, 0x42, 0x03, 0x01, 0xf2,
+	0x11, 0xb2, 0xb9, 0x04, 0xfd, 0x98, 0xe1, 0x50, 0x66, 0x64, 0xc9, 0xf0, 0x61, 0xe1, 0xdc, 0xba,
+	0xab, 0xab, 0x7e, 0xd5, 0x55, 0x5d, 0x5d, 0x55, 0xdd, 0xb0, 0xe1, 0x7a, 0xb6, 0x43, 0x0c, 0xda,
+	0x39, 0xb6, 0x07, 0x03, 0xdb, 0x2a, 0x0d, 0x1d, 0xdb, 0xb3, 0xd1, 0x0d, 0xd3, 0xea, 0xf5, 0x47,
+	0xcf, 0xbb, 0xc4, 0x23, 0xa5, 0x61, 0x9f, 0x78, 0x3d, 0xdb, 0x19, 0x94, 0x24, 0xa7, 0xb6, 0x61,
+	0xd8, 0x86, 0xcd, 0xf9, 0xee, 0xb1, 0x91, 0x10, 0xd1, 0xae, 0x1b, 0xb6, 0x6d, 0xf4, 0xe9, 0x3d,
+	0x3e, 0x7b, 0x3a, 0xea, 0xdd, 0x23, 0xd6, 0x58, 0x2e, 0x5d, 0x1a, 0x3a, 0xb4, 0x6b, 0x1e, 0x13,
+	0x8f, 0x0a, 0x42, 0xf1, 0x9f, 0x0a, 0x5c, 0xc6, 0x94, 0x74, 0x77, 0xcd, 0xbe, 0x47, 0x1d, 0x4c,
+	0x3f, 0x1f, 0x51, 0xd7, 0x43, 0x55, 0xc8, 0x38, 0x94, 0x74, 0x3b, 0xae, 0x3d, 0x72, 0x8e, 0xe9,
+	0xa6, 0xa2, 0x2b, 0x5b, 0x99, 0xed, 0x8d, 0x92, 0xc0, 0x2d, 0xf9, 0xb8, 0xa5, 0xb2, 0x35, 0xae,
+	0xe4, 0xe6, 0xb3, 0x02, 0x30, 0x84, 0x16, 0xe7, 0xc5, 0xe0, 0x04, 0x63, 0xb4, 0x07, 0x09, 0x87,
+	0x58, 0x06, 0xdd, 0x8c, 0x72, 0x80, 0xff, 0x2f, 0x9d, 0x61, 0x4b, 0xa9, 0x6d, 0x0e, 0xa8, 0xeb,
+	0x91, 0xc1, 0x10, 0x33, 0x91, 0x4a, 0xfc, 0xc5, 0xac, 0x10, 0xc1, 0x42, 0x1e, 0xed, 0x40, 0x3a,
+	0xd8, 0xf8, 0x66, 0x8c, 0x83, 0x7d, 0x78, 0x26, 0xd8, 0xa1, 0xcf, 0x8d, 0x17, 0x82, 0xc5, 0x3f,
+	0x27, 0x40, 0x65, 0x3b, 0xdd, 0x73, 0xec, 0xd1, 0xf0, 0x9d, 0x36, 0x15, 0xdd, 0x05, 0x30, 0x98,
+	0x95, 0x9d, 0xcf, 0xe8, 0xd8, 0xdd, 0x8c, 0xeb, 0xb1, 0xad, 0x74, 0x65, 0x7d, 0x3e, 0x2b, 0xa4,
+	0xb9, 0xed, 0x8f, 0xe9, 0xd8, 0xc5, 0x69, 0xc3, 0x1f, 0xa2, 0x1a, 0x24, 0xf8, 0x64, 0x33, 0xa1,
+	0x2b, 0x5b, 0xb9, 0xed, 0x8f, 0xce, 0xd4, 0x77, 0xda, 0x83, 0x25, 0x31, 0x11, 0x08, 0x6c, 0xfb,
+	0xc4, 0x30, 0x1c, 0x6a, 0xb0, 0xed, 0x27, 0xcf, 0xb1, 0xfd, 0xb2, 0xcf, 0x8d, 0x17, 0x82, 0xe8,
+	0x2e, 0x24, 0x9e, 0x99, 0x96, 0xe7, 0x6e, 0xae, 0xe9, 0xca, 0xd6, 0x5a, 0xe5, 0xea, 0x7c, 0x56,
+	0x48, 0xec, 0x33, 0xc2, 0xd7, 0xb3, 0x42, 0x9a, 0x0d, 0x76, 0xfb, 0xc4, 0x70, 0xb1, 0x60, 0x2a,
+	0xee, 0x41, 0x82, 0xef, 0x01, 0xdd, 0x04, 0xd8, 0xc3, 0xcd, 0xa3, 0xc3, 0x4e, 0xa3, 0xd9, 0xa8,
+	0xaa, 0x11, 0x6d, 0x7d, 0x32, 0xd5, 0x85, 0xc5, 0x0d, 0xdb, 0xa2, 0xe8, 0x3a, 0xa4, 0xc4, 0x72,
+	0xe5, 0x87, 0x6a, 0x54, 0xcb, 0x4c, 0xa6, 0xfa, 0x1a, 0x5f, 0xac, 0x8c, 0xb5, 0xf8, 0x97, 0xbf,
+	0xcd, 0x47, 0x8a, 0xbf, 0x57, 0x60, 0x81, 0x8e, 0x6e, 0x40, 0x7a, 0xbf, 0xd6, 0x68, 0xfb, 0x60,
+	0xd9, 0xc9, 0x54, 0x4f, 0xb1, 0x55, 0x8e, 0xf5, 0x7f, 0x90, 0x93, 0x8b, 0x9d, 0xc3, 0x66, 0xad,
+	0xd1, 0x6e, 0xa9, 0x8a, 0xa6, 0x4e, 0xa6, 0x7a, 0x56, 0x70, 0x1c, 0xda, 0x6c, 0x67, 0x61, 0xae,
+	0x56, 0x15, 0xd7, 0xaa, 0x2d, 0x35, 0x1a, 0xe6, 0x6a, 0x51, 0xc7, 0xa4, 0x2e, 0xba, 0x07, 0x1b,
+	0x9c, 0xab, 0xf5, 0x68, 0xbf, 0x5a, 0x2f, 0x77, 0xca, 0x07, 0x07, 0x9d, 0x76, 0xad, 0x5e, 0x55,
+	0xe3, 0xda, 0x7b, 0x93, 0xa9, 0x7e, 0x99, 0xf1, 0xb6, 0x8e, 0x9f, 0xd1, 0x01, 0x29, 0xf7, 0xfb,
+	0x2c, 0x74, 0xe4, 0x6e, 0x7f, 0x17, 0x87, 0x74, 0xe0, 0x3d, 0xb4, 0x0f, 0x71, 0x6f, 0x3c, 0x14,
+	0x01, 0x9c, 0xdb, 0xfe, 0xf8, 0x7c, 0x3e, 0x5f, 0x8c, 0xda, 0xe3, 0x21, 0xc5, 0x1c, 0x01, 0x69,
+	0x90, 0xfa, 0x7c, 0x44, 0x2c, 0xcf, 0xec, 0x8b, 0x68, 0x56, 0x70, 0x30, 0x47, 0x3a, 0x64, 0x8e,
+	0xed, 0xc1, 0xd0, 0xa1, 0xae, 0x6b, 0xda, 0x16, 0x8f, 0x4f, 0x05, 0x87, 0x49, 0xc5, 0x9f, 0xc7,
+	0x60, 0x7d, 0x09, 0x15, 0x15, 0x20, 0x2e, 0x5d, 0xc8, 0xcd, 0x59, 0x5a, 0xe4, 0xbe, 0xbc, 0x09,
+	0xb1, 0xd6, 0x51, 0x5d, 0x55, 0xb4, 0x8d, 0xc9, 0x54, 0x57, 0x97, 0xd6, 0x5b, 0xa3, 0x01, 0xba,
+	0x05, 0x89, 0x47, 0xcd, 0xa3, 0x46, 0x5b, 0x8d, 0x6a, 0x57, 0x27, 0x53, 0x1d, 0x2d, 0x31, 0x3c,
+	0xb2, 0x47, 0x96, 0xc7, 0x10, 0xea, 0xb5, 0x86, 0x1a, 0x5b, 0x81, 0x50, 0x37, 0x2d, 0xbe, 0x5c,
+	0xfe, 0x81, 0x1a, 0x5f, 0xb5, 0x4c, 0x9e, 0x33, 0x05, 0xbb, 0x35, 0xdc, 0x6a, 0xab, 0x89, 0x15,
+	0x0a, 0x76, 0x4d, 0xc7, 0xf5, 0x98, 0x0d, 0x07, 0xe5, 0x56, 0x5b, 0x4d, 0xae, 0xb0, 0xe1, 0x80,
+	0x08, 0x86, 0x7a, 0xb5, 0xdc, 0x50, 0xd7, 0x56, 0x30, 0xd4, 0x29, 0xb1, 0xd0, 0x6d, 0x48, 0xb6,
+	0x0e, 0x71, 0xb5, 0xbc, 0xa3, 0xa6, 0xb4, 0x6b, 0x93, 0xa9, 0x7e, 0x65, 0xd9, 0xce, 0x21, 0xcb,
+	0x25, 0x9c, 0xa9, 0xbd, 0xb3, 0x53, 0x7d, 0xa2, 0xa6, 0x57, 0x31, 0x79, 0xdd, 0x2e, 0x3d, 0x41,
+	0x77, 0x20, 0xf5, 0xe9, 0x51, 0xb9, 0xd1, 0xae, 0x1d, 0x54, 0x55, 0xd0, 0xae, 0x4f, 0xa6, 0xfa,
+	0x7b, 0x4b, 0x6c, 0x9f, 0xca, 0xc3, 0x92, 0x61, 0xf2, 0x2d, 0x88, 0xb5, 0x89, 0x81, 0x54, 0x88,
+	0x7d, 0x46, 0xc7, 0x3c, 0x3c, 0xb2, 0x98, 0x0d, 0xd1, 0x06, 0x24, 0x4e, 0x48, 0x7f, 0x24, 0x0e,
+	0x39, 0x8b, 0xc5, 0xa4, 0xf8, 0xcb, 0x1c, 0x64, 0xd9, 0x15, 0xc7, 0xd4, 0x1d, 0xda, 0x96, 0x4b,
+	0x51, 0x1d, 0x92, 0x3d, 0x87, 0x0c, 0xa8, 0xbb, 0xa9, 0xe8, 0xb1, 0xad, 0xcc, 0xf6, 0xbd, 0xd7,
+	0x66, 0x07, 0x5f, 0xb4, 0xb4, 0xcb, 0xe4, 0x64, 0x7a, 0x93, 0x20, 0xda, 0x97, 0x49, 0x48, 0x70,
+	0x3a, 0x3a, 0xf0, 0xb3, 0xce, 0x1a, 0x4f, 0x13, 0x1f, 0x9f, 0x1f, 0x97, 0xdf, 0x5a, 0x0e, 0xb2,
+	0x1f, 0xf1, 0x13, 0x4f, 0x13, 0x92, 0x2e, 0xbf, 0x4e, 0x32, 0x85, 0x7f, 0xfb, 0xfc, 0x70, 0xe2,
+	0x1a, 0xfa, 0x78, 0x12, 0x06, 0x0d, 0x21, 0xdb, 0xeb, 0xdb, 0xc4, 0xeb, 0x0c, 0xf9, 0x5d, 0x96,
+	0x89, 0xfd, 0xfe, 0x05, 0xac, 0x67, 0xd2, 0x22, 0x11, 0x08, 0x47, 0x5c, 0x9a, 0xcf, 0x0a, 0x99,
+	0x10, 0x75, 0x3f, 0x82, 0x33, 0xbd, 0xc5, 0x14, 0x3d, 0x87, 0x9c, 0x69, 0x79, 0xd4, 0xa0, 0x8e,
+	0xaf, 0x53, 0xe4, 0xff, 0xef, 0x9c, 0x5f, 0x67, 0x4d, 0xc8, 0x87, 0xb5, 0x5e, 0x9e, 0xcf, 0x0a,
+	0xeb, 0x4b, 0xf4, 0xfd, 0x08, 0x5e, 0x37, 0xc3, 0x04, 0xf4, 0x13, 0xb8, 0x34, 0xb2, 0x5c, 0xd3,
+	0xb0, 0x68, 0xd7, 0x57, 0x1d, 0xe7, 0xaa, 0x1f, 0x9e, 0x5f, 0xf5, 0x91, 0x04, 0x08, 0xeb, 0x46,
+	0xf3, 0x59, 0x21, 0xb7, 0xbc, 0xb0, 0x1f, 0xc1, 0xb9, 0xd1, 0x12, 0x85, 0xd9, 0xfd, 0xd4, 0xb6,
+	0xfb, 0x94, 0x58, 0xbe, 0xf2, 0xc4, 0x45, 0xed, 0xae, 0x08, 0xf9, 0x57, 0xec, 0x5e, 0xa2, 0x33,
+	0xbb, 0x9f, 0x86, 0x09, 0xc8, 0x83, 0x75, 0xd7, 0x73, 0x4c, 0xcb, 0xf0, 0x15, 0x8b, 0x8a, 0xf5,
+	0xe0, 0x02, 0xb1, 0xc3, 0xc5, 0xc3, 0x7a, 0xd5, 0xf9, 0xac, 0x90, 0x0d, 0x93, 0xf7, 0x23, 0x38,
+	0xeb, 0x86, 0xe6, 0x95, 0x24, 0xc4, 0x19, 0xb2, 0xf6, 0x1c, 0x60, 0x11, 0xc9, 0xe8, 0x43, 0x48,
+	0x79, 0xc4, 0x10, 0x05, 0x9b, 0xdd, 0xb4, 0x6c, 0x25, 0x33, 0x9f, 0x15, 0xd6, 0xda, 0xc4, 0xe0,
+	0xe5, 0x7a, 0xcd, 0x13, 0x03, 0x54, 0x01, 0x34, 0x24, 0x8e, 0x67, 0x7a, 0xa6, 0x6d, 0x31, 0xee,
+	0xce, 0x09, 0xe9, 0xb3, 0xe8, 0x64, 0x12, 0x1b, 0xf3, 0x59, 0x41, 0x3d, 0xf4, 0x57, 0x1f, 0xd3,
+	0xf1, 0x13, 0xd2, 0x77, 0xb1, 0x3a, 0x3c, 0x45, 0xd1, 0x7e, 0xa3, 0x40, 0x26, 0x14, 0xf5, 0xe8,
+	0x3e, 0xc4, 0x3d, 0x62, 0xf8, 0x37, 0x5c, 0x3f, 0xbb, 0x79, 0x21, 0x86, 0xbc, 0xd2, 0x5c, 0x06,
+	0x35, 0x21, 0xcd, 0x18, 0x3b, 0xbc, 0xfa, 0x44, 0x79, 0xf5, 0xd9, 0x3e, 0xbf, 0xff, 0x76, 0x88,
+	0x47, 0x78, 0xed, 0x49, 0x75, 0xe5, 0x48, 0xfb, 0x1e, 0xa8, 0xa7, 0xaf, 0x0e, 0xca, 0x03, 0x78,
+	0x7e, 0xd3, 0x24, 0xb6, 0xa9, 0xe2, 0x10, 0x05, 0x5d, 0x85, 0x24, 0x4f, 0x5f, 0xc2, 0x11, 0x0a,
+	0x96, 0x33, 0xed, 0x00, 0xd0, 0xab, 0x57, 0xe2, 0x82, 0x68, 0xb1, 0x00, 0xad, 0x0e, 0x57, 0x56,
+	0x44, 0xf9, 0x05, 0xe1, 0xe2, 0xe1, 0xcd, 0xbd, 0x1a, 0xb7, 0x17, 0x44, 0x4b, 0x05, 0x68, 0x8f,
+	0xe1, 0xf2, 0x2b, 0xc1, 0x78, 0x41, 0xb0, 0xb4, 0x0f, 0x56, 0x6c, 0x41, 0x9a, 0x03, 0xc8, 0x02,
+	0x9e, 0x94, 0xdd, 0x4b, 0x44, 0xbb, 0x32, 0x99, 0xea, 0x97, 0x82, 0x25, 0xd9, 0xc0, 0x14, 0x20,
+	0x19, 0x34, 0x41, 0xcb, 0x0c, 0x62, 0x2f, 0xb2, 0x12, 0xfd, 0x51, 0x81, 0x94, 0x7f, 0xde, 0xe8,
+	0x7d, 0x48, 0xec, 0x1e, 0x34, 0xcb, 0x6d, 0x35, 0xa2, 0x5d, 0x9e, 0x4c, 0xf5, 0x75, 0x7f, 0x81,
+	0x1f, 0x3d, 0xd2, 0x61, 0xad, 0xd6, 0x68, 0x57, 0xf7, 0xaa, 0xd8, 0x87, 0xf4, 0xd7, 0xe5, 0x71,
+	0xa2, 0x22, 0xa4, 0x8e, 0x1a, 0xad, 0xda, 0x5e, 0xa3, 0xba, 0xa3, 0x46, 0x45, 0x61, 0xf7, 0x59,
+	0xfc, 0x33, 0x62, 0x28, 0x95, 0x66, 0xf3, 0x80, 0xd5, 0xe5, 0xd8, 0x32, 0x8a, 0xf4, 0x3b, 0xca,
+	0xb3, 0x82, 0x8b, 0x6b, 0x8d, 0x3d, 0x35, 0xae, 0xa1, 0xc9, 0x54, 0xcf, 0xf9, 0x0c, 0xc2, 0x95,
+	0x72, 0xe3, 0x5b, 0x00, 0x8f, 0xc8, 0x90, 0x3c, 0x35, 0xfb, 0xa6, 0x37, 0x66, 0xfd, 0x51, 0x8f,
+	0x12, 0x6f, 0xe4, 0xc8, 0x92, 0x98, 0xc6, 0xc1, 0xbc, 0xf8, 0x27, 0x05, 0x36, 0x02, 0x56, 0x93,
+	0xba, 0x41, 0x15, 0x6d, 0x42, 0xfc, 0x98, 0x0c, 0xfd, 0x1b, 0x76, 0x76, 0x82, 0x59, 0x05, 0xc0,
+	0x88, 0x6e, 0xd5, 0xf2, 0x9c, 0x31, 0xe6, 0x40, 0xda, 0x8f, 0x21, 0x1d, 0x90, 0xc2, 0xc5, 0x3d,
+	0x2d, 0x8a, 0xfb, 0xc3, 0x70, 0x71, 0xcf, 0x6c, 0xdf, 0x39, 0x9f, 0xc2, 0xb1, 0xec, 0x02, 0xee,
+	0x47, 0x3f, 0x51, 0x8a, 0x9f, 0x40, 0x6e, 0xf9, 0xa1, 0xc2, 0x3a, 0x06, 0xd7, 0x23, 0x8e, 0xc7,
+	0x15, 0xc5, 0xb0, 0x98, 0x30, 0xe5, 0xd4, 0xea, 0x72, 0x45, 0x31, 0xcc, 0x86, 0xc5, 0x7f, 0x28,
+	0x90, 0xf3, 0xf3, 0xd6, 0xe2, 0x99, 0xc5, 0xb2, 0xc5, 0xb9, 0x9f, 0x59, 0x6d, 0x62, 0xb8, 0xfe,
+	0x33, 0xcb, 0x0b, 0xc6, 0xdf, 0xb4, 0x17, 0xe5, 0x4f, 0xa3, 0xa0, 0xb6, 0x89, 0xf1, 0x84, 0x5f,
+	0x9a, 0x77, 0xda, 0x54, 0x74, 0x0d, 0xd6, 0x64, 0x79, 0xe2, 0xad, 0x41, 0x1a, 0x27, 0x45, 0x41,
+	0x2a, 0x96, 0x60, 0x43, 0x5c, 0x16, 0xdf, 0x0b, 0x32, 0xe2, 0x17, 0xa9, 0x85, 0x57, 0xb3, 0x20,
+	0xb5, 0xfc, 0x45, 0x81, 0x6b, 0x75, 0x4a, 0xdc, 0x91, 0x43, 0x07, 0xd4, 0xf2, 0x1a, 0x64, 0xb0,
+	0x70, 0xdd, 0x5d, 0x48, 0xbe, 0xde, 0x6b, 0x38, 0xe9, 0x7e, 0x13, 0x3d, 0x54, 0xfc, 0x5a, 0x81,
+	0xeb, 0x21, 0xc3, 0x4e, 0x5d, 0x80, 0x8b, 0x99, 0xa6, 0x43, 0x66, 0xb0, 0x80, 0xe2, 0x06, 0xa6,
+	0x71, 0x98, 0xb4, 0x30, 0x3e, 0xf6, 0x36, 0x8d, 0x8f, 0xbf, 0xa9, 0xf1, 0xbf, 0x8e, 0xc2, 0x8d,
+	0x65, 0xe3, 0x97, 0x2f, 0xc5, 0xdb, 0x36, 0x3f, 0x14, 0x8e, 0xb1, 0x70, 0x38, 0x2e, 0xfc, 0x12,
+	0x7f, 0x9b, 0x7e, 0x49, 0xbc, 0xa9, 0x5f, 0xfe, 0xa5, 0xc0, 0x66, 0xc8, 0x2f, 0xbb, 0x26, 0xed,
+	0x77, 0xff, 0x57, 0x62, 0xe2, 0xdf, 0x31, 0xb8, 0xbe, 0xc2, 0x76, 0x99, 0x1f, 0x08, 0x24, 0x7b,
+	0x9c, 0x22, 0x6b, 0xe2, 0xa3, 0x33, 0x15, 0xfc, 0x57, 0x9c, 0x52, 0x9d, 0xba, 0x2e, 0x31, 0x28,
+	0xa7, 0x06, 0x6f, 0x4d, 0xce, 0xa2, 0xfd, 0x4a, 0x81, 0x6c, 0x78, 0x79, 0x45, 0x9d, 0x6c, 0xcb,
+	0x6f, 0x13, 0xd1, 0xb8, 0x7e, 0xf7, 0x0d, 0xf7, 0xc0, 0xa7, 0xa1, 0x2f, 0x94, 0xf7, 0x21, 0x1d,
+	0x34, 0x59, 0xfc, 0x30, 0x54, 0xbc, 0x20, 0x14, 0x5f, 0x2a, 0x90, 0x0e, 0x24, 0xd0, 0xcd, 0x45,
+	0x23, 0xc4, 0x3b, 0x90, 0x60, 0x45, 0x74, 0x42, 0xb7, 0xc2, 0x9d, 0x10, 0x6f, 0x73, 0x02, 0x06,
+	0xbf, 0x15, 0xba, 0xbd, 0xd4, 0x0a, 0xf1, 0xff, 0x87, 0x80, 0x27, 0xe8, 0x85, 0x0a, 0x41, 0xa7,
+	0x23, 0x5b, 0xa1, 0x80, 0x45, 0x64, 0x6f, 0x74, 0x6b, 0xd1, 0x2c, 0xc5, 0x4f, 0x29, 0xf2, 0xbb,
+	0xa5, 0x0f, 0x20, 0x7d, 0xd4, 0xd8, 0xa9, 0xee, 0xd6, 0x98, 0x26, 0xf9, 0x59, 0x12, 0xd2, 0xd4,
+	0xa5, 0x3d, 0xd3, 0xa2, 0x5d, 0xd9, 0x34, 0xfd, 0x21, 0x06, 0x1a, 0x6b, 0xf5, 0xbf, 0x6f, 0x5a,
+	0x5d, 0xfb, 0x8b, 0xc5, 0x37, 0xdf, 0x3b, 0xfd, 0xef, 0xaa, 0x43, 0x46, 0xd8, 0x5b, 0x3d, 0xa1,
+	0x8e, 0xa8, 0x94, 0x31, 0x1c, 0x26, 0xb1, 0xb2, 0xd8, 0xec, 0xf5, 0x5c, 0xea, 0xf1, 0xb7, 0x66,
+	0x0c, 0xcb, 0xd9, 0xf2, 0xc7, 0x69, 0x42, 0x8f, 0xbd, 0x56, 0xff, 0xca, 0x8f, 0xd3, 0x07, 0x90,
+	0xfc, 0x82, 0x2b, 0x93, 0x9f, 0x2a, 0xb7, 0xcf, 0x84, 0x10, 0xfb, 0xc2, 0x52, 0xa4, 0xf8, 0x33,
+	0x05, 0x92, 0x82, 0x84, 0x1e, 0x40, 0x82, 0x72, 0x0b, 0xc4, 0xb9, 0x7c, 0x70, 0x26, 0xcc, 0xce,
+	0xc8, 0x21, 0xec, 0x75, 0x89, 0x85, 0x0c, 0x7a, 0x08, 0x49, 0x5b, 0x98, 0x18, 0xbd, 0x88, 0xb4,
+	0x14, 0x2a, 0xb6, 0x21, 0xe5, 0xd3, 0x58, 0xc7, 0x69, 0xb9, 0xf4, 0xd8, 0xf5, 0x3b, 0x4e, 0x3e,
+	0x61, 0x3e, 0x1c, 0xd8, 0x96, 0xf7, 0xcc, 0x95, 0x4d, 0xa7, 0x9c, 0xb1, 0xce, 0xdc, 0x62, 0x7e,
+	0x30, 0x4f, 0xc4, 0x11, 0xa6, 0x70, 0x30, 0xaf, 0xdc, 0x79, 0xf1, 0xf7, 0x7c, 0xe4, 0xc5, 0x3c,
+	0xaf, 0x7c, 0x35, 0xcf, 0x2b, 0x7f, 0x9b, 0xe7, 0x95, 0x5f, 0xbc, 0xcc, 0x47, 0xbe, 0x7a, 0x99,
+	0x8f, 0xfc, 0xf5, 0x65, 0x3e, 0xf2, 0x23, 0xfe, 0x84, 0x65, 0x57, 0xd7, 0x7d, 0x9a, 0xe4, 0xb1,
+	0xf7, 0xd1, 0x7f, 0x06, 0x00, 0x36, 0x9b, 0x6b, 0xb5, 0x8f, 0x19, 0x00, 0x00,
 }
 
 func (m *ReadFilterRequest) Marshal() (dAtA []byte, err error) {
@@ -1775,6 +1795,18 @@ func (m *Aggregate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Compression != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Compression))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.Quantile != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Quantile))))
+		i--
+		dAtA[i] = 0x11
+	}
 	if m.Type != 0 {
 		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Type))
 		i--
@@ -3206,6 +3238,12 @@ func (m *Aggregate) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovStorageCommon(uint64(m.Type))
 	}
+	if m.Quantile != 0 {
+		n += 9
+	}
+	if m.Compression != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -4234,6 +4272,28 @@ func (m *Aggregate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quantile", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Quantile = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Compression = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorageCommon(dAtA[iNdEx:])
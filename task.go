@@ -42,7 +42,9 @@ type Task struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Timezone        string                 `json:"timezone,omitempty"`
 	Offset          time.Duration          `json:"offset,omitempty"`
+	Upstream        []ID                   `json:"upstream,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	LatestSuccess   time.Time              `json:"latestSuccess,omitempty"`
@@ -58,7 +60,8 @@ type Task struct {
 // If the cron option was specified, it is returned.
 // If the every option was specified, it is converted into a cron string using "@every".
 // Otherwise, the empty string is returned.
-// The value of the offset option is not considered.
+// The value of the offset option is not considered, nor is the time zone,
+// which the schedule is evaluated in.
 func (t *Task) EffectiveCron() string {
 	if t.Cron != "" {
 		return t.Cron
@@ -141,6 +144,7 @@ type TaskCreate struct {
 	OrganizationID ID                     `json:"orgID,omitempty"`
 	Organization   string                 `json:"org,omitempty"`
 	OwnerID        ID                     `json:"-"`
+	Upstream       []ID                   `json:"upstream,omitempty"`
 	Metadata       map[string]interface{} `json:"-"` // not to be set through a web request but rather used by a http service using tasks backend.
 }
 
@@ -162,6 +166,9 @@ type TaskUpdate struct {
 	Status      *string `json:"status,omitempty"`
 	Description *string `json:"description,omitempty"`
 
+	// Upstream replaces the upstream tasks, if not nil.
+	Upstream *[]ID `json:"upstream,omitempty"`
+
 	// LatestCompleted us to set latest completed on startup to skip task catchup
 	LatestCompleted *time.Time             `json:"-"`
 	LatestScheduled *time.Time             `json:"-"`
@@ -182,10 +189,14 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 		Status      *string `json:"status,omitempty"`
 		Name        string  `json:"name,omitempty"`
 		Description *string `json:"description,omitempty"`
+		Upstream    *[]ID   `json:"upstream,omitempty"`
 
 		// Cron is a cron style time schedule that can be used in place of Every.
 		Cron string `json:"cron,omitempty"`
 
+		// Timezone is the IANA time zone Cron is evaluated in.
+		Timezone string `json:"timezone,omitempty"`
+
 		// Every represents a fixed period to repeat execution.
 		// It gets marshalled from a string duration, i.e.: "10s" is 10 seconds
 		Every options.Duration `json:"every,omitempty"`
@@ -204,7 +215,9 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 	}
 	t.Options.Name = jo.Name
 	t.Description = jo.Description
+	t.Upstream = jo.Upstream
 	t.Options.Cron = jo.Cron
+	t.Options.Timezone = jo.Timezone
 	t.Options.Every = jo.Every
 	if jo.Offset != nil {
 		offset := *jo.Offset
@@ -223,10 +236,14 @@ func (t *TaskUpdate) MarshalJSON() ([]byte, error) {
 		Status      *string `json:"status,omitempty"`
 		Name        string  `json:"name,omitempty"`
 		Description *string `json:"description,omitempty"`
+		Upstream    *[]ID   `json:"upstream,omitempty"`
 
 		// Cron is a cron style time schedule that can be used in place of Every.
 		Cron string `json:"cron,omitempty"`
 
+		// Timezone is the IANA time zone Cron is evaluated in.
+		Timezone string `json:"timezone,omitempty"`
+
 		// Every represents a fixed period to repeat execution.
 		Every options.Duration `json:"every,omitempty"`
 
@@ -239,8 +256,10 @@ func (t *TaskUpdate) MarshalJSON() ([]byte, error) {
 	}{}
 	jo.Name = t.Options.Name
 	jo.Cron = t.Options.Cron
+	jo.Timezone = t.Options.Timezone
 	jo.Every = t.Options.Every
 	jo.Description = t.Description
+	jo.Upstream = t.Upstream
 	if t.Options.Offset != nil {
 		offset := *t.Options.Offset
 		jo.Offset = &offset
@@ -256,6 +275,8 @@ func (t *TaskUpdate) Validate() error {
 	switch {
 	case !t.Options.Every.IsZero() && t.Options.Cron != "":
 		return errors.New("cannot specify both every and cron")
+	case !t.Options.Every.IsZero() && t.Options.Timezone != "":
+		return errors.New("cannot specify timezone with every")
 	case !t.Options.Every.IsZero():
 		if _, err := options.ParseSignedDuration(t.Options.Every.String()); err != nil {
 			return fmt.Errorf("every: %s is invalid", err)
@@ -264,7 +285,7 @@ func (t *TaskUpdate) Validate() error {
 		if _, err := time.ParseDuration(t.Options.Offset.String()); err != nil {
 			return fmt.Errorf("offset: %s, %s is invalid, the largest unit supported is h", t.Options.Offset.String(), err)
 		}
-	case t.Flux == nil && t.Status == nil && t.Options.IsZero():
+	case t.Flux == nil && t.Status == nil && t.Upstream == nil && t.Options.IsZero():
 		return errors.New("cannot update task without content")
 	case t.Status != nil && *t.Status != TaskStatusActive && *t.Status != TaskStatusInactive:
 		return fmt.Errorf("invalid task status: %q", *t.Status)
@@ -328,6 +349,9 @@ func (t *TaskUpdate) updateFlux(parser FluxLanguageService, oldFlux string) erro
 	if t.Options.Cron != "" {
 		op["cron"] = &ast.StringLiteral{Value: t.Options.Cron}
 	}
+	if t.Options.Timezone != "" {
+		op["timezone"] = &ast.StringLiteral{Value: t.Options.Timezone}
+	}
 	if t.Options.Offset != nil {
 		if !t.Options.Offset.IsZero() {
 			op["offset"] = &t.Options.Offset.Node
@@ -345,6 +369,10 @@ func (t *TaskUpdate) updateFlux(parser FluxLanguageService, oldFlux string) erro
 			if !ok {
 				return nil, fmt.Errorf("value is is %s, not an object expression", a.Init.Type())
 			}
+			// a time zone only applies to a cron schedule
+			if !t.Options.Every.IsZero() {
+				edit.DeleteProperty(obj, "timezone")
+			}
 			// modify in the keys and values that already are in the ast
 			for i, p := range obj.Properties {
 				k := p.Key.Key()
@@ -371,6 +399,11 @@ func (t *TaskUpdate) updateFlux(parser FluxLanguageService, oldFlux string) erro
 						p.Value = cron
 						p.Key = &ast.Identifier{Name: "cron"}
 					}
+				case "timezone":
+					if tz, ok := op["timezone"]; ok && t.Options.Timezone != "" {
+						delete(op, "timezone")
+						p.Value = tz
+					}
 				case "cron":
 					if cron, ok := op["cron"]; ok && t.Options.Cron != "" {
 						delete(op, "cron")
@@ -437,6 +470,7 @@ func (t *TaskUpdate) updateFluxAST(parser FluxLanguageService, oldFlux string) e
 	if !t.Options.Every.IsZero() {
 		edit.SetProperty(optsExpr, "every", t.Options.Every.Node.Copy().(*ast.DurationLiteral))
 		edit.DeleteProperty(optsExpr, "cron")
+		edit.DeleteProperty(optsExpr, "timezone")
 	}
 	if t.Options.Cron != "" {
 		edit.SetProperty(optsExpr, "cron", &ast.StringLiteral{
@@ -444,6 +478,11 @@ func (t *TaskUpdate) updateFluxAST(parser FluxLanguageService, oldFlux string) e
 		})
 		edit.DeleteProperty(optsExpr, "every")
 	}
+	if t.Options.Timezone != "" {
+		edit.SetProperty(optsExpr, "timezone", &ast.StringLiteral{
+			Value: t.Options.Timezone,
+		})
+	}
 	if t.Options.Offset != nil {
 		if !t.Options.Offset.IsZero() {
 			edit.SetProperty(optsExpr, "offset", t.Options.Offset.Node.Copy().(*ast.DurationLiteral))
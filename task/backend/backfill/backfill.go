@@ -0,0 +1,298 @@
+// Package backfill runs a task for every time its schedule triggered in a
+// time range, to rebuild the data the task would have written.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/snowflake"
+	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
+	"github.com/influxdata/influxdb/v2/task/options"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.BackfillService = (*Service)(nil)
+
+// Service runs backfills by forcing the runs of a task. The backfills are kept
+// in memory, so they don't outlive the process.
+//
+// The task service is expected to return from ForceRun once the run is done,
+// as the coordinating task service does, so a backfill only has as many runs
+// in progress as it calls ForceRun concurrently.
+type Service struct {
+	log  *zap.Logger
+	ts   influxdb.TaskService
+	lang influxdb.FluxLanguageService
+
+	IDGenerator influxdb.IDGenerator
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	backfills map[influxdb.ID]*backfill
+}
+
+// NewService returns a service which forces the runs of backfills with ts,
+// and reads the concurrency of tasks with lang.
+func NewService(log *zap.Logger, ts influxdb.TaskService, lang influxdb.FluxLanguageService) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		log:         log,
+		ts:          ts,
+		lang:        lang,
+		IDGenerator: snowflake.NewDefaultIDGenerator(),
+		ctx:         ctx,
+		cancel:      cancel,
+		backfills:   make(map[influxdb.ID]*backfill),
+	}
+}
+
+// Close cancels the running backfills and waits for them to stop.
+func (s *Service) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// backfill is the state of a backfill. Its fields are guarded by the mutex of
+// the service.
+type backfill struct {
+	influxdb.Backfill
+	cancel context.CancelFunc
+}
+
+// CreateBackfill starts a backfill of the task for the runs scheduled from
+// start until end.
+func (s *Service) CreateBackfill(ctx context.Context, taskID influxdb.ID, start, end time.Time) (*influxdb.Backfill, error) {
+	if !end.After(start) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "backfill end must be later than start",
+		}
+	}
+
+	t, err := s.ts.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := ScheduledTimes(t, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := 1
+	if o, err := options.FromScriptAST(s.lang, t.Flux); err == nil && o.Concurrency != nil && *o.Concurrency > 1 {
+		concurrency = int(*o.Concurrency)
+	}
+	if concurrency > len(times) {
+		concurrency = len(times)
+	}
+
+	bctx, cancel := context.WithCancel(s.ctx)
+	b := &backfill{
+		Backfill: influxdb.Backfill{
+			ID:        s.IDGenerator.ID(),
+			TaskID:    taskID,
+			Start:     start.UTC(),
+			End:       end.UTC(),
+			Status:    influxdb.BackfillRunning,
+			TotalRuns: len(times),
+			CreatedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	s.mu.Lock()
+	s.backfills[b.ID] = b
+	created := b.Backfill
+	s.mu.Unlock()
+
+	s.log.Info("Starting backfill",
+		zap.String("taskID", taskID.String()),
+		zap.String("backfillID", b.ID.String()),
+		zap.Int("runs", len(times)),
+		zap.Int("concurrency", concurrency))
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(bctx, b, times, concurrency)
+	}()
+	return &created, nil
+}
+
+// run forces the runs of the backfill, with up to concurrency runs at a time.
+func (s *Service) run(ctx context.Context, b *backfill, times []time.Time, concurrency int) {
+	next := make(chan time.Time)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range next {
+				// The select below may still hand out a time once the
+				// backfill is canceled.
+				if ctx.Err() != nil {
+					continue
+				}
+				_, err := s.ts.ForceRun(ctx, b.TaskID, t.Unix())
+				s.finishRun(b, t, err)
+			}
+		}()
+	}
+
+loop:
+	for _, t := range times {
+		select {
+		case next <- t:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		b.Status = influxdb.BackfillCanceled
+	} else {
+		b.Status = influxdb.BackfillCompleted
+	}
+	b.FinishedAt = time.Now().UTC()
+	b.cancel()
+
+	s.log.Info("Finished backfill",
+		zap.String("taskID", b.TaskID.String()),
+		zap.String("backfillID", b.ID.String()),
+		zap.String("status", b.Status),
+		zap.Int("completedRuns", b.CompletedRuns),
+		zap.Int("failedRuns", b.FailedRuns))
+}
+
+func (s *Service) finishRun(b *backfill, scheduledFor time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b.CompletedRuns++
+	if err != nil {
+		b.FailedRuns++
+		b.LastError = fmt.Sprintf("run scheduled for %s: %s", scheduledFor.UTC().Format(time.RFC3339), err.Error())
+	}
+}
+
+// FindBackfills returns the backfills of a task, oldest first.
+func (s *Service) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bs := []*influxdb.Backfill{}
+	for _, b := range s.backfills {
+		if b.TaskID == taskID {
+			cp := b.Backfill
+			bs = append(bs, &cp)
+		}
+	}
+	sort.Slice(bs, func(i, j int) bool {
+		return bs[i].ID < bs[j].ID
+	})
+	return bs, nil
+}
+
+// FindBackfillByID returns a single backfill.
+func (s *Service) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.backfills[id]
+	if !ok || b.TaskID != taskID {
+		return nil, influxdb.ErrBackfillNotFound
+	}
+	cp := b.Backfill
+	return &cp, nil
+}
+
+// CancelBackfill stops a backfill. Canceling a finished backfill has no effect.
+func (s *Service) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.backfills[id]
+	if !ok || b.TaskID != taskID {
+		return influxdb.ErrBackfillNotFound
+	}
+	b.cancel()
+	return nil
+}
+
+// ScheduledTimes returns the times from start until end at which the schedule
+// of the task triggers, which are the scheduledFor times of its runs.
+func ScheduledTimes(t *influxdb.Task, start, end time.Time) ([]time.Time, error) {
+	cron := t.EffectiveCron()
+	if cron == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task has no schedule to backfill",
+		}
+	}
+
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task timezone",
+			Err:  err,
+		}
+	}
+
+	// Start before the first second of the range, which may be a scheduled
+	// time itself.
+	sch, from, err := scheduler.NewScheduleInLocation(cron, loc, start.Add(-time.Second))
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task schedule",
+			Err:  err,
+		}
+	}
+
+	var times []time.Time
+	for {
+		next, err := sch.Next(from)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid task schedule",
+				Err:  err,
+			}
+		}
+		if !next.Before(end) {
+			break
+		}
+		if !next.Before(start) {
+			if len(times) == influxdb.MaxBackfillRuns {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("backfill exceeds the maximum of %d runs", influxdb.MaxBackfillRuns),
+				}
+			}
+			times = append(times, next)
+		}
+		from = next
+	}
+	if len(times) == 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task is not scheduled to run in the backfill range",
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,227 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/task/backend/backfill"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestScheduledTimes(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	for _, tt := range []struct {
+		name       string
+		task       influxdb.Task
+		start, end string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:  "every",
+			task:  influxdb.Task{Every: "1h"},
+			start: "2020-01-01T00:00:00Z",
+			end:   "2020-01-01T03:00:00Z",
+			want:  []string{"2020-01-01T00:00:00Z", "2020-01-01T01:00:00Z", "2020-01-01T02:00:00Z"},
+		},
+		{
+			name:  "every aligned after start",
+			task:  influxdb.Task{Every: "1h"},
+			start: "2020-01-01T00:30:00Z",
+			end:   "2020-01-01T02:30:00Z",
+			want:  []string{"2020-01-01T01:00:00Z", "2020-01-01T02:00:00Z"},
+		},
+		{
+			name:  "cron",
+			task:  influxdb.Task{Cron: "0 6 * * *"},
+			start: "2020-01-01T00:00:00Z",
+			end:   "2020-01-03T06:00:00Z",
+			want:  []string{"2020-01-01T06:00:00Z", "2020-01-02T06:00:00Z"},
+		},
+		{
+			name:  "cron in time zone",
+			task:  influxdb.Task{Cron: "0 0 * * *", Timezone: "America/New_York"},
+			start: "2020-03-07T00:00:00Z",
+			end:   "2020-03-10T00:00:00Z",
+			want:  []string{"2020-03-07T05:00:00Z", "2020-03-08T05:00:00Z", "2020-03-09T04:00:00Z"},
+		},
+		{
+			name:    "invalid time zone",
+			task:    influxdb.Task{Cron: "0 0 * * *", Timezone: "Mars/Olympus_Mons"},
+			start:   "2020-03-07T00:00:00Z",
+			end:     "2020-03-10T00:00:00Z",
+			wantErr: true,
+		},
+		{
+			name:    "no runs in range",
+			task:    influxdb.Task{Cron: "0 6 * * *"},
+			start:   "2020-01-01T07:00:00Z",
+			end:     "2020-01-01T08:00:00Z",
+			wantErr: true,
+		},
+		{
+			name:    "too many runs",
+			task:    influxdb.Task{Every: "1s"},
+			start:   "2020-01-01T00:00:00Z",
+			end:     "2020-01-02T00:00:00Z",
+			wantErr: true,
+		},
+		{
+			name:    "no schedule",
+			start:   "2020-01-01T00:00:00Z",
+			end:     "2020-01-02T00:00:00Z",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := backfill.ScheduledTimes(&tt.task, at(tt.start), at(tt.end))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", times)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, tm := range times {
+				got = append(got, tm.UTC().Format(time.RFC3339))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected times -want/+got\n%s", diff)
+			}
+		})
+	}
+}
+
+func newTaskService(forceRun func(context.Context, influxdb.ID, int64) (*influxdb.Run, error)) *mock.TaskService {
+	ts := mock.NewTaskService()
+	ts.FindTaskByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Task, error) {
+		if id != 1 {
+			return nil, influxdb.ErrTaskNotFound
+		}
+		return &influxdb.Task{ID: id, Every: "1m"}, nil
+	}
+	ts.ForceRunFn = forceRun
+	return ts
+}
+
+// waitForStatus waits until the backfill is no longer running.
+func waitForStatus(t *testing.T, s *backfill.Service, id influxdb.ID) *influxdb.Backfill {
+	t.Helper()
+	for i := 0; i < 200; i++ {
+		b, err := s.FindBackfillByID(context.Background(), 1, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Status != influxdb.BackfillRunning {
+			return b
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("backfill did not finish")
+	return nil
+}
+
+func TestService_CreateBackfill(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		runs []int64
+	)
+	ts := newTaskService(func(_ context.Context, id influxdb.ID, scheduledFor int64) (*influxdb.Run, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		runs = append(runs, scheduledFor)
+		if len(runs) == 2 {
+			return nil, errors.New("oops")
+		}
+		return &influxdb.Run{TaskID: id}, nil
+	})
+	s := backfill.NewService(zaptest.NewLogger(t), ts, nil)
+	defer s.Close()
+
+	start := time.Unix(600, 0)
+	b, err := s.CreateBackfill(context.Background(), 1, start, start.Add(3*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.TotalRuns != 3 {
+		t.Fatalf("unexpected number of runs: got %d want 3", b.TotalRuns)
+	}
+
+	b = waitForStatus(t, s, b.ID)
+	if b.Status != influxdb.BackfillCompleted || b.CompletedRuns != 3 || b.FailedRuns != 1 || b.LastError == "" {
+		t.Errorf("unexpected backfill: %+v", b)
+	}
+	if diff := cmp.Diff([]int64{600, 660, 720}, runs); diff != "" {
+		t.Errorf("unexpected runs -want/+got\n%s", diff)
+	}
+
+	bs, err := s.FindBackfills(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bs) != 1 || bs[0].ID != b.ID {
+		t.Errorf("unexpected backfills: %v", bs)
+	}
+}
+
+func TestService_CancelBackfill(t *testing.T) {
+	started := make(chan struct{}, 1)
+	ts := newTaskService(func(ctx context.Context, id influxdb.ID, scheduledFor int64) (*influxdb.Run, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	s := backfill.NewService(zaptest.NewLogger(t), ts, nil)
+	defer s.Close()
+
+	start := time.Unix(600, 0)
+	b, err := s.CreateBackfill(context.Background(), 1, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	if err := s.CancelBackfill(context.Background(), 1, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	b = waitForStatus(t, s, b.ID)
+	if b.Status != influxdb.BackfillCanceled || b.CompletedRuns != 1 {
+		t.Errorf("unexpected backfill: %+v", b)
+	}
+
+	if err := s.CancelBackfill(context.Background(), 2, b.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found for another task, got %v", err)
+	}
+}
+
+func TestService_CreateBackfill_Invalid(t *testing.T) {
+	ts := newTaskService(nil)
+	s := backfill.NewService(zaptest.NewLogger(t), ts, nil)
+	defer s.Close()
+
+	start := time.Unix(600, 0)
+	if _, err := s.CreateBackfill(context.Background(), 1, start, start); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected invalid range error, got %v", err)
+	}
+	if _, err := s.CreateBackfill(context.Background(), 2, start, start.Add(time.Hour)); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected task not found error, got %v", err)
+	}
+}
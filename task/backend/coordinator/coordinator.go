@@ -3,6 +3,7 @@ package coordinator
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/influxdata/influxdb/v2"
@@ -18,8 +19,13 @@ var _ Executor = (*executor.Executor)(nil)
 // DefaultLimit is the maximum number of tasks that a given taskd server can own
 const DefaultLimit = 1000
 
+// maxPendingRuns is the maximum number of scheduled times a task with
+// upstream tasks waits on at once. The oldest is dropped past it.
+const maxPendingRuns = 100
+
 // Executor is an abstraction of the task executor with only the functions needed by the coordinator
 type Executor interface {
+	Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error
 	ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error)
 	Cancel(ctx context.Context, runID influxdb.ID) error
 }
@@ -31,6 +37,18 @@ type Coordinator struct {
 	ex  Executor
 
 	limit int
+
+	mu sync.Mutex
+	// active are the active tasks.
+	active map[influxdb.ID]bool
+	// upstream are the upstream tasks of the active tasks that have some.
+	upstream map[influxdb.ID][]influxdb.ID
+	// schedules are the schedules of the active tasks that have upstream
+	// tasks, which only run for the times of their own schedule.
+	schedules map[influxdb.ID]scheduler.Schedule
+	// pending are, for each task with upstream tasks and each scheduled
+	// time, the upstream tasks whose run for that time succeeded.
+	pending map[influxdb.ID]map[int64]map[influxdb.ID]bool
 }
 
 type CoordinatorOption func(*Coordinator)
@@ -81,21 +99,30 @@ func NewSchedulableTask(task *influxdb.Task) (SchedulableTask, error) {
 		ts = task.LatestScheduled
 	}
 
+	loc, err := time.LoadLocation(task.Timezone)
+	if err != nil {
+		return SchedulableTask{}, err
+	}
+
 	var sch scheduler.Schedule
-	var err error
-	sch, ts, err = scheduler.NewSchedule(effCron, ts)
+	sch, ts, err = scheduler.NewScheduleInLocation(effCron, loc, ts)
 	if err != nil {
 		return SchedulableTask{}, err
 	}
 	return SchedulableTask{Task: task, sch: sch, lsc: ts}, nil
 }
 
-func NewCoordinator(log *zap.Logger, scheduler scheduler.Scheduler, executor Executor, opts ...CoordinatorOption) *Coordinator {
+func NewCoordinator(log *zap.Logger, sch scheduler.Scheduler, executor Executor, opts ...CoordinatorOption) *Coordinator {
 	c := &Coordinator{
 		log:   log,
-		sch:   scheduler,
+		sch:   sch,
 		ex:    executor,
 		limit: DefaultLimit,
+
+		active:    make(map[influxdb.ID]bool),
+		upstream:  make(map[influxdb.ID][]influxdb.ID),
+		schedules: make(map[influxdb.ID]scheduler.Schedule),
+		pending:   make(map[influxdb.ID]map[int64]map[influxdb.ID]bool),
 	}
 
 	for _, opt := range opts {
@@ -105,8 +132,16 @@ func NewCoordinator(log *zap.Logger, scheduler scheduler.Scheduler, executor Exe
 	return c
 }
 
-// TaskCreated asks the Scheduler to schedule the newly created task
+// TaskCreated asks the Scheduler to schedule the newly created task.
+// A task with upstream tasks isn't scheduled, it runs once they succeed.
 func (c *Coordinator) TaskCreated(ctx context.Context, task *influxdb.Task) error {
+	if err := c.setDependencies(task); err != nil {
+		return err
+	}
+	if len(task.Upstream) > 0 {
+		return nil
+	}
+
 	t, err := NewSchedulableTask(task)
 
 	if err != nil {
@@ -124,6 +159,19 @@ func (c *Coordinator) TaskCreated(ctx context.Context, task *influxdb.Task) erro
 // TaskUpdated releases the task if it is being disabled, and schedules it otherwise
 func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task) error {
 	sid := scheduler.ID(to.ID)
+	if err := c.setDependencies(to); err != nil {
+		return err
+	}
+	if len(to.Upstream) > 0 {
+		// the task now runs once its upstream tasks succeed
+		if len(from.Upstream) == 0 {
+			if err := c.sch.Release(sid); err != nil && err != influxdb.ErrTaskNotClaimed {
+				return err
+			}
+		}
+		return nil
+	}
+
 	t, err := NewSchedulableTask(to)
 	if err != nil {
 		return err
@@ -145,6 +193,7 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 
 //TaskDeleted asks the Scheduler to release the deleted task
 func (c *Coordinator) TaskDeleted(ctx context.Context, id influxdb.ID) error {
+	c.removeDependencies(id)
 	tid := scheduler.ID(id)
 	if err := c.sch.Release(tid); err != nil && err != influxdb.ErrTaskNotClaimed {
 		return err
@@ -189,3 +238,130 @@ func (c *Coordinator) RunForced(ctx context.Context, task *influxdb.Task, run *i
 
 	return nil
 }
+
+// RunFinished runs the tasks downstream of the task of a successful run, once
+// all of their active upstream tasks succeeded for the same scheduled time.
+// A downstream task only runs for the times of its own schedule.
+// It is meant to be called by the executor once a run is finished.
+func (c *Coordinator) RunFinished(task *influxdb.Task, run *influxdb.Run, status influxdb.RunStatus) {
+	if status != influxdb.RunSuccess {
+		return
+	}
+
+	scheduledFor := run.ScheduledFor
+	for _, id := range c.succeeded(task.ID, scheduledFor) {
+		go func(id influxdb.ID) {
+			if err := c.ex.Execute(context.Background(), scheduler.ID(id), scheduledFor, time.Now().UTC()); err != nil {
+				c.log.Error("Failed to run downstream task", zap.Stringer("task_id", id), zap.Stringer("upstream_task_id", task.ID), zap.Error(err))
+			}
+		}(id)
+	}
+}
+
+// setDependencies records whether the task is active, and its upstream tasks
+// and schedule.
+func (c *Coordinator) setDependencies(task *influxdb.Task) error {
+	if task.Status != string(influxdb.TaskActive) {
+		c.removeDependencies(task.ID)
+		return nil
+	}
+
+	var sch scheduler.Schedule
+	if len(task.Upstream) > 0 {
+		t, err := NewSchedulableTask(task)
+		if err != nil {
+			return err
+		}
+		sch = t.Schedule()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active[task.ID] = true
+	if len(task.Upstream) == 0 {
+		delete(c.upstream, task.ID)
+		delete(c.schedules, task.ID)
+		delete(c.pending, task.ID)
+		return nil
+	}
+	c.upstream[task.ID] = task.Upstream
+	c.schedules[task.ID] = sch
+	return nil
+}
+
+// removeDependencies forgets a deleted or inactive task.
+func (c *Coordinator) removeDependencies(id influxdb.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.active, id)
+	delete(c.upstream, id)
+	delete(c.schedules, id)
+	delete(c.pending, id)
+}
+
+// succeeded records the success of the run of the upstream task scheduled for
+// the given time, and returns the downstream tasks now ready to run for it.
+func (c *Coordinator) succeeded(up influxdb.ID, scheduledFor time.Time) []influxdb.ID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ready []influxdb.ID
+	key := scheduledFor.Unix()
+	for id, upstream := range c.upstream {
+		if !dependsOn(upstream, up) || !onSchedule(c.schedules[id], scheduledFor) {
+			continue
+		}
+
+		runs := c.pending[id]
+		if runs == nil {
+			runs = make(map[int64]map[influxdb.ID]bool)
+			c.pending[id] = runs
+		}
+		if runs[key] == nil {
+			if len(runs) >= maxPendingRuns {
+				delete(runs, oldest(runs))
+			}
+			runs[key] = make(map[influxdb.ID]bool)
+		}
+		runs[key][up] = true
+
+		done := true
+		for _, u := range upstream {
+			if c.active[u] && !runs[key][u] {
+				done = false
+				break
+			}
+		}
+		if done {
+			delete(runs, key)
+			ready = append(ready, id)
+		}
+	}
+	return ready
+}
+
+// onSchedule reports whether t is one of the times of the schedule.
+func onSchedule(sch scheduler.Schedule, t time.Time) bool {
+	next, err := sch.Next(t.Add(-time.Second))
+	return err == nil && next.Equal(t)
+}
+
+func dependsOn(upstream []influxdb.ID, id influxdb.ID) bool {
+	for _, up := range upstream {
+		if up == id {
+			return true
+		}
+	}
+	return false
+}
+
+func oldest(runs map[int64]map[influxdb.ID]bool) int64 {
+	first := true
+	var min int64
+	for k := range runs {
+		if first || k < min {
+			min, first = k, false
+		}
+	}
+	return min
+}
@@ -143,6 +143,14 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			CreatedAt: now,
 			Cron:      "* * * * *",
 		}
+		taskThreeDownstream = &influxdb.Task{
+			ID:        three,
+			Status:    "active",
+			Name:      "Renamed",
+			CreatedAt: now,
+			Cron:      "* * * * *",
+			Upstream:  []influxdb.ID{one},
+		}
 	)
 
 	schedulableT, err := NewSchedulableTask(taskOne)
@@ -186,6 +194,15 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TaskCreated - with upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskCreated(context.Background(), taskThreeDownstream); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{},
+		},
 		{
 			name: "TaskUpdated - deactivate task",
 			call: func(t *testing.T, c *Coordinator) {
@@ -225,6 +242,32 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TaskUpdated - add upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskUpdated(context.Background(), taskThreeNew, taskThreeDownstream); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					releaseCallC{scheduler.ID(taskThreeNew.ID)},
+				},
+			},
+		},
+		{
+			name: "TaskUpdated - remove upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskUpdated(context.Background(), taskThreeDownstream, taskThreeNew); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					scheduleCall{schedulableTaskThree},
+				},
+			},
+		},
 		{
 			name: "TaskDeleted",
 			call: func(t *testing.T, c *Coordinator) {
@@ -251,7 +294,7 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			if diff := cmp.Diff(
 				test.scheduler.calls,
 				sch.calls,
-				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, *coord),
+				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, Coordinator{}),
 				cmpopts.IgnoreUnexported(scheduler.Schedule{}),
 			); diff != "" {
 				t.Errorf("unexpected scheduler contents %s", diff)
@@ -259,3 +302,123 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 		})
 	}
 }
+
+func Test_Coordinator_RunFinished(t *testing.T) {
+	var (
+		one   = influxdb.ID(1)
+		two   = influxdb.ID(2)
+		three = influxdb.ID(3)
+		now   = time.Now().UTC()
+
+		taskOne   = &influxdb.Task{ID: one, Status: "active", CreatedAt: now, Cron: "* * * * *"}
+		taskTwo   = &influxdb.Task{ID: two, Status: "active", CreatedAt: now, Cron: "* * * * *"}
+		taskThree = &influxdb.Task{ID: three, Status: "active", CreatedAt: now, Cron: "* * * * *", Upstream: []influxdb.ID{one, two}}
+
+		scheduledFor = now.Truncate(time.Minute)
+	)
+
+	var (
+		executor = &executorE{executed: make(chan executeCall, 10)}
+		sch      = &schedulerC{}
+		coord    = NewCoordinator(zaptest.NewLogger(t), sch, executor)
+	)
+	for _, task := range []*influxdb.Task{taskOne, taskTwo, taskThree} {
+		if err := coord.TaskCreated(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectNoRun := func(t *testing.T) {
+		t.Helper()
+		select {
+		case call := <-executor.executed:
+			t.Fatalf("unexpected run %v", call)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// a failed run doesn't count
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor}, influxdb.RunFail)
+	coord.RunFinished(taskTwo, &influxdb.Run{TaskID: two, ScheduledFor: scheduledFor}, influxdb.RunSuccess)
+	expectNoRun(t)
+
+	// neither does a run for another time
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor.Add(time.Minute)}, influxdb.RunSuccess)
+	expectNoRun(t)
+
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor}, influxdb.RunSuccess)
+	select {
+	case call := <-executor.executed:
+		if exp := (executeCall{scheduler.ID(three), scheduledFor}); !cmp.Equal(exp, call) {
+			t.Fatalf("expected run %v, got %v", exp, call)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected downstream task to run")
+	}
+
+	// an inactive upstream task isn't waited on
+	taskTwoInactive := &influxdb.Task{ID: two, Status: "inactive", CreatedAt: now, Cron: "* * * * *"}
+	if err := coord.TaskUpdated(context.Background(), taskTwo, taskTwoInactive); err != nil {
+		t.Fatal(err)
+	}
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor.Add(2 * time.Minute)}, influxdb.RunSuccess)
+	select {
+	case call := <-executor.executed:
+		if exp := (executeCall{scheduler.ID(three), scheduledFor.Add(2 * time.Minute)}); !cmp.Equal(exp, call) {
+			t.Fatalf("expected run %v, got %v", exp, call)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected downstream task to run")
+	}
+
+	// a deleted downstream task doesn't run
+	if err := coord.TaskDeleted(context.Background(), three); err != nil {
+		t.Fatal(err)
+	}
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor.Add(3 * time.Minute)}, influxdb.RunSuccess)
+	expectNoRun(t)
+}
+
+func Test_Coordinator_RunFinished_Schedule(t *testing.T) {
+	var (
+		one = influxdb.ID(1)
+		two = influxdb.ID(2)
+		now = time.Now().UTC()
+
+		taskOne = &influxdb.Task{ID: one, Status: "active", CreatedAt: now, Cron: "* * * * *"}
+		taskTwo = &influxdb.Task{ID: two, Status: "active", CreatedAt: now, Cron: "0 * * * *", Upstream: []influxdb.ID{one}}
+
+		scheduledFor = now.Truncate(time.Hour)
+	)
+
+	var (
+		executor = &executorE{executed: make(chan executeCall, 10)}
+		sch      = &schedulerC{}
+		coord    = NewCoordinator(zaptest.NewLogger(t), sch, executor)
+	)
+	for _, task := range []*influxdb.Task{taskOne, taskTwo} {
+		if err := coord.TaskCreated(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the upstream task runs every minute, the downstream task only runs hourly
+	for i := 1; i < 60; i++ {
+		coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor.Add(time.Duration(i) * time.Minute)}, influxdb.RunSuccess)
+	}
+	select {
+	case call := <-executor.executed:
+		t.Fatalf("unexpected run %v", call)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	coord.RunFinished(taskOne, &influxdb.Run{TaskID: one, ScheduledFor: scheduledFor.Add(time.Hour)}, influxdb.RunSuccess)
+	select {
+	case call := <-executor.executed:
+		if exp := (executeCall{scheduler.ID(two), scheduledFor.Add(time.Hour)}); !cmp.Equal(exp, call) {
+			t.Fatalf("expected run %v, got %v", exp, call)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected downstream task to run")
+	}
+}
@@ -2,6 +2,7 @@ package coordinator
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/task/backend/executor"
@@ -13,6 +14,15 @@ var _ Executor = (*executorE)(nil)
 type (
 	executorE struct {
 		calls []interface{}
+
+		// executed receives the calls to Execute, made concurrently by
+		// the coordinator.
+		executed chan executeCall
+	}
+
+	executeCall struct {
+		TaskID       scheduler.ID
+		ScheduledFor time.Time
 	}
 
 	manualRunCall struct {
@@ -94,6 +104,11 @@ func (s *schedulerC) Release(taskID scheduler.ID) error {
 	return nil
 }
 
+func (e *executorE) Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error {
+	e.executed <- executeCall{id, scheduledFor}
+	return nil
+}
+
 func (e *executorE) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
 	e.calls = append(e.calls, manualRunCall{id, runID})
 	ctx, cancel := context.WithCancel(ctx)
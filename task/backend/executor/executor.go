@@ -18,6 +18,7 @@ import (
 	"github.com/influxdata/influxdb/v2/query"
 	"github.com/influxdata/influxdb/v2/task/backend"
 	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
+	"github.com/influxdata/influxdb/v2/task/options"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +26,9 @@ const (
 	maxPromises       = 1000
 	defaultMaxWorkers = 100
 
+	defaultMinRetryBackoff = 10 * time.Second
+	defaultMaxRetryBackoff = 5 * time.Minute
+
 	lastSuccessOption = "tasks.lastSuccessTime"
 )
 
@@ -56,11 +60,18 @@ func MultiLimit(limits ...LimitFunc) LimitFunc {
 // LimitFunc is a function the executor will use to
 type LimitFunc func(*influxdb.Task, *influxdb.Run) error
 
+// RunFinishedFunc is a function the executor calls once a run of a task is
+// finished, with the final status of the run.
+type RunFinishedFunc func(*influxdb.Task, *influxdb.Run, influxdb.RunStatus)
+
 type executorConfig struct {
 	maxWorkers             int
 	systemBuildCompiler    CompilerBuilderFunc
 	nonSystemBuildCompiler CompilerBuilderFunc
 	flagger                feature.Flagger
+	lang                   influxdb.FluxLanguageService
+	minRetryBackoff        time.Duration
+	maxRetryBackoff        time.Duration
 }
 
 type executorOption func(*executorConfig)
@@ -124,12 +135,31 @@ func WithFlagger(flagger feature.Flagger) executorOption {
 	}
 }
 
+// WithFluxLanguageService is an Executor option that sets the service used to
+// read the retry option of tasks. Without it, failed runs are not retried.
+func WithFluxLanguageService(lang influxdb.FluxLanguageService) executorOption {
+	return func(o *executorConfig) {
+		o.lang = lang
+	}
+}
+
+// WithRetryBackoff is an Executor option that sets the delay before the first
+// retry of a failed run. The delay doubles with each further retry, up to max.
+func WithRetryBackoff(min, max time.Duration) executorOption {
+	return func(o *executorConfig) {
+		o.minRetryBackoff = min
+		o.maxRetryBackoff = max
+	}
+}
+
 // NewExecutor creates a new task executor
 func NewExecutor(log *zap.Logger, qs query.QueryService, us PermissionService, ts influxdb.TaskService, tcs backend.TaskControlService, opts ...executorOption) (*Executor, *ExecutorMetrics) {
 	cfg := &executorConfig{
 		maxWorkers:             defaultMaxWorkers,
 		systemBuildCompiler:    NewASTCompiler,
 		nonSystemBuildCompiler: NewASTCompiler,
+		minRetryBackoff:        defaultMinRetryBackoff,
+		maxRetryBackoff:        defaultMaxRetryBackoff,
 	}
 	for _, opt := range opts {
 		opt(cfg)
@@ -145,10 +175,14 @@ func NewExecutor(log *zap.Logger, qs query.QueryService, us PermissionService, t
 		currentPromises:        sync.Map{},
 		promiseQueue:           make(chan *promise, maxPromises),
 		workerLimit:            make(chan struct{}, cfg.maxWorkers),
-		limitFunc:              func(*influxdb.Task, *influxdb.Run) error { return nil }, // noop
+		limitFunc:              func(*influxdb.Task, *influxdb.Run) error { return nil },   // noop
+		runFinishedFunc:        func(*influxdb.Task, *influxdb.Run, influxdb.RunStatus) {}, // noop
 		systemBuildCompiler:    cfg.systemBuildCompiler,
 		nonSystemBuildCompiler: cfg.nonSystemBuildCompiler,
 		flagger:                cfg.flagger,
+		lang:                   cfg.lang,
+		minRetryBackoff:        cfg.minRetryBackoff,
+		maxRetryBackoff:        cfg.maxRetryBackoff,
 	}
 
 	e.metrics = NewExecutorMetrics(e)
@@ -178,7 +212,8 @@ type Executor struct {
 	// keep a pool of promise's we have in queue
 	promiseQueue chan *promise
 
-	limitFunc LimitFunc
+	limitFunc       LimitFunc
+	runFinishedFunc RunFinishedFunc
 
 	// keep a pool of execution workers.
 	workerPool  sync.Pool
@@ -187,6 +222,10 @@ type Executor struct {
 	nonSystemBuildCompiler CompilerBuilderFunc
 	systemBuildCompiler    CompilerBuilderFunc
 	flagger                feature.Flagger
+
+	lang            influxdb.FluxLanguageService
+	minRetryBackoff time.Duration
+	maxRetryBackoff time.Duration
 }
 
 // SetLimitFunc sets the limit func for this task executor
@@ -194,6 +233,11 @@ func (e *Executor) SetLimitFunc(l LimitFunc) {
 	e.limitFunc = l
 }
 
+// SetRunFinishedFunc sets the func called once a run of a task is finished.
+func (e *Executor) SetRunFinishedFunc(f RunFinishedFunc) {
+	e.runFinishedFunc = f
+}
+
 // Execute is a executor to satisfy the needs of tasks
 func (e *Executor) Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error {
 	_, err := e.PromisedExecute(ctx, id, scheduledFor, runAt)
@@ -337,10 +381,12 @@ func (e *Executor) createPromise(ctx context.Context, run *influxdb.Run) (*promi
 			OrgID:       t.OrganizationID,
 			Permissions: perm,
 		},
-		createdAt:  time.Now().UTC(),
-		done:       make(chan struct{}),
-		ctx:        ctx,
-		cancelFunc: cancel,
+		createdAt:   time.Now().UTC(),
+		done:        make(chan struct{}),
+		ctx:         ctx,
+		cancelFunc:  cancel,
+		attempt:     1,
+		maxAttempts: e.maxAttempts(t),
 	}
 
 	// insert promise into queue to be worked
@@ -352,6 +398,59 @@ func (e *Executor) createPromise(ctx context.Context, run *influxdb.Run) (*promi
 	return p, nil
 }
 
+// maxAttempts returns the number of times a run of the task is attempted,
+// which is set by the retry option of the task.
+func (e *Executor) maxAttempts(t *influxdb.Task) int {
+	if e.lang == nil {
+		return 1
+	}
+	o, err := options.FromScriptAST(e.lang, t.Flux)
+	if err != nil || o.Retry == nil || *o.Retry < 1 {
+		return 1
+	}
+	return int(*o.Retry)
+}
+
+// retryBackoff returns the delay before the given attempt of a run.
+func (e *Executor) retryBackoff(attempt int) time.Duration {
+	backoff := e.minRetryBackoff
+	for i := 2; i < attempt && backoff < e.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > e.maxRetryBackoff {
+		backoff = e.maxRetryBackoff
+	}
+	return backoff
+}
+
+// retry queues the promise again after a backoff, if its run failed with an
+// error that may go away and it has attempts left. It returns false if the
+// run is not retried.
+func (e *Executor) retry(p *promise, err error) bool {
+	if err == nil || backend.IsUnrecoverable(err) || p.attempt >= p.maxAttempts || p.ctx.Err() != nil {
+		return false
+	}
+
+	backoff := e.retryBackoff(p.attempt + 1)
+	e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Attempt %d of %d failed, retrying in %s: %s", p.attempt, p.maxAttempts, backoff, err.Error()))
+	e.metrics.RetryRun(p.task)
+	p.attempt++
+
+	go func() {
+		timer := time.NewTimer(backoff)
+		defer timer.Stop()
+
+		// A canceled run is queued right away, so that a worker finishes it.
+		select {
+		case <-p.ctx.Done():
+		case <-timer.C:
+		}
+		e.promiseQueue <- p
+		e.startWorker()
+	}()
+	return true
+}
+
 type workerMaker struct {
 	e *Executor
 }
@@ -417,8 +516,10 @@ func (w *worker) work() {
 			}
 		}
 
-		// execute the promise
-		w.executeQuery(prom)
+		// execute the promise, unless it is queued again to retry the run
+		if !w.executeQuery(prom) {
+			continue
+		}
 
 		// close promise done channel and set appropriate error
 		close(prom.done)
@@ -435,6 +536,9 @@ func (w *worker) start(p *promise) {
 
 	// add to run log
 	w.e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Started task from script: %q", p.task.Flux))
+	if p.maxAttempts > 1 {
+		w.e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Attempt %d of %d", p.attempt, p.maxAttempts))
+	}
 	// update run status
 	w.e.tcs.UpdateRunState(ctx, p.task.ID, p.run.ID, time.Now().UTC(), influxdb.RunStarted)
 
@@ -443,10 +547,16 @@ func (w *worker) start(p *promise) {
 	p.startedAt = time.Now()
 }
 
-func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) {
+// finish completes the run of the promise, or queues it again if the run
+// failed and is retried. It returns false if the run is retried.
+func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) bool {
 	span, ctx := tracing.StartSpanFromContext(p.ctx)
 	defer span.Finish()
 
+	if rs == influxdb.RunFail && w.e.retry(p, err) {
+		return false
+	}
+
 	// add to run log
 	w.e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Completed(%s)", rs.String()))
 	// update run status
@@ -482,9 +592,13 @@ func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) {
 	if _, err := w.e.tcs.FinishRun(p.ctx, p.task.ID, p.run.ID); err != nil {
 		w.e.log.Error("Failed to finish run", zap.String("taskID", p.task.ID.String()), zap.String("runID", p.run.ID.String()), zap.Error(err))
 	}
+	w.e.runFinishedFunc(p.task, p.run, rs)
+	return true
 }
 
-func (w *worker) executeQuery(p *promise) {
+// executeQuery runs the query of the promise. It returns false if the run
+// failed and is retried.
+func (w *worker) executeQuery(p *promise) bool {
 	span, ctx := tracing.StartSpanFromContext(p.ctx)
 	defer span.Finish()
 
@@ -502,21 +616,20 @@ func (w *worker) executeQuery(p *promise) {
 		LatestSuccess: p.task.LatestSuccess,
 	})
 	if err != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrFluxParseError(err))
-		return
+		return w.finish(p, influxdb.RunFail, influxdb.ErrFluxParseError(err))
 	}
 
 	req := &query.Request{
 		Authorization:  p.auth,
 		OrganizationID: p.task.OrganizationID,
 		Compiler:       compiler,
+		Priority:       query.PriorityTask,
 	}
 	req.WithReturnNoContent(true)
 	it, err := w.e.qs.Query(ctx, req)
 	if err != nil {
 		// Assume the error should not be part of the runResult.
-		w.finish(p, influxdb.RunFail, influxdb.ErrQueryError(err))
-		return
+		return w.finish(p, influxdb.RunFail, influxdb.ErrQueryError(err))
 	}
 
 	var runErr error
@@ -538,16 +651,14 @@ func (w *worker) executeQuery(p *promise) {
 	}
 
 	if runErr != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrRunExecutionError(runErr))
-		return
+		return w.finish(p, influxdb.RunFail, influxdb.ErrRunExecutionError(runErr))
 	}
 
 	if it.Err() != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrResultIteratorError(it.Err()))
-		return
+		return w.finish(p, influxdb.RunFail, influxdb.ErrResultIteratorError(it.Err()))
 	}
 
-	w.finish(p, influxdb.RunSuccess, nil)
+	return w.finish(p, influxdb.RunSuccess, nil)
 }
 
 // RunsActive returns the current number of workers, which is equivalent to
@@ -578,6 +689,10 @@ type promise struct {
 	createdAt time.Time
 	startedAt time.Time
 
+	// attempt is the current attempt of the run, out of maxAttempts.
+	attempt     int
+	maxAttempts int
+
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 }
@@ -15,6 +15,7 @@ type ExecutorMetrics struct {
 	errorsCounter        *prometheus.CounterVec
 	manualRunsCounter    *prometheus.CounterVec
 	resumeRunsCounter    *prometheus.CounterVec
+	retriesCounter       *prometheus.CounterVec
 	unrecoverableCounter *prometheus.CounterVec
 	runLatency           *prometheus.HistogramVec
 }
@@ -84,6 +85,13 @@ func NewExecutorMetrics(ex *Executor) *ExecutorMetrics {
 			Help:      "Total number of runs resumed by task ID",
 		}, []string{"taskID"}),
 
+		retriesCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_counter",
+			Help:      "Total number of failed runs retried by task ID",
+		}, []string{"taskID"}),
+
 		runLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: subsystem,
@@ -128,6 +136,7 @@ func (em *ExecutorMetrics) PrometheusCollectors() []prometheus.Collector {
 		em.runDuration,
 		em.manualRunsCounter,
 		em.resumeRunsCounter,
+		em.retriesCounter,
 		em.unrecoverableCounter,
 		em.runLatency,
 	}
@@ -150,6 +159,11 @@ func (em *ExecutorMetrics) FinishRun(task *influxdb.Task, status influxdb.RunSta
 	em.runDuration.WithLabelValues("", task.ID.String()).Observe(runDuration.Seconds())
 }
 
+// RetryRun increments the count of retried runs of the given task.
+func (em *ExecutorMetrics) RetryRun(task *influxdb.Task) {
+	em.retriesCounter.WithLabelValues(task.ID.String()).Inc()
+}
+
 // LogError increments the count of errors by error code.
 func (em *ExecutorMetrics) LogError(taskType string, err error) {
 	switch e := err.(type) {
@@ -54,7 +54,7 @@ type tes struct {
 	tc      testCreds
 }
 
-func taskExecutorSystem(t *testing.T) tes {
+func taskExecutorSystem(t *testing.T, opts ...executorOption) tes {
 	var (
 		aqs = newFakeQueryService()
 		qs  = query.QueryServiceBridge{
@@ -85,7 +85,7 @@ func taskExecutorSystem(t *testing.T) tes {
 		})
 
 		tcs         = &taskControlService{TaskControlService: svc}
-		ex, metrics = NewExecutor(zaptest.NewLogger(t), qs, ps, svc, tcs)
+		ex, metrics = NewExecutor(zaptest.NewLogger(t), qs, ps, svc, tcs, opts...)
 	)
 	return tes{
 		svc:     aqs,
@@ -100,6 +100,7 @@ func taskExecutorSystem(t *testing.T) tes {
 func TestTaskExecutor(t *testing.T) {
 	t.Run("QuerySuccess", testQuerySuccess)
 	t.Run("QueryFailure", testQueryFailure)
+	t.Run("QueryRetry", testQueryRetry)
 	t.Run("ManualRun", testManualRun)
 	t.Run("ResumeRun", testResumingRun)
 	t.Run("WorkerLimit", testWorkerLimit)
@@ -213,6 +214,87 @@ func testQueryFailure(t *testing.T) {
 	}
 }
 
+func testQueryRetry(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t,
+		WithFluxLanguageService(fluxlang.DefaultService),
+		WithRetryBackoff(10*time.Millisecond, 10*time.Millisecond),
+	)
+	reg := prom.NewRegistry(zaptest.NewLogger(t))
+	reg.MustRegister(tes.metrics.PrometheusCollectors()...)
+
+	script := fmt.Sprintf(fmtTestRetryScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first failure is retried for the same run.
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.FailQuery(script, errors.New("blargyblargblarg"))
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.SucceedQuery(script)
+
+	<-promise.Done()
+	if got := promise.Error(); got != nil {
+		t.Fatal(got)
+	}
+
+	run := tes.tcs.run
+	if run == nil || run.ID != promise.ID() {
+		t.Fatal("expected the retried run to be finished")
+	}
+	var attempts []string
+	for _, l := range run.Log {
+		if strings.HasPrefix(l.Message, "Attempt ") {
+			attempts = append(attempts, l.Message)
+		}
+	}
+	if len(attempts) != 3 || !strings.HasPrefix(attempts[1], "Attempt 1 of 2 failed") || attempts[2] != "Attempt 2 of 2" {
+		t.Fatalf("unexpected attempts in run log: %q", attempts)
+	}
+
+	mg := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mg, "task_executor_retries_counter", map[string]string{"taskID": task.ID.String()})
+	assert.EqualValues(t, 1, *m.Counter.Value, "unexpected number of retries")
+
+	// The run fails once it is out of attempts.
+	promise, err = tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(183, 0), time.Unix(186, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		tes.svc.WaitForQueryLive(t, script)
+		tes.svc.FailQuery(script, errors.New("blargyblargblarg"))
+	}
+
+	<-promise.Done()
+	if got := promise.Error(); got == nil {
+		t.Fatal("got no error when I should have")
+	}
+}
+
+func TestExecutor_retryBackoff(t *testing.T) {
+	e := &Executor{minRetryBackoff: time.Second, maxRetryBackoff: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{
+		2: time.Second,
+		3: 2 * time.Second,
+		4: 4 * time.Second,
+		5: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if got := e.retryBackoff(attempt); got != want {
+			t.Errorf("unexpected backoff before attempt %d: got %s want %s", attempt, got, want)
+		}
+	}
+}
+
 func testManualRun(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
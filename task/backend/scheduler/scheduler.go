@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"math"
 	"strings"
 	"time"
 
@@ -52,7 +53,17 @@ type SchedulableService interface {
 	UpdateLastScheduled(ctx context.Context, id ID, t time.Time) error
 }
 
+// NewSchedule parses a cron string into a Schedule evaluated in UTC, and
+// aligns lastScheduledAt to it.
 func NewSchedule(unparsed string, lastScheduledAt time.Time) (Schedule, time.Time, error) {
+	return NewScheduleInLocation(unparsed, time.UTC, lastScheduledAt)
+}
+
+// NewScheduleInLocation is like NewSchedule, but evaluates the cron string in
+// the wall clock of loc. See Schedule.Next for how it handles daylight saving
+// time. Fixed periods ("@every") don't depend on a location, so loc is ignored
+// for them.
+func NewScheduleInLocation(unparsed string, loc *time.Location, lastScheduledAt time.Time) (Schedule, time.Time, error) {
 	lastScheduledAt = lastScheduledAt.UTC().Truncate(time.Second)
 	c, err := cron.ParseUTC(unparsed)
 	if err != nil {
@@ -60,6 +71,9 @@ func NewSchedule(unparsed string, lastScheduledAt time.Time) (Schedule, time.Tim
 	}
 
 	unparsed = strings.TrimSpace(unparsed)
+	if !strings.HasPrefix(unparsed, "@every ") && loc != nil && loc != time.UTC {
+		return Schedule{cron: c, loc: loc}, lastScheduledAt, nil
+	}
 
 	// Align create to the hour/minute
 	if strings.HasPrefix(unparsed, "@every ") {
@@ -68,31 +82,103 @@ func NewSchedule(unparsed string, lastScheduledAt time.Time) (Schedule, time.Tim
 		err := every.Parse(everyString)
 		if err != nil {
 			// We cannot align a invalid time
-			return Schedule{c}, lastScheduledAt, nil
+			return Schedule{cron: c}, lastScheduledAt, nil
 		}
 
 		// drop nanoseconds
 		lastScheduledAt = time.Unix(lastScheduledAt.UTC().Unix(), 0).UTC()
 		everyDur, err := every.DurationFrom(lastScheduledAt)
 		if err != nil {
-			return Schedule{c}, lastScheduledAt, nil
+			return Schedule{cron: c}, lastScheduledAt, nil
 		}
 
 		// and align
 		lastScheduledAt = lastScheduledAt.Truncate(everyDur).Truncate(time.Second)
 	}
 
-	return Schedule{c}, lastScheduledAt, err
+	return Schedule{cron: c}, lastScheduledAt, err
 }
 
 // Schedule is an object a valid schedule of runs
 type Schedule struct {
 	cron cron.Parsed
+
+	// loc is the location the cron string is evaluated in, nil for UTC.
+	loc *time.Location
 }
 
 // Next returns the next time after from that a schedule should trigger on.
+//
+// The schedule is evaluated on the wall clock of its location, so that a run
+// at midnight stays at local midnight across daylight saving time changes.
+// When the clocks are set forward, the wall clock times that are skipped
+// trigger once, at the instant the clocks change. When the clocks are set
+// back, the wall clock times that are repeated only trigger on their first
+// occurrence.
 func (s Schedule) Next(from time.Time) (time.Time, error) {
-	return cron.Parsed(s.cron).Next(from)
+	loc := s.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	from = from.In(loc)
+	wall := wallClock(from)
+	for {
+		next, err := s.cron.Next(wall)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// Wall clock times in the first occurrence of a repeated hour can be
+		// before from, so move on to the next wall clock time.
+		if t := inLocation(next, loc); t.After(from) {
+			return t, nil
+		}
+		wall = next
+	}
+}
+
+// wallClock returns the wall clock time of t as a time in UTC.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// inLocation returns the first instant the wall clock of loc shows wall, which
+// is a wall clock time as returned by wallClock. If the wall clock skips wall,
+// it returns the instant the wall clock skips it.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	if loc == time.UTC {
+		return wall
+	}
+
+	// The instants the wall clock may show wall at are the wall clock time
+	// minus the offsets in effect around it.
+	u := wall.Unix()
+	first, last := int64(math.MaxInt64), int64(math.MinInt64)
+	for _, d := range []int64{-86400, 0, 86400} {
+		_, offset := time.Unix(u+d, 0).In(loc).Zone()
+		t := u - int64(offset)
+		if wallClock(time.Unix(t, 0).In(loc)).Unix() == u && t < first {
+			first = t
+		}
+		if t > last {
+			last = t
+		}
+	}
+	if first != math.MaxInt64 {
+		return time.Unix(first, int64(wall.Nanosecond())).In(loc)
+	}
+
+	// wall is skipped. The wall clock is past it at the latest candidate, so
+	// search for the first instant it is past it, which is when it skips.
+	lo, hi := u-14*3600, last
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if wallClock(time.Unix(mid, 0).In(loc)).Unix() > u {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return time.Unix(hi, 0).In(loc)
 }
 
 // ValidSchedule returns an error if the cron string is invalid.
@@ -666,3 +666,113 @@ func TestNewSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedule_NextInLocation(t *testing.T) {
+	// In New York, the clocks are set forward from 02:00 to 03:00 on
+	// 2020-03-08, and back from 02:00 to 01:00 on 2020-11-01.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name string
+		cron string
+		loc  *time.Location
+		from string
+		want []string
+	}{
+		{
+			name: "UTC by default",
+			cron: "0 0 * * *",
+			from: "2020-03-07T00:00:00Z",
+			want: []string{"2020-03-08T00:00:00Z", "2020-03-09T00:00:00Z"},
+		},
+		{
+			name: "local midnight when set forward",
+			cron: "0 0 * * *",
+			loc:  ny,
+			from: "2020-03-07T00:00:00-05:00",
+			want: []string{"2020-03-08T00:00:00-05:00", "2020-03-09T00:00:00-04:00"},
+		},
+		{
+			name: "local midnight when set back",
+			cron: "0 0 * * *",
+			loc:  ny,
+			from: "2020-10-31T00:00:00-04:00",
+			want: []string{"2020-11-01T00:00:00-04:00", "2020-11-02T00:00:00-05:00"},
+		},
+		{
+			name: "skipped time triggers when set forward",
+			cron: "30 2 * * *",
+			loc:  ny,
+			from: "2020-03-07T02:30:00-05:00",
+			want: []string{"2020-03-08T03:00:00-04:00", "2020-03-09T02:30:00-04:00"},
+		},
+		{
+			name: "skipped times trigger once",
+			cron: "0,30 * * * *",
+			loc:  ny,
+			from: "2020-03-08T01:30:00-05:00",
+			want: []string{"2020-03-08T03:00:00-04:00", "2020-03-08T03:30:00-04:00"},
+		},
+		{
+			name: "repeated time triggers on first occurrence",
+			cron: "30 1 * * *",
+			loc:  ny,
+			from: "2020-10-31T01:30:00-04:00",
+			want: []string{"2020-11-01T01:30:00-04:00", "2020-11-02T01:30:00-05:00"},
+		},
+		{
+			name: "repeated hour triggers once",
+			cron: "0 * * * *",
+			loc:  ny,
+			from: "2020-11-01T00:00:00-04:00",
+			want: []string{"2020-11-01T01:00:00-04:00", "2020-11-01T02:00:00-05:00"},
+		},
+		{
+			name: "from in second occurrence of repeated hour",
+			cron: "30 * * * *",
+			loc:  ny,
+			from: "2020-11-01T01:10:00-05:00",
+			want: []string{"2020-11-01T02:30:00-05:00"},
+		},
+		{
+			name: "every ignores location",
+			cron: "@every 1h",
+			loc:  ny,
+			from: "2020-03-08T01:00:00-05:00",
+			want: []string{"2020-03-08T03:00:00-04:00", "2020-03-08T04:00:00-04:00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			sch, from, err := NewScheduleInLocation(tt.cron, loc, at(tt.from))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range tt.want {
+				next, err := sch.Next(from)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := at(w); !next.Equal(want) {
+					t.Fatalf("unexpected next time after %s: got %s want %s", from, next.In(loc), want)
+				}
+				from = next
+			}
+		})
+	}
+}
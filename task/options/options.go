@@ -29,6 +29,10 @@ type Options struct {
 	// Cron is a cron style time schedule that can be used in place of Every.
 	Cron string `json:"cron,omitempty"`
 
+	// Timezone is the IANA name of the time zone Cron is evaluated in.
+	// It defaults to UTC and can only be used with Cron.
+	Timezone string `json:"timezone,omitempty"`
+
 	// Every represents a fixed period to repeat execution.
 	// this can be unmarshaled from json as a string i.e.: "1d" will unmarshal as 1 day
 	Every Duration `json:"every,omitempty"`
@@ -39,6 +43,8 @@ type Options struct {
 
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
+	// Retry is the maximum number of times a run is attempted before it
+	// fails. Failed attempts are retried with an exponential backoff.
 	Retry *int64 `json:"retry,omitempty"`
 }
 
@@ -114,6 +120,7 @@ func (a *Duration) Add(t time.Time) (time.Time, error) {
 func (o *Options) Clear() {
 	o.Name = ""
 	o.Cron = ""
+	o.Timezone = ""
 	o.Every = Duration{}
 	o.Offset = nil
 	o.Concurrency = nil
@@ -124,6 +131,7 @@ func (o *Options) Clear() {
 func (o *Options) IsZero() bool {
 	return o.Name == "" &&
 		o.Cron == "" &&
+		o.Timezone == "" &&
 		o.Every.IsZero() &&
 		(o.Offset == nil || o.Offset.IsZero()) &&
 		o.Concurrency == nil &&
@@ -134,6 +142,7 @@ func (o *Options) IsZero() bool {
 const (
 	optName        = "name"
 	optCron        = "cron"
+	optTimezone    = "timezone"
 	optEvery       = "every"
 	optOffset      = "offset"
 	optConcurrency = "concurrency"
@@ -278,6 +287,7 @@ type extractFn func(*Options, *ast.ObjectExpression) error
 var taskOptionExtractors = []extractFn{
 	extractNameOption,
 	extractScheduleOptions,
+	extractTimezoneOption,
 	extractOffsetOption,
 	extractConcurrencyOption,
 	extractRetryOption,
@@ -326,6 +336,21 @@ func extractScheduleOptions(opts *Options, objExpr *ast.ObjectExpression) error
 	return nil
 }
 
+func extractTimezoneOption(opts *Options, objExpr *ast.ObjectExpression) error {
+	tzExpr, err := edit.GetProperty(objExpr, optTimezone)
+	if err != nil {
+		return nil
+	}
+
+	tzStr, ok := tzExpr.(*ast.StringLiteral)
+	if !ok {
+		return errParseTaskOptionField(optTimezone)
+	}
+	opts.Timezone = ast.StringFromLiteral(tzStr)
+
+	return nil
+}
+
 func extractOffsetOption(opts *Options, objExpr *ast.ObjectExpression) error {
 	offsetExpr, offsetErr := edit.GetProperty(objExpr, optOffset)
 	if offsetErr != nil {
@@ -457,6 +482,13 @@ func FromScript(lang FluxLanguageService, script string) (Options, error) {
 		opt.Every.Node = *durNode
 	}
 
+	if tzVal, ok := optObject.Get(optTimezone); ok {
+		if err := checkNature(tzVal.Type().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Timezone = tzVal.Str()
+	}
+
 	if offsetVal, ok := optObject.Get(optOffset); ok {
 		if err := checkNature(offsetVal.Type().Nature(), semantic.Duration); err != nil {
 			return opt, err
@@ -516,11 +548,21 @@ func (o *Options) Validate() error {
 		if err != nil {
 			errs = append(errs, "cron invalid: "+err.Error())
 		}
+		if o.Timezone == "Local" {
+			// Local depends on the host, so it would change the schedule
+			// depending on where the task happens to run.
+			errs = append(errs, "timezone invalid: Local is not allowed")
+		} else if _, err := time.LoadLocation(o.Timezone); err != nil {
+			errs = append(errs, "timezone invalid: "+err.Error())
+		}
 	} else if everyPresent {
 		every, err := o.Every.DurationFrom(now)
 		if err != nil {
 			return err
 		}
+		if o.Timezone != "" {
+			errs = append(errs, "timezone can only be used with cron")
+		}
 		if every < time.Second {
 			errs = append(errs, "every option must be at least 1 second")
 		} else if every.Truncate(time.Second) != every {
@@ -592,7 +634,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optTimezone, optEvery, optOffset, optConcurrency, optRetry:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -601,7 +643,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optTimezone, optEvery, optOffset, optConcurrency, optRetry}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
@@ -24,6 +24,9 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Cron != "" {
 		taskData = fmt.Sprintf("%s  cron: %q,\n", taskData, opt.Cron)
 	}
+	if opt.Timezone != "" {
+		taskData = fmt.Sprintf("%s  timezone: %q,\n", taskData, opt.Timezone)
+	}
 	if !opt.Every.IsZero() {
 		taskData = fmt.Sprintf("%s  every: %s,\n", taskData, opt.Every.String())
 	}
@@ -80,6 +83,9 @@ func TestFromScriptAST(t *testing.T) {
 		{script: scriptGenerator(options.Options{Name: "name7", Retry: pointer.Int64(20), Every: *(options.MustParseDuration("1h"))}, ""), shouldErr: true},
 		{script: "option task = {\n  name: \"name8\",\n  retry: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name9"}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name12", Cron: "0 0 * * *", Timezone: "America/New_York"}, ""), exp: options.Options{Name: "name12", Cron: "0 0 * * *", Timezone: "America/New_York", Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: scriptGenerator(options.Options{Name: "name13", Cron: "0 0 * * *", Timezone: "Mars/Olympus_Mons"}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name14", Every: *(options.MustParseDuration("1h")), Timezone: "America/New_York"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{}, ""), shouldErr: true},
 		{script: `option task = {
 			name: "name10",
@@ -146,6 +152,9 @@ func TestFromScript(t *testing.T) {
 		{script: scriptGenerator(options.Options{Name: "name7", Retry: pointer.Int64(20), Every: *(options.MustParseDuration("1h"))}, ""), shouldErr: true},
 		{script: "option task = {\n  name: \"name8\",\n  retry: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name9"}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name12", Cron: "0 0 * * *", Timezone: "America/New_York"}, ""), exp: options.Options{Name: "name12", Cron: "0 0 * * *", Timezone: "America/New_York", Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: scriptGenerator(options.Options{Name: "name13", Cron: "0 0 * * *", Timezone: "Mars/Olympus_Mons"}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name14", Every: *(options.MustParseDuration("1h")), Timezone: "America/New_York"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{}, ""), shouldErr: true},
 		{script: `option task = {
 			name: "name10",
@@ -215,7 +224,7 @@ func TestFromScriptWithUnknownOptions(t *testing.T) {
 		t.Errorf("expected error to mention unrecognized options, but it said: %v", err)
 	}
 
-	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry"}
+	validOpts := []string{"name", "cron", "timezone", "every", "offset", "concurrency", "retry"}
 	for _, o := range validOpts {
 		if !strings.Contains(msg, o) {
 			t.Errorf("expected error to mention valid option %q but it said: %v", o, err)
@@ -261,6 +270,26 @@ func TestValidate(t *testing.T) {
 		t.Error("expected error for negative every")
 	}
 
+	*bad = good
+	bad.Timezone = "Mars/Olympus_Mons"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+
+	*bad = good
+	bad.Timezone = "Local"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for Local timezone")
+	}
+
+	*bad = good
+	bad.Cron = ""
+	bad.Every = *options.MustParseDuration("1m")
+	bad.Timezone = "America/New_York"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for timezone with every")
+	}
+
 	*bad = good
 	bad.Offset = options.MustParseDuration("1500ms")
 	if err := bad.Validate(); err == nil {
@@ -292,6 +321,12 @@ func TestValidate(t *testing.T) {
 	}
 
 	notbad := new(options.Options)
+	*notbad = good
+	notbad.Timezone = "America/New_York"
+	if err := notbad.Validate(); err != nil {
+		t.Error("expected no error for cron with timezone")
+	}
+
 	*notbad = good
 	notbad.Cron = ""
 	notbad.Every = *options.MustParseDuration("22d")
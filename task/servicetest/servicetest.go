@@ -102,6 +102,11 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory, testCategory ...s
 					testTaskType(t, sys)
 				})
 
+				t.Run("Task Dependencies", func(t *testing.T) {
+					t.Parallel()
+					testTaskDependencies(t, sys)
+				})
+
 			})
 		case "analytical":
 			t.Run("AnalyticalTaskService", func(t *testing.T) {
@@ -1823,6 +1828,59 @@ from(bucket: "b")
 	|> to(bucket: "two", orgID: "000000000000000")`
 )
 
+func testTaskDependencies(t *testing.T, sys *System) {
+	cr := creds(t, sys)
+	authorizedCtx := icontext.SetAuthorizer(sys.Ctx, cr.Authorizer())
+
+	create := func(upstream ...influxdb.ID) (*influxdb.Task, error) {
+		return sys.TaskService.CreateTask(authorizedCtx, influxdb.TaskCreate{
+			OrganizationID: cr.OrgID,
+			Flux:           fmt.Sprintf(scriptFmt, 0),
+			OwnerID:        cr.UserID,
+			Upstream:       upstream,
+		})
+	}
+
+	raw, err := create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollup, err := create(raw.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rollup.Upstream, []influxdb.ID{raw.ID}) {
+		t.Fatalf("expected upstream %v, got %v", []influxdb.ID{raw.ID}, rollup.Upstream)
+	}
+
+	found, err := sys.TaskService.FindTaskByID(authorizedCtx, rollup.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(found.Upstream, rollup.Upstream) {
+		t.Fatalf("expected upstream %v, got %v", rollup.Upstream, found.Upstream)
+	}
+
+	if _, err := create(influxdb.ID(1)); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for missing upstream task, got %v", err)
+	}
+
+	// raw depending on rollup would form a cycle
+	upstream := []influxdb.ID{rollup.ID}
+	if _, err := sys.TaskService.UpdateTask(authorizedCtx, raw.ID, influxdb.TaskUpdate{Upstream: &upstream}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for dependency cycle, got %v", err)
+	}
+
+	upstream = []influxdb.ID{}
+	updated, err := sys.TaskService.UpdateTask(authorizedCtx, rollup.ID, influxdb.TaskUpdate{Upstream: &upstream})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Upstream) != 0 {
+		t.Fatalf("expected no upstream tasks, got %v", updated.Upstream)
+	}
+}
+
 func testTaskType(t *testing.T, sys *System) {
 	cr := creds(t, sys)
 	authorizedCtx := icontext.SetAuthorizer(sys.Ctx, cr.Authorizer())
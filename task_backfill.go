@@ -0,0 +1,49 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// Statuses of a backfill.
+const (
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillCanceled  = "canceled"
+)
+
+// MaxBackfillRuns is the maximum number of runs of a single backfill.
+const MaxBackfillRuns = 10000
+
+// Backfill is a set of runs of a task, one for each time the schedule of the
+// task triggers in a time range, which are run in the order of their times.
+type Backfill struct {
+	ID            ID        `json:"id"`
+	TaskID        ID        `json:"taskID"`
+	Start         time.Time `json:"start"` // Start is the earliest scheduledFor time, inclusive
+	End           time.Time `json:"end"`   // End is the latest scheduledFor time, exclusive
+	Status        string    `json:"status"`
+	TotalRuns     int       `json:"totalRuns"`
+	CompletedRuns int       `json:"completedRuns"` // CompletedRuns is the number of runs that are done, including the failed ones
+	FailedRuns    int       `json:"failedRuns"`
+	LastError     string    `json:"lastError,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	FinishedAt    time.Time `json:"finishedAt,omitempty"`
+}
+
+// BackfillService manages the backfills of tasks.
+type BackfillService interface {
+	// CreateBackfill starts a backfill of the task for the runs scheduled
+	// from start until end. No more runs of the backfill are executed at a
+	// time than the concurrency of the task allows.
+	CreateBackfill(ctx context.Context, taskID ID, start, end time.Time) (*Backfill, error)
+
+	// FindBackfills returns the backfills of a task.
+	FindBackfills(ctx context.Context, taskID ID) ([]*Backfill, error)
+
+	// FindBackfillByID returns a single backfill.
+	FindBackfillByID(ctx context.Context, taskID, id ID) (*Backfill, error)
+
+	// CancelBackfill stops a backfill, canceling its runs in progress.
+	CancelBackfill(ctx context.Context, taskID, id ID) error
+}
@@ -0,0 +1,142 @@
+package influxdb
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MaxTaskUpstream is the maximum number of upstream tasks of a task.
+const MaxTaskUpstream = 100
+
+// TaskDependencyGraph is the graph of the tasks connected to a task through
+// their upstream tasks.
+type TaskDependencyGraph struct {
+	Nodes []TaskDependencyNode `json:"nodes"`
+	Edges []TaskDependencyEdge `json:"edges"`
+}
+
+// TaskDependencyNode is a task of a dependency graph.
+type TaskDependencyNode struct {
+	ID     ID     `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// TaskDependencyEdge means that the downstream task runs once the upstream
+// task succeeded.
+type TaskDependencyEdge struct {
+	Upstream   ID `json:"upstream"`
+	Downstream ID `json:"downstream"`
+}
+
+// NewTaskDependencyGraph returns the graph of the tasks connected to the task
+// id, directly or not, out of tasks. Upstream tasks missing from tasks are
+// left out.
+func NewTaskDependencyGraph(id ID, tasks []*Task) *TaskDependencyGraph {
+	byID := make(map[ID]*Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	// neighbors are the upstream and downstream tasks of a task.
+	neighbors := make(map[ID][]ID)
+	var edges []TaskDependencyEdge
+	for _, t := range tasks {
+		for _, up := range t.Upstream {
+			if _, ok := byID[up]; !ok {
+				continue
+			}
+			neighbors[t.ID] = append(neighbors[t.ID], up)
+			neighbors[up] = append(neighbors[up], t.ID)
+			edges = append(edges, TaskDependencyEdge{Upstream: up, Downstream: t.ID})
+		}
+	}
+
+	g := &TaskDependencyGraph{
+		Nodes: []TaskDependencyNode{},
+		Edges: []TaskDependencyEdge{},
+	}
+	if _, ok := byID[id]; !ok {
+		return g
+	}
+
+	connected := map[ID]bool{id: true}
+	queue := []ID{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, n := range neighbors[next] {
+			if !connected[n] {
+				connected[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	for n := range connected {
+		t := byID[n]
+		g.Nodes = append(g.Nodes, TaskDependencyNode{ID: t.ID, Name: t.Name, Status: t.Status})
+	}
+	for _, e := range edges {
+		if connected[e.Downstream] {
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Upstream != g.Edges[j].Upstream {
+			return g.Edges[i].Upstream < g.Edges[j].Upstream
+		}
+		return g.Edges[i].Downstream < g.Edges[j].Downstream
+	})
+	return g
+}
+
+// ValidateTaskUpstream returns an error if the task id can't depend on the
+// upstream tasks: if it is one of them, if one of them is listed twice, or if
+// one of them depends on the task already, directly or not.
+//
+// findUpstream returns the upstream tasks of a task.
+func ValidateTaskUpstream(id ID, upstream []ID, findUpstream func(ID) ([]ID, error)) error {
+	if len(upstream) > MaxTaskUpstream {
+		return ErrInvalidTaskUpstream(fmt.Sprintf("more than the maximum of %d", MaxTaskUpstream))
+	}
+
+	seen := make(map[ID]bool, len(upstream))
+	for _, up := range upstream {
+		if up == id {
+			return ErrTaskDependencyCycle
+		}
+		if seen[up] {
+			return ErrInvalidTaskUpstream(fmt.Sprintf("task %s is listed more than once", up))
+		}
+		seen[up] = true
+	}
+
+	// Walk up from the upstream tasks, looking for the task.
+	visited := make(map[ID]bool)
+	stack := append([]ID(nil), upstream...)
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+
+		ups, err := findUpstream(next)
+		if err != nil {
+			return err
+		}
+		for _, up := range ups {
+			if up == id {
+				return ErrTaskDependencyCycle
+			}
+			stack = append(stack, up)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	platform "github.com/influxdata/influxdb/v2"
+)
+
+func TestNewTaskDependencyGraph(t *testing.T) {
+	tasks := []*platform.Task{
+		{ID: 1, Name: "raw", Status: "active"},
+		{ID: 2, Name: "1m", Status: "active", Upstream: []platform.ID{1}},
+		{ID: 3, Name: "1h", Status: "active", Upstream: []platform.ID{2, 9}},
+		{ID: 4, Name: "other", Status: "active"},
+		{ID: 5, Name: "also raw", Status: "inactive", Upstream: []platform.ID{1}},
+	}
+
+	got := platform.NewTaskDependencyGraph(2, tasks)
+	exp := &platform.TaskDependencyGraph{
+		Nodes: []platform.TaskDependencyNode{
+			{ID: 1, Name: "raw", Status: "active"},
+			{ID: 2, Name: "1m", Status: "active"},
+			{ID: 3, Name: "1h", Status: "active"},
+			{ID: 5, Name: "also raw", Status: "inactive"},
+		},
+		Edges: []platform.TaskDependencyEdge{
+			{Upstream: 1, Downstream: 2},
+			{Upstream: 1, Downstream: 5},
+			{Upstream: 2, Downstream: 3},
+		},
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("unexpected graph: -want/+got\n%s", diff)
+	}
+
+	got = platform.NewTaskDependencyGraph(4, tasks)
+	exp = &platform.TaskDependencyGraph{
+		Nodes: []platform.TaskDependencyNode{{ID: 4, Name: "other", Status: "active"}},
+		Edges: []platform.TaskDependencyEdge{},
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("unexpected graph: -want/+got\n%s", diff)
+	}
+}
+
+func TestValidateTaskUpstream(t *testing.T) {
+	// 3 depends on 2, which depends on 1.
+	upstream := map[platform.ID][]platform.ID{
+		2: {1},
+		3: {2},
+	}
+	findUpstream := func(id platform.ID) ([]platform.ID, error) {
+		return upstream[id], nil
+	}
+
+	tooMany := make([]platform.ID, platform.MaxTaskUpstream+1)
+	for i := range tooMany {
+		tooMany[i] = platform.ID(100 + i)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		id       platform.ID
+		upstream []platform.ID
+		wantErr  bool
+	}{
+		{name: "none", id: 1},
+		{name: "chain", id: 4, upstream: []platform.ID{3}},
+		{name: "shared upstream", id: 4, upstream: []platform.ID{1, 3}},
+		{name: "self", id: 4, upstream: []platform.ID{4}, wantErr: true},
+		{name: "twice", id: 4, upstream: []platform.ID{3, 3}, wantErr: true},
+		{name: "direct cycle", id: 2, upstream: []platform.ID{3}, wantErr: true},
+		{name: "indirect cycle", id: 1, upstream: []platform.ID{3}, wantErr: true},
+		{name: "too many", id: 1, upstream: tooMany, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := platform.ValidateTaskUpstream(tt.id, tt.upstream, findUpstream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
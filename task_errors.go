@@ -47,6 +47,12 @@ var (
 		Msg:  "run not found",
 	}
 
+	// ErrBackfillNotFound is returned when searching for a single backfill that doesn't exist.
+	ErrBackfillNotFound = &Error{
+		Code: ENotFound,
+		Msg:  "backfill not found",
+	}
+
 	ErrRunKeyNotFound = &Error{
 		Code: ENotFound,
 		Msg:  "run key not found",
@@ -88,8 +94,22 @@ var (
 		Code: EInvalid,
 		Msg:  "cannot create task with invalid ownerID",
 	}
+
+	// ErrTaskDependencyCycle is returned when the upstream tasks of a task depend on the task.
+	ErrTaskDependencyCycle = &Error{
+		Code: EInvalid,
+		Msg:  "task dependencies would form a cycle",
+	}
 )
 
+// ErrInvalidTaskUpstream is returned when the upstream tasks of a task are not valid.
+func ErrInvalidTaskUpstream(msg string) *Error {
+	return &Error{
+		Code: EInvalid,
+		Msg:  "invalid upstream tasks: " + msg,
+	}
+}
+
 // ErrFluxParseError is returned when an error is thrown by Flux.Parse in the task executor
 func ErrFluxParseError(err error) *Error {
 	return &Error{
@@ -52,8 +52,13 @@ const (
 	// block in a TSM file
 	DefaultMaxPointsPerBlock = 1000
 
+	// DefaultMaxSeriesPerBucket is the maximum number of series a bucket can have.
+	// A value of 0 disables the limit.
+	DefaultMaxSeriesPerBucket = 0
+
 	// DefaultMaxValuesPerTag is the maximum number of values a tag can have within a measurement.
-	DefaultMaxValuesPerTag = 100000
+	// A value of 0 disables the limit.
+	DefaultMaxValuesPerTag = 0
 
 	// DefaultMaxConcurrentCompactions is the maximum number of concurrent full and level compactions
 	// that can run at one time.  A value of 0 results in 50% of runtime.GOMAXPROCS(0) used at runtime.
@@ -102,6 +107,16 @@ type Config struct {
 
 	// Limits
 
+	// MaxSeriesPerBucket is the maximum number of series a bucket can have.
+	// Points that would create series past it are dropped. A value of 0
+	// disables the limit.
+	MaxSeriesPerBucket int `toml:"max-series-per-bucket"`
+
+	// MaxValuesPerTag is the maximum number of values a tag can have within a
+	// measurement of a shard. Points that would add values past it are dropped.
+	// A value of 0 disables the limit.
+	MaxValuesPerTag int `toml:"max-values-per-tag"`
+
 	// MaxConcurrentCompactions is the maximum number of concurrent level and full compactions
 	// that can be running at one time across all shards.  Compactions scheduled to run when the
 	// limit is reached are blocked until a running compaction completes.  Snapshot compactions are
@@ -150,6 +165,8 @@ func NewConfig() Config {
 		CompactThroughput:              toml.Size(DefaultCompactThroughput),
 		CompactThroughputBurst:         toml.Size(DefaultCompactThroughputBurst),
 
+		MaxSeriesPerBucket:       DefaultMaxSeriesPerBucket,
+		MaxValuesPerTag:          DefaultMaxValuesPerTag,
 		MaxConcurrentCompactions: DefaultMaxConcurrentCompactions,
 
 		MaxIndexLogFileSize:  toml.Size(DefaultMaxIndexLogFileSize),
@@ -170,6 +187,14 @@ func (c *Config) Validate() error {
 		return errors.New("Data.WALDir must be specified")
 	}
 
+	if c.MaxSeriesPerBucket < 0 {
+		return errors.New("max-series-per-bucket must be non-negative")
+	}
+
+	if c.MaxValuesPerTag < 0 {
+		return errors.New("max-values-per-tag must be non-negative")
+	}
+
 	if c.MaxConcurrentCompactions < 0 {
 		return errors.New("max-concurrent-compactions must be non-negative")
 	}
@@ -217,6 +242,8 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 		"cache-snapshot-memory-size":             c.CacheSnapshotMemorySize,
 		"cache-snapshot-write-cold-duration":     c.CacheSnapshotWriteColdDuration,
 		"compact-full-write-cold-duration":       c.CompactFullWriteColdDuration,
+		"max-series-per-bucket":                  c.MaxSeriesPerBucket,
+		"max-values-per-tag":                     c.MaxValuesPerTag,
 		"max-concurrent-compactions":             c.MaxConcurrentCompactions,
 		"max-index-log-file-size":                c.MaxIndexLogFileSize,
 		"series-id-set-cache-size":               c.SeriesIDSetCacheSize,
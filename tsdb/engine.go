@@ -189,6 +189,11 @@ type EngineOptions struct {
 
 	OnNewEngine func(Engine)
 
+	// OnCardinalityLimit, if set, is called with the number of points of a
+	// write to the database dropped for exceeding the limit, one of
+	// LimitMaxSeriesPerBucket or LimitMaxValuesPerTag.
+	OnCardinalityLimit func(database, limit string, dropped int)
+
 	FileStoreObserver FileStoreObserver
 }
 
@@ -140,6 +140,11 @@ type Shard struct {
 	index   Index
 	enabled bool
 
+	// tagValueNs are the numbers of values of the tags of the measurements,
+	// keyed by measurement and tag key, for the max-values-per-tag limit.
+	tagValueNMu sync.Mutex
+	tagValueNs  map[string]int
+
 	// expvar-based stats.
 	stats       *ShardStatistics
 	defaultTags models.StatisticTags
@@ -169,6 +174,8 @@ func NewShard(id uint64, path string, walPath string, sfile *SeriesFile, opt Eng
 		sfile:   sfile,
 		options: opt,
 
+		tagValueNs: make(map[string]int),
+
 		stats: &ShardStatistics{},
 		defaultTags: models.StatisticTags{
 			"path":            path,
@@ -560,6 +567,9 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 	// Check if keys should be unicode validated.
 	validateKeys := s.options.Config.ValidateKeys
 
+	limiter := s.newCardinalityLimiter()
+	defer limiter.done()
+
 	var j int
 	for i, p := range points {
 		tags := p.Tags()
@@ -584,6 +594,17 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 			continue
 		}
 
+		// Drop any series past the cardinality limits.
+		if r, err := limiter.check(p.Key(), p.Name(), tags); err != nil {
+			return nil, nil, err
+		} else if r != "" {
+			dropped++
+			if reason == "" {
+				reason = r
+			}
+			continue
+		}
+
 		keys[j] = p.Key()
 		names[j] = p.Name()
 		tagsSlice[j] = tags
@@ -613,6 +634,7 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 			return nil, nil, err
 		}
 	}
+	limiter.created(droppedKeys)
 
 	j = 0
 	for i, p := range points {
@@ -729,6 +751,7 @@ func (s *Shard) DeleteSeriesRange(itr SeriesIterator, min, max int64) error {
 	if err != nil {
 		return err
 	}
+	defer s.resetTagValueCounts()
 	return engine.DeleteSeriesRange(itr, min, max)
 }
 
@@ -739,6 +762,7 @@ func (s *Shard) DeleteSeriesRangeWithPredicate(itr SeriesIterator, predicate fun
 	if err != nil {
 		return err
 	}
+	defer s.resetTagValueCounts()
 	return engine.DeleteSeriesRangeWithPredicate(itr, predicate)
 }
 
@@ -748,6 +772,7 @@ func (s *Shard) DeleteMeasurement(name []byte) error {
 	if err != nil {
 		return err
 	}
+	defer s.resetTagValueCounts()
 	return engine.DeleteMeasurement(name)
 }
 
@@ -0,0 +1,212 @@
+package tsdb
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/pkg/bytesutil"
+)
+
+// Cardinality limits of the databases, as passed to EngineOptions.OnCardinalityLimit.
+const (
+	LimitMaxSeriesPerBucket = "max-series-per-bucket"
+	LimitMaxValuesPerTag    = "max-values-per-tag"
+)
+
+// cardinalityLimiter drops the points of a write to a shard that would create
+// series past the max-series-per-bucket limit of its database, or add tag
+// values past the max-values-per-tag limit of the shard.
+//
+// Only the series new to the database are checked: the values of the series
+// already in the database were checked when they were first written. The tag
+// values of the series kept are counted once the series are created.
+type cardinalityLimiter struct {
+	s         *Shard
+	maxSeries int
+	maxValues int
+
+	// seriesN is the number of series of the database, with the series of the
+	// write kept so far. It is -1 until it is needed.
+	seriesN int
+
+	// newSeries are the series kept so far, with the tag values they add.
+	// newValues are the tag values kept so far, and newValueNs their number
+	// for each tag.
+	newSeries  map[string][]tagValue
+	newValues  map[string]struct{}
+	newValueNs map[string]int
+
+	// dropped is the number of points dropped for each limit.
+	dropped map[string]int
+
+	buf []byte
+}
+
+// newCardinalityLimiter returns a limiter for a write to the shard, or nil if
+// the shard has no cardinality limits.
+func (s *Shard) newCardinalityLimiter() *cardinalityLimiter {
+	c := s.options.Config
+	if c.MaxSeriesPerBucket <= 0 && c.MaxValuesPerTag <= 0 {
+		return nil
+	}
+	return &cardinalityLimiter{
+		s:          s,
+		maxSeries:  c.MaxSeriesPerBucket,
+		maxValues:  c.MaxValuesPerTag,
+		seriesN:    -1,
+		newSeries:  make(map[string][]tagValue),
+		newValues:  make(map[string]struct{}),
+		newValueNs: make(map[string]int),
+		dropped:    make(map[string]int),
+	}
+}
+
+// check returns the reason to drop the point with the given series key, name
+// and tags, or an empty string if it can be written.
+func (l *cardinalityLimiter) check(key, name []byte, tags models.Tags) (string, error) {
+	if l == nil {
+		return "", nil
+	}
+	if _, ok := l.newSeries[string(key)]; ok {
+		return "", nil
+	}
+	if l.s.sfile.HasSeries(name, tags, l.buf) {
+		return "", nil
+	}
+
+	// values are the tag values the point adds to the shard.
+	var values []tagValue
+	if l.maxValues > 0 {
+		for _, t := range tags {
+			v := newTagValue(name, t.Key, t.Value)
+			if _, ok := l.newValues[v.value]; ok {
+				continue
+			}
+			if ok, err := l.s.index.HasTagValue(name, t.Key, t.Value); err != nil {
+				return "", err
+			} else if ok {
+				continue
+			}
+
+			l.s.tagValueNMu.Lock()
+			n, err := l.s.tagValueN(name, t.Key, v.tag)
+			l.s.tagValueNMu.Unlock()
+			if err != nil {
+				return "", err
+			}
+			n += l.newValueNs[v.tag]
+			if n >= l.maxValues {
+				l.dropped[LimitMaxValuesPerTag]++
+				return fmt.Sprintf("%s limit exceeded (%d/%d): measurement=%q tag=%q value=%q",
+					LimitMaxValuesPerTag, n, l.maxValues, name, t.Key, t.Value), nil
+			}
+			values = append(values, v)
+		}
+	}
+
+	if l.maxSeries > 0 {
+		if l.seriesN < 0 {
+			l.seriesN = int(l.s.sfile.SeriesCount())
+		}
+		if l.seriesN >= l.maxSeries {
+			l.dropped[LimitMaxSeriesPerBucket]++
+			return fmt.Sprintf("%s limit exceeded (%d/%d): measurement=%q series=%q",
+				LimitMaxSeriesPerBucket, l.seriesN, l.maxSeries, name, key), nil
+		}
+		l.seriesN++
+	}
+
+	// Keep the point, with the tag values it adds.
+	l.newSeries[string(key)] = values
+	for _, v := range values {
+		l.newValues[v.value] = struct{}{}
+		l.newValueNs[v.tag]++
+	}
+	return "", nil
+}
+
+// created counts the tag values added by the series kept, once they are
+// created. droppedKeys are the keys of the series that couldn't be created.
+func (l *cardinalityLimiter) created(droppedKeys [][]byte) {
+	if l == nil || l.maxValues <= 0 {
+		return
+	}
+
+	l.s.tagValueNMu.Lock()
+	defer l.s.tagValueNMu.Unlock()
+	for key, values := range l.newSeries {
+		if len(droppedKeys) > 0 && bytesutil.Contains(droppedKeys, []byte(key)) {
+			continue
+		}
+		for _, v := range values {
+			// The values of a tag not counted yet are counted from the index,
+			// which has the new series.
+			if _, ok := l.s.tagValueNs[v.tag]; ok {
+				l.s.tagValueNs[v.tag]++
+			}
+		}
+	}
+}
+
+// done ends the write, and reports the points dropped.
+func (l *cardinalityLimiter) done() {
+	if l == nil {
+		return
+	}
+
+	for limit, n := range l.dropped {
+		atomic.AddInt64(&l.s.stats.WritePointsDropped, int64(n))
+		if fn := l.s.options.OnCardinalityLimit; fn != nil {
+			fn(l.s.database, limit, n)
+		}
+	}
+}
+
+// tagValue identifies a tag value of a measurement. tag identifies its tag.
+type tagValue struct {
+	tag   string
+	value string
+}
+
+func newTagValue(name, key, value []byte) tagValue {
+	tag := string(name) + "\x00" + string(key)
+	return tagValue{tag: tag, value: tag + "\x00" + string(value)}
+}
+
+// tagValueN returns the number of values of the tag of a measurement of the
+// shard, counted from the index the first time. tag is the key of the counts
+// of the tag. s.tagValueNMu must be held.
+func (s *Shard) tagValueN(name, key []byte, tag string) (int, error) {
+	if n, ok := s.tagValueNs[tag]; ok {
+		return n, nil
+	}
+
+	itr, err := s.index.TagValueIterator(name, key)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if itr != nil {
+		defer itr.Close()
+		for {
+			v, err := itr.Next()
+			if err != nil {
+				return 0, err
+			} else if v == nil {
+				break
+			}
+			n++
+		}
+	}
+	s.tagValueNs[tag] = n
+	return n, nil
+}
+
+// resetTagValueCounts forgets the counts of tag values of the shard, after
+// series are deleted.
+func (s *Shard) resetTagValueCounts() {
+	s.tagValueNMu.Lock()
+	s.tagValueNs = make(map[string]int)
+	s.tagValueNMu.Unlock()
+}
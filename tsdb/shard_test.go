@@ -313,6 +313,125 @@ func TestWriteTimeField(t *testing.T) {
 	}
 }
 
+func TestShard_WritePoints_MaxValuesPerTag(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := filepath.Join(tmpDir, "shard")
+	tmpWal := filepath.Join(tmpDir, "wal")
+
+	sfile := MustOpenSeriesFile(t)
+	defer sfile.Close()
+
+	var limited []string
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.Config.MaxValuesPerTag = 2
+	opts.OnCardinalityLimit = func(database, limit string, dropped int) {
+		limited = append(limited, fmt.Sprintf("%s %d", limit, dropped))
+	}
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, sfile.SeriesFile, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	point := func(host, region string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host, "region": region}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	// The second point of serverA adds no value to host.
+	if err := sh.WritePoints([]models.Point{point("serverA", "east"), point("serverA", "west"), point("serverB", "east")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := sh.WritePoints([]models.Point{point("serverB", "west"), point("serverC", "east"), point("serverD", "east")})
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 2 {
+		t.Fatalf("expected 2 points dropped, got %d", perr.Dropped)
+	} else if exp := `max-values-per-tag limit exceeded (2/2): measurement="cpu" tag="host" value="serverC"`; perr.Reason != exp {
+		t.Fatalf("unexpected reason: got %q, exp %q", perr.Reason, exp)
+	}
+	if exp := []string{"max-values-per-tag 2"}; !reflect.DeepEqual(limited, exp) {
+		t.Fatalf("unexpected limits reported: got %v, exp %v", limited, exp)
+	}
+
+	if got, exp := sh.SeriesN(), int64(4); got != exp {
+		t.Fatalf("unexpected number of series: got %d, exp %d", got, exp)
+	}
+
+	// The values added by a write count toward the limit for the rest of it.
+	mem := func(host string) models.Point {
+		return models.MustNewPoint(
+			"mem",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+	err = sh.WritePoints([]models.Point{mem("serverA"), mem("serverB"), mem("serverC")})
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if exp := `max-values-per-tag limit exceeded (2/2): measurement="mem" tag="host" value="serverC"`; perr.Reason != exp {
+		t.Fatalf("unexpected reason: got %q, exp %q", perr.Reason, exp)
+	}
+	if got, exp := sh.SeriesN(), int64(6); got != exp {
+		t.Fatalf("unexpected number of series: got %d, exp %d", got, exp)
+	}
+}
+
+func TestShard_WritePoints_MaxSeriesPerBucket(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := filepath.Join(tmpDir, "shard")
+	tmpWal := filepath.Join(tmpDir, "wal")
+
+	sfile := MustOpenSeriesFile(t)
+	defer sfile.Close()
+
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.Config.MaxSeriesPerBucket = 2
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, sfile.SeriesFile, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	point := func(host string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	err := sh.WritePoints([]models.Point{point("serverA"), point("serverB"), point("serverA"), point("serverC")})
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 1 {
+		t.Fatalf("expected 1 point dropped, got %d", perr.Dropped)
+	} else if exp := `max-series-per-bucket limit exceeded (2/2): measurement="cpu" series="cpu,host=serverC"`; perr.Reason != exp {
+		t.Fatalf("unexpected reason: got %q, exp %q", perr.Reason, exp)
+	}
+
+	// Existing series can still be written.
+	if err := sh.WritePoints([]models.Point{point("serverA"), point("serverB")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, exp := sh.SeriesN(), int64(2); got != exp {
+		t.Fatalf("unexpected number of series: got %d, exp %d", got, exp)
+	}
+}
+
 func TestShardWriteAddNewField(t *testing.T) {
 	tmpDir, _ := ioutil.TempDir("", "shard_test")
 	defer os.RemoveAll(tmpDir)
@@ -1008,15 +1008,20 @@ func (s *Store) DiskSize() (int64, error) {
 // sketchesForDatabase returns merged sketches for the provided database, by
 // walking each shard in the database and merging the sketches found there.
 func (s *Store) sketchesForDatabase(dbName string, getSketches func(*Shard) (estimator.Sketch, estimator.Sketch, error)) (estimator.Sketch, estimator.Sketch, error) {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(dbName))
+	s.mu.RUnlock()
+
+	return sketchesForShards(shards, getSketches)
+}
+
+// sketchesForShards returns merged sketches for the provided shards.
+func sketchesForShards(shards []*Shard, getSketches func(*Shard) (estimator.Sketch, estimator.Sketch, error)) (estimator.Sketch, estimator.Sketch, error) {
 	var (
 		ss estimator.Sketch // Sketch estimating number of items.
 		ts estimator.Sketch // Sketch estimating number of tombstoned items.
 	)
 
-	s.mu.RLock()
-	shards := s.filterShards(byDatabase(dbName))
-	s.mu.RUnlock()
-
 	// Never return nil sketches. In the case that db exists but no data written
 	// return empty sketches.
 	if len(shards) == 0 {
@@ -1121,6 +1126,23 @@ func (s *Store) MeasurementsSketches(database string) (estimator.Sketch, estimat
 	})
 }
 
+// SeriesSketchesByShards returns the sketches associated with the series data
+// in the provided shards. Shards that do not exist on this node are ignored.
+func (s *Store) SeriesSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error) {
+	return sketchesForShards(s.Shards(shardIDs), func(sh *Shard) (estimator.Sketch, estimator.Sketch, error) {
+		return sh.SeriesSketches()
+	})
+}
+
+// MeasurementsSketchesByShards returns the sketches associated with the
+// measurement data in the provided shards. Shards that do not exist on this
+// node are ignored.
+func (s *Store) MeasurementsSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error) {
+	return sketchesForShards(s.Shards(shardIDs), func(sh *Shard) (estimator.Sketch, estimator.Sketch, error) {
+		return sh.MeasurementsSketches()
+	})
+}
+
 // BackupShard will get the shard and have the engine backup since the passed in
 // time to the writer.
 func (s *Store) BackupShard(id uint64, since time.Time, w io.Writer) error {
@@ -1457,6 +1479,214 @@ func (s *Store) MeasurementSeriesCounts(database string) (measuments int, series
 	return 0, 0
 }
 
+// indexSetForShards returns an IndexSet over the indexes of the provided
+// shards. Shards that do not exist on this node are ignored.
+func (s *Store) indexSetForShards(shardIDs []uint64) (IndexSet, error) {
+	is := IndexSet{Indexes: make([]Index, 0, len(shardIDs))}
+	for _, sh := range s.Shards(shardIDs) {
+		if is.SeriesFile == nil {
+			sfile, err := sh.SeriesFile()
+			if err != nil {
+				return IndexSet{}, err
+			}
+			is.SeriesFile = sfile
+		}
+
+		index, err := sh.Index()
+		if err != nil {
+			return IndexSet{}, err
+		}
+		is.Indexes = append(is.Indexes, index)
+	}
+	return is, nil
+}
+
+// MeasurementNamesByShards returns the measurements in the provided shards
+// matching the optional condition.
+func (s *Store) MeasurementNamesByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+	is, err := s.indexSetForShards(shardIDs)
+	if err != nil {
+		return nil, err
+	} else if is.SeriesFile == nil {
+		return nil, nil
+	}
+	return is.MeasurementNamesByExpr(auth, cond)
+}
+
+// SeriesCardinality is the exact number of series in a single measurement.
+type SeriesCardinality struct {
+	Measurement string
+	N           int64
+}
+
+// SeriesCardinalityByShards returns the exact series cardinality of each
+// measurement in the provided shards matching the optional condition. The
+// condition may reference the measurement name via _name as well as tags, and
+// is evaluated as a whole against the series of each measurement.
+//
+// A series present in more than one shard is counted once.
+func (s *Store) SeriesCardinalityByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]SeriesCardinality, error) {
+	is, err := s.indexSetForShards(shardIDs)
+	if err != nil {
+		return nil, err
+	} else if is.SeriesFile == nil {
+		return nil, nil
+	}
+
+	names, err := is.MeasurementNamesByExpr(auth, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	release := is.SeriesFile.Retain()
+	defer release()
+
+	results := make([]SeriesCardinality, 0, len(names))
+	for _, name := range names {
+		n, err := s.measurementSeriesCardinality(auth, is, name, cond)
+		if err != nil {
+			return nil, err
+		} else if n == 0 {
+			continue
+		}
+		results = append(results, SeriesCardinality{Measurement: string(name), N: n})
+	}
+	return results, nil
+}
+
+// measurementSeriesCardinality counts the distinct series of a measurement
+// matching expr that auth is permitted to read.
+func (s *Store) measurementSeriesCardinality(auth query.Authorizer, is IndexSet, name []byte, expr influxql.Expr) (int64, error) {
+	ids, err := s.measurementSeriesIDs(auth, is, name, expr)
+	if err != nil {
+		return 0, err
+	}
+	return int64(ids.Cardinality()), nil
+}
+
+// measurementSeriesIDs returns the distinct series of a measurement matching
+// expr that auth is permitted to read.
+func (s *Store) measurementSeriesIDs(auth query.Authorizer, is IndexSet, name []byte, expr influxql.Expr) (*SeriesIDSet, error) {
+	ids := NewSeriesIDSet()
+	itr, err := is.measurementSeriesByExprIterator(name, expr)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return ids, nil
+	}
+	defer itr.Close()
+
+	open := query.AuthorizerIsOpen(auth)
+	for {
+		e, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if e.SeriesID == 0 {
+			break
+		}
+
+		if !open {
+			sname, tags := is.SeriesFile.Series(e.SeriesID)
+			if !auth.AuthorizeSeriesRead(is.Database(), sname, tags) {
+				continue
+			}
+		}
+		ids.AddNoLock(e.SeriesID)
+	}
+	return ids, nil
+}
+
+// SeriesKeysByShards returns the sorted keys of the series in the provided
+// shards matching the optional condition. The condition may reference the
+// measurement name via _name as well as tags.
+//
+// A series present in more than one shard is listed once.
+func (s *Store) SeriesKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error) {
+	is, err := s.indexSetForShards(shardIDs)
+	if err != nil {
+		return nil, err
+	} else if is.SeriesFile == nil {
+		return nil, nil
+	}
+
+	names, err := is.MeasurementNamesByExpr(auth, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	release := is.SeriesFile.Retain()
+	defer release()
+
+	var keys []string
+	for _, name := range names {
+		ids, err := s.measurementSeriesIDs(auth, is, name, cond)
+		if err != nil {
+			return nil, err
+		}
+		ids.ForEachNoLock(func(id uint64) {
+			sname, tags := is.SeriesFile.Series(id)
+			keys = append(keys, string(models.MakeKey(sname, tags)))
+		})
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// FieldKeys is the set of field keys of a single measurement, with the type
+// of each key.
+type FieldKeys struct {
+	Measurement string
+	Keys        []string
+	Types       []influxql.DataType
+}
+
+// FieldKeysByShards returns the field keys of each measurement in the provided
+// shards that has a series matching the optional condition. The condition may
+// reference the measurement name via _name as well as tags.
+//
+// The field keys of a measurement are the union of its fields in each shard
+// containing a matching series. The type of a key is its type in the last of
+// those shards.
+func (s *Store) FieldKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]FieldKeys, error) {
+	fields := make(map[string]map[string]influxql.DataType)
+	for _, sh := range s.Shards(shardIDs) {
+		names, err := s.MeasurementNamesByShards(auth, []uint64{sh.ID()}, cond)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			mf := sh.MeasurementFields(name)
+			if mf == nil {
+				continue
+			}
+			mf.ForEachField(func(k string, typ influxql.DataType) bool {
+				if fields[string(name)] == nil {
+					fields[string(name)] = make(map[string]influxql.DataType)
+				}
+				fields[string(name)][k] = typ
+				return true
+			})
+		}
+	}
+
+	results := make([]FieldKeys, 0, len(fields))
+	for name, set := range fields {
+		fk := FieldKeys{Measurement: name, Keys: make([]string, 0, len(set))}
+		for k := range set {
+			fk.Keys = append(fk.Keys, k)
+		}
+		sort.Strings(fk.Keys)
+		fk.Types = make([]influxql.DataType, len(fk.Keys))
+		for i, k := range fk.Keys {
+			fk.Types[i] = set[k]
+		}
+		results = append(results, fk)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Measurement < results[j].Measurement })
+	return results, nil
+}
+
 type TagKeys struct {
 	Measurement string
 	Keys        []string
@@ -820,6 +820,253 @@ func TestStore_MeasurementNames_Deduplicate(t *testing.T) {
 	}
 }
 
+func TestStore_SeriesCardinalityByShards(t *testing.T) {
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(t, index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1 0`,
+			`cpu,host=serverB value=1 0`,
+			`mem,host=serverA value=1 0`,
+		)
+
+		// The same series in a 2nd shard must only be counted once.
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			`cpu,host=serverA value=1 10`,
+			`cpu,host=serverC value=1 10`,
+			`disk,host=serverA value=1 10`,
+		)
+
+		for _, tt := range []struct {
+			shardIDs []uint64
+			cond     string
+			exp      []tsdb.SeriesCardinality
+			names    int
+		}{
+			{
+				shardIDs: []uint64{1, 2},
+				exp: []tsdb.SeriesCardinality{
+					{Measurement: "cpu", N: 3},
+					{Measurement: "disk", N: 1},
+					{Measurement: "mem", N: 1},
+				},
+				names: 3,
+			},
+			{
+				shardIDs: []uint64{2},
+				exp: []tsdb.SeriesCardinality{
+					{Measurement: "cpu", N: 2},
+					{Measurement: "disk", N: 1},
+				},
+				names: 2,
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `_name = 'cpu' AND host =~ /server[AB]/`,
+				exp: []tsdb.SeriesCardinality{
+					{Measurement: "cpu", N: 2},
+				},
+				names: 1,
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `host = 'serverA' OR _name = 'cpu'`,
+				exp: []tsdb.SeriesCardinality{
+					{Measurement: "cpu", N: 3},
+					{Measurement: "disk", N: 1},
+					{Measurement: "mem", N: 1},
+				},
+				names: 3,
+			},
+			{
+				shardIDs: []uint64{3},
+			},
+		} {
+			var cond influxql.Expr
+			if tt.cond != "" {
+				cond = influxql.MustParseExpr(tt.cond)
+			}
+
+			got, err := s.SeriesCardinalityByShards(query.OpenAuthorizer, tt.shardIDs, cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 || len(tt.exp) != 0 {
+				if !reflect.DeepEqual(got, tt.exp) {
+					t.Errorf("shards %v, cond %q: got %v, expected %v", tt.shardIDs, tt.cond, got, tt.exp)
+				}
+			}
+
+			names, err := s.MeasurementNamesByShards(query.OpenAuthorizer, tt.shardIDs, cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(names) != tt.names {
+				t.Errorf("shards %v, cond %q: got %d measurements, expected %d", tt.shardIDs, tt.cond, len(names), tt.names)
+			}
+		}
+
+		ss, ts, err := s.SeriesSketchesByShards([]uint64{2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := ss.Count()-ts.Count(), uint64(3); got != exp {
+			t.Errorf("got estimated series cardinality %d, expected %d", got, exp)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+func TestStore_SeriesKeysByShards(t *testing.T) {
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(t, index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1 0`,
+			`cpu,host=serverB,region=east value=1 0`,
+			`mem,host=serverA value=1 0`,
+		)
+
+		// The same series in a 2nd shard must only be listed once.
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			`cpu,host=serverA value=1 10`,
+			`disk,host=serverC value=1 10`,
+		)
+
+		for _, tt := range []struct {
+			shardIDs []uint64
+			cond     string
+			exp      []string
+		}{
+			{
+				shardIDs: []uint64{1, 2},
+				exp:      []string{"cpu,host=serverA", "cpu,host=serverB,region=east", "disk,host=serverC", "mem,host=serverA"},
+			},
+			{
+				shardIDs: []uint64{2},
+				exp:      []string{"cpu,host=serverA", "disk,host=serverC"},
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `_name = 'cpu' AND region = 'east'`,
+				exp:      []string{"cpu,host=serverB,region=east"},
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `host = 'serverA' OR _name = 'disk'`,
+				exp:      []string{"cpu,host=serverA", "disk,host=serverC", "mem,host=serverA"},
+			},
+			{
+				shardIDs: []uint64{3},
+			},
+		} {
+			var cond influxql.Expr
+			if tt.cond != "" {
+				cond = influxql.MustParseExpr(tt.cond)
+			}
+
+			got, err := s.SeriesKeysByShards(query.OpenAuthorizer, tt.shardIDs, cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 || len(tt.exp) != 0 {
+				if !reflect.DeepEqual(got, tt.exp) {
+					t.Errorf("shards %v, cond %q: got %v, expected %v", tt.shardIDs, tt.cond, got, tt.exp)
+				}
+			}
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+func TestStore_FieldKeysByShards(t *testing.T) {
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(t, index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1,idle=2i 0`,
+			`mem,host=serverB free=1 0`,
+		)
+
+		// The fields of a measurement are merged across shards.
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			`cpu,host=serverC value=1,user=2 10`,
+			`disk,host=serverA used=1 10`,
+		)
+
+		for _, tt := range []struct {
+			shardIDs []uint64
+			cond     string
+			exp      []tsdb.FieldKeys
+		}{
+			{
+				shardIDs: []uint64{1, 2},
+				exp: []tsdb.FieldKeys{
+					{Measurement: "cpu", Keys: []string{"idle", "user", "value"}, Types: []influxql.DataType{influxql.Integer, influxql.Float, influxql.Float}},
+					{Measurement: "disk", Keys: []string{"used"}, Types: []influxql.DataType{influxql.Float}},
+					{Measurement: "mem", Keys: []string{"free"}, Types: []influxql.DataType{influxql.Float}},
+				},
+			},
+			{
+				shardIDs: []uint64{2},
+				exp: []tsdb.FieldKeys{
+					{Measurement: "cpu", Keys: []string{"user", "value"}, Types: []influxql.DataType{influxql.Float, influxql.Float}},
+					{Measurement: "disk", Keys: []string{"used"}, Types: []influxql.DataType{influxql.Float}},
+				},
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `host = 'serverA'`,
+				exp: []tsdb.FieldKeys{
+					{Measurement: "cpu", Keys: []string{"idle", "value"}, Types: []influxql.DataType{influxql.Integer, influxql.Float}},
+					{Measurement: "disk", Keys: []string{"used"}, Types: []influxql.DataType{influxql.Float}},
+				},
+			},
+			{
+				shardIDs: []uint64{1, 2},
+				cond:     `_name = 'mem'`,
+				exp: []tsdb.FieldKeys{
+					{Measurement: "mem", Keys: []string{"free"}, Types: []influxql.DataType{influxql.Float}},
+				},
+			},
+			{
+				shardIDs: []uint64{3},
+			},
+		} {
+			var cond influxql.Expr
+			if tt.cond != "" {
+				cond = influxql.MustParseExpr(tt.cond)
+			}
+
+			got, err := s.FieldKeysByShards(query.OpenAuthorizer, tt.shardIDs, cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 || len(tt.exp) != 0 {
+				if !reflect.DeepEqual(got, tt.exp) {
+					t.Errorf("shards %v, cond %q: got %v, expected %v", tt.shardIDs, tt.cond, got, tt.exp)
+				}
+			}
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
 func testStoreCardinalityTombstoning(t *testing.T, store *Store) {
 	// Generate point data to write to the shards.
 	series := genTestSeries(10, 2, 4) // 160 series
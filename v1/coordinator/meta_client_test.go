@@ -32,6 +32,7 @@ type MetaClient struct {
 	SetAdminPrivilegeFn                 func(username string, admin bool) error
 	SetPrivilegeFn                      func(username, database string, p influxql.Privilege) error
 	ShardGroupsByTimeRangeFn            func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	ShardOwnerFn                        func(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
 	TruncateShardGroupsFn               func(t time.Time) error
 	UpdateRetentionPolicyFn             func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUserFn                        func(name, password string) error
@@ -132,6 +133,10 @@ func (c *MetaClient) ShardGroupsByTimeRange(database, policy string, min, max ti
 	return c.ShardGroupsByTimeRangeFn(database, policy, min, max)
 }
 
+func (c *MetaClient) ShardOwner(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo) {
+	return c.ShardOwnerFn(shardID)
+}
+
 func (c *MetaClient) TruncateShardGroups(t time.Time) error {
 	return c.TruncateShardGroupsFn(t)
 }
@@ -4,17 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/authorizer"
+	icontext "github.com/influxdata/influxdb/v2/context"
 	iql "github.com/influxdata/influxdb/v2/influxql"
 	"github.com/influxdata/influxdb/v2/influxql/query"
 	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/pkg/estimator"
 	"github.com/influxdata/influxdb/v2/pkg/tracing"
 	"github.com/influxdata/influxdb/v2/pkg/tracing/fields"
+	transpiler "github.com/influxdata/influxdb/v2/query/influxql"
 	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/v1/monitor"
 	"github.com/influxdata/influxdb/v2/v1/services/meta"
 	"github.com/influxdata/influxql"
 )
@@ -35,6 +42,21 @@ type StatementExecutor struct {
 
 	DBRP influxdb.DBRPMappingServiceV2
 
+	// BucketService manages the buckets backing the databases and retention
+	// policies created or dropped through InfluxQL.
+	BucketService influxdb.BucketService
+
+	// QueryTracker lists and kills the queries running in the query executor.
+	QueryTracker *query.QueryTracker
+
+	// Monitor provides the internal statistics and diagnostics
+	// for SHOW STATS and SHOW DIAGNOSTICS.
+	Monitor *monitor.Monitor
+
+	// TaskService and LabelService manage the tasks emulating continuous queries.
+	TaskService  influxdb.TaskService
+	LabelService influxdb.LabelService
+
 	// Select statement limits
 	MaxSelectPointN   int
 	MaxSelectSeriesN  int
@@ -53,13 +75,13 @@ func (e *StatementExecutor) ExecuteStatement(ctx context.Context, stmt influxql.
 	var err error
 	switch stmt := stmt.(type) {
 	case *influxql.AlterRetentionPolicyStatement:
-		err = iql.ErrNotImplemented("ALTER RETENTION POLICY")
+		err = e.executeAlterRetentionPolicyStatement(ctx, stmt, ectx)
 	case *influxql.CreateContinuousQueryStatement:
-		err = iql.ErrNotImplemented("CREATE CONTINUOUS QUERY")
+		err = e.executeCreateContinuousQueryStatement(ctx, stmt, ectx)
 	case *influxql.CreateDatabaseStatement:
-		err = iql.ErrNotImplemented("CREATE DATABASE")
+		err = e.executeCreateDatabaseStatement(ctx, stmt, ectx)
 	case *influxql.CreateRetentionPolicyStatement:
-		err = iql.ErrNotImplemented("CREATE RETENTION POLICY")
+		err = e.executeCreateRetentionPolicyStatement(ctx, stmt, ectx)
 	case *influxql.CreateSubscriptionStatement:
 		err = iql.ErrNotImplemented("CREATE SUBSCRIPTION")
 	case *influxql.CreateUserStatement:
@@ -67,17 +89,17 @@ func (e *StatementExecutor) ExecuteStatement(ctx context.Context, stmt influxql.
 	case *influxql.DeleteSeriesStatement:
 		return e.executeDeleteSeriesStatement(ctx, stmt, ectx.Database, ectx)
 	case *influxql.DropContinuousQueryStatement:
-		err = iql.ErrNotImplemented("DROP CONTINUOUS QUERY")
+		err = e.executeDropContinuousQueryStatement(ctx, stmt, ectx)
 	case *influxql.DropDatabaseStatement:
-		err = iql.ErrNotImplemented("DROP DATABASE")
+		err = e.executeDropDatabaseStatement(ctx, stmt, ectx)
 	case *influxql.DropMeasurementStatement:
 		return e.executeDropMeasurementStatement(ctx, stmt, ectx.Database, ectx)
 	case *influxql.DropSeriesStatement:
-		err = iql.ErrNotImplemented("DROP SERIES")
+		err = e.executeDropSeriesStatement(ctx, stmt, ectx.Database, ectx)
 	case *influxql.DropRetentionPolicyStatement:
-		err = iql.ErrNotImplemented("DROP RETENTION POLICY")
+		err = e.executeDropRetentionPolicyStatement(ctx, stmt, ectx)
 	case *influxql.DropShardStatement:
-		err = iql.ErrNotImplemented("DROP SHARD")
+		err = e.executeDropShardStatement(ctx, stmt, ectx)
 	case *influxql.DropSubscriptionStatement:
 		err = iql.ErrNotImplemented("DROP SUBSCRIPTION")
 	case *influxql.DropUserStatement:
@@ -97,27 +119,33 @@ func (e *StatementExecutor) ExecuteStatement(ctx context.Context, stmt influxql.
 	case *influxql.RevokeAdminStatement:
 		err = iql.ErrNotImplemented("REVOKE ALL")
 	case *influxql.ShowContinuousQueriesStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW CONTINUOUS QUERIES")
+		rows, err = e.executeShowContinuousQueriesStatement(ctx, stmt, ectx)
 	case *influxql.ShowDatabasesStatement:
 		rows, err = e.executeShowDatabasesStatement(ctx, stmt, ectx)
 	case *influxql.ShowDiagnosticsStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW DIAGNOSTICS")
+		rows, err = e.executeShowDiagnosticsStatement(ctx, stmt)
+	case *influxql.ShowFieldKeyCardinalityStatement:
+		rows, err = e.executeShowFieldKeyCardinalityStatement(ctx, stmt, ectx)
+	case *influxql.ShowFieldKeysStatement:
+		return e.executeShowFieldKeysStatement(ctx, stmt, ectx)
 	case *influxql.ShowGrantsForUserStatement:
 		rows, err = nil, iql.ErrNotImplemented("SHOW GRANTS")
 	case *influxql.ShowMeasurementsStatement:
 		return e.executeShowMeasurementsStatement(ctx, stmt, ectx)
 	case *influxql.ShowMeasurementCardinalityStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW MEASUREMENT CARDINALITY")
+		rows, err = e.executeShowMeasurementCardinalityStatement(ctx, stmt, ectx)
 	case *influxql.ShowRetentionPoliciesStatement:
 		rows, err = e.executeShowRetentionPoliciesStatement(ctx, stmt, ectx)
+	case *influxql.ShowSeriesStatement:
+		rows, err = e.executeShowSeriesStatement(ctx, stmt, ectx)
 	case *influxql.ShowSeriesCardinalityStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW SERIES CARDINALITY")
+		rows, err = e.executeShowSeriesCardinalityStatement(ctx, stmt, ectx)
 	case *influxql.ShowShardsStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW SHARDS")
+		rows, err = e.executeShowShardsStatement(ctx, stmt, ectx)
 	case *influxql.ShowShardGroupsStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW SHARD GROUPS")
+		rows, err = e.executeShowShardGroupsStatement(ctx, stmt, ectx)
 	case *influxql.ShowStatsStatement:
-		rows, err = nil, iql.ErrNotImplemented("SHOW STATS")
+		rows, err = e.executeShowStatsStatement(ctx, stmt)
 	case *influxql.ShowSubscriptionsStatement:
 		rows, err = nil, iql.ErrNotImplemented("SHOW SUBSCRIPTIONS")
 	case *influxql.ShowTagKeysStatement:
@@ -128,8 +156,10 @@ func (e *StatementExecutor) ExecuteStatement(ctx context.Context, stmt influxql.
 		rows, err = nil, iql.ErrNotImplemented("SHOW USERS")
 	case *influxql.SetPasswordUserStatement:
 		err = iql.ErrNotImplemented("SET PASSWORD")
-	case *influxql.ShowQueriesStatement, *influxql.KillQueryStatement:
-		err = iql.ErrNotImplemented("SHOW QUERIES")
+	case *influxql.ShowQueriesStatement:
+		rows, err = e.executeShowQueriesStatement(stmt, ectx)
+	case *influxql.KillQueryStatement:
+		err = e.QueryTracker.Kill(ectx.OrgID, stmt.QueryID)
 	default:
 		return query.ErrInvalidQuery
 	}
@@ -144,6 +174,423 @@ func (e *StatementExecutor) ExecuteStatement(ctx context.Context, stmt influxql.
 	})
 }
 
+// ContinuousQueryLabel is the name of the label attached to the tasks
+// emulating continuous queries.
+const ContinuousQueryLabel = "influxql-continuous-query"
+
+// Continuous queries are emulated by tasks. The select statement of the
+// continuous query is transpiled into the script of a task owned by the user
+// executing the statement, which is labeled with ContinuousQueryLabel. The
+// name of the task is the name of the continuous query and its description is
+// the original statement, which records the database of the continuous query.
+
+// continuousQuery is a continuous query emulated by a task.
+type continuousQuery struct {
+	task *influxdb.Task
+	stmt *influxql.CreateContinuousQueryStatement
+}
+
+func (e *StatementExecutor) executeCreateContinuousQueryStatement(ctx context.Context, stmt *influxql.CreateContinuousQueryStatement, ectx *query.ExecutionContext) error {
+	auth, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	cqs, err := e.continuousQueries(ctx, ectx.OrgID)
+	if err != nil {
+		return err
+	}
+	for _, cq := range cqs {
+		if cq.stmt.Database == stmt.Database && cq.stmt.Name == stmt.Name {
+			return meta.ErrContinuousQueryExists
+		}
+	}
+
+	pkg, err := transpiler.NewTranspiler(e.DBRP).TranspileContinuousQuery(ctx, stmt, ectx.OrgID)
+	if err != nil {
+		return err
+	}
+
+	label, err := e.continuousQueryLabel(ctx, ectx.OrgID, true)
+	if err != nil {
+		return err
+	}
+
+	task, err := e.TaskService.CreateTask(ctx, influxdb.TaskCreate{
+		Flux:           ast.Format(pkg),
+		Description:    stmt.String(),
+		Status:         string(influxdb.TaskActive),
+		OrganizationID: ectx.OrgID,
+		OwnerID:        auth.GetUserID(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := e.LabelService.CreateLabelMapping(ctx, &influxdb.LabelMapping{
+		LabelID:      label.ID,
+		ResourceID:   task.ID,
+		ResourceType: influxdb.TasksResourceType,
+	}); err != nil {
+		// Without the label the task would no longer be seen as a continuous query.
+		_ = e.TaskService.DeleteTask(ctx, task.ID)
+		return err
+	}
+	return nil
+}
+
+func (e *StatementExecutor) executeDropContinuousQueryStatement(ctx context.Context, stmt *influxql.DropContinuousQueryStatement, ectx *query.ExecutionContext) error {
+	cqs, err := e.continuousQueries(ctx, ectx.OrgID)
+	if err != nil {
+		return err
+	}
+
+	for _, cq := range cqs {
+		if cq.stmt.Database != stmt.Database || cq.stmt.Name != stmt.Name {
+			continue
+		}
+		return e.TaskService.DeleteTask(ctx, cq.task.ID)
+	}
+	return meta.ErrContinuousQueryNotFound
+}
+
+func (e *StatementExecutor) executeShowContinuousQueriesStatement(ctx context.Context, stmt *influxql.ShowContinuousQueriesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	dbrps, err := e.readableMappings(ctx, ectx)
+	if err != nil {
+		return nil, err
+	}
+	cqs, err := e.continuousQueries(ctx, ectx.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every database is listed, including those without continuous queries.
+	rows := []*models.Row{}
+	byDatabase := make(map[string]*models.Row, len(dbrps))
+	for _, dbrp := range dbrps {
+		if _, ok := byDatabase[dbrp.Database]; ok {
+			continue
+		}
+		row := &models.Row{Name: dbrp.Database, Columns: []string{"name", "query"}}
+		byDatabase[dbrp.Database] = row
+		rows = append(rows, row)
+	}
+	for _, cq := range cqs {
+		row, ok := byDatabase[cq.stmt.Database]
+		if !ok {
+			continue
+		}
+		row.Values = append(row.Values, []interface{}{cq.stmt.Name, cq.stmt.String()})
+	}
+	return rows, nil
+}
+
+// continuousQueries returns the continuous queries of the organization.
+func (e *StatementExecutor) continuousQueries(ctx context.Context, orgID influxdb.ID) ([]continuousQuery, error) {
+	label, err := e.continuousQueryLabel(ctx, orgID, false)
+	if err != nil || label == nil {
+		return nil, err
+	}
+
+	var cqs []continuousQuery
+	filter := influxdb.TaskFilter{OrganizationID: &orgID, Limit: influxdb.TaskMaxPageSize}
+	for {
+		tasks, _, err := e.TaskService.FindTasks(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			labels, err := e.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{
+				ResourceID:   task.ID,
+				ResourceType: influxdb.TasksResourceType,
+			})
+			if err != nil {
+				return nil, err
+			} else if !hasLabel(labels, label.ID) {
+				continue
+			}
+
+			// Ignore tasks that were labeled by hand.
+			stmt, err := influxql.ParseStatement(task.Description)
+			if err != nil {
+				continue
+			}
+			if stmt, ok := stmt.(*influxql.CreateContinuousQueryStatement); ok {
+				cqs = append(cqs, continuousQuery{task: task, stmt: stmt})
+			}
+		}
+
+		if len(tasks) < filter.Limit {
+			return cqs, nil
+		}
+		filter.After = &tasks[len(tasks)-1].ID
+	}
+}
+
+// continuousQueryLabel returns the label of the tasks emulating continuous
+// queries in the organization. If the label does not exist, it is created
+// when create is set and nil is returned otherwise.
+func (e *StatementExecutor) continuousQueryLabel(ctx context.Context, orgID influxdb.ID, create bool) (*influxdb.Label, error) {
+	labels, err := e.LabelService.FindLabels(ctx, influxdb.LabelFilter{
+		Name:  ContinuousQueryLabel,
+		OrgID: &orgID,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(labels) > 0 {
+		return labels[0], nil
+	} else if !create {
+		return nil, nil
+	}
+
+	label := &influxdb.Label{
+		OrgID: orgID,
+		Name:  ContinuousQueryLabel,
+		Properties: map[string]string{
+			"description": "Task emulating an InfluxQL continuous query",
+		},
+	}
+	if err := e.LabelService.CreateLabel(ctx, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+func hasLabel(labels []*influxdb.Label, id influxdb.ID) bool {
+	for _, l := range labels {
+		if l.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Databases and retention policies created through InfluxQL are mapped onto
+// buckets as follows:
+//
+//  - database "db" with retention policy "rp" is stored in the bucket named
+//    "db/rp" of the organization executing the statement, the same name
+//    influxd upgrade gives to migrated retention policies;
+//  - a DBRP mapping from (db, rp) to that bucket is created alongside it,
+//    marked as the default when the statement asks for it;
+//  - the duration of the retention policy is the retention period of the
+//    bucket, with zero meaning infinite retention.
+//
+// Replication factors and shard group durations are accepted for
+// compatibility but otherwise ignored.
+//
+// Dropping a retention policy removes its mapping. The bucket is removed as
+// well when it follows the naming scheme above and no other mapping refers to
+// it, so buckets created through the v2 API and mapped manually are kept.
+
+// bucketName returns the name of the bucket backing the retention policy rp
+// of database db.
+func bucketName(db, rp string) string {
+	return db + "/" + rp
+}
+
+func (e *StatementExecutor) executeCreateDatabaseStatement(ctx context.Context, stmt *influxql.CreateDatabaseStatement, ectx *query.ExecutionContext) error {
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		Database: &stmt.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Creating a database that already exists is a no-op, unless it
+	// explicitly requests a retention policy.
+	if len(mappings) > 0 && !stmt.RetentionPolicyCreate {
+		return nil
+	}
+
+	rpName := meta.DefaultRetentionPolicyName
+	if stmt.RetentionPolicyName != "" {
+		rpName = stmt.RetentionPolicyName
+	}
+	var duration time.Duration
+	if stmt.RetentionPolicyDuration != nil {
+		duration = *stmt.RetentionPolicyDuration
+	}
+
+	for _, m := range mappings {
+		if m.RetentionPolicy != rpName {
+			continue
+		}
+		// The retention policy exists, so it must not conflict.
+		b, err := e.BucketService.FindBucketByID(ctx, m.BucketID)
+		if err != nil {
+			return err
+		}
+		if b.RetentionPeriod != duration {
+			return meta.ErrRetentionPolicyConflict
+		}
+		return nil
+	}
+
+	return e.createRetentionPolicy(ctx, ectx.OrgID, stmt.Name, rpName, duration, true)
+}
+
+func (e *StatementExecutor) executeCreateRetentionPolicyStatement(ctx context.Context, stmt *influxql.CreateRetentionPolicyStatement, ectx *query.ExecutionContext) error {
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		Database: &stmt.Database,
+	})
+	if err != nil {
+		return err
+	} else if len(mappings) == 0 {
+		return query.ErrDatabaseNotFound(stmt.Database)
+	}
+
+	for _, m := range mappings {
+		if m.RetentionPolicy != stmt.Name {
+			continue
+		}
+		// Creating an identical retention policy is a no-op.
+		b, err := e.BucketService.FindBucketByID(ctx, m.BucketID)
+		if err != nil {
+			return err
+		}
+		if b.RetentionPeriod != stmt.Duration || (stmt.Default && !m.Default) {
+			return meta.ErrRetentionPolicyExists
+		}
+		return nil
+	}
+
+	return e.createRetentionPolicy(ctx, ectx.OrgID, stmt.Database, stmt.Name, stmt.Duration, stmt.Default)
+}
+
+// createRetentionPolicy creates or updates the bucket backing the retention
+// policy and maps the database and retention policy onto it.
+func (e *StatementExecutor) createRetentionPolicy(ctx context.Context, orgID influxdb.ID, db, rp string, duration time.Duration, isDefault bool) error {
+	name := bucketName(db, rp)
+	b, err := e.BucketService.FindBucketByName(ctx, orgID, name)
+	switch {
+	case influxdb.ErrorCode(err) == influxdb.ENotFound:
+		b = &influxdb.Bucket{
+			OrgID:               orgID,
+			Type:                influxdb.BucketTypeUser,
+			Name:                name,
+			Description:         fmt.Sprintf("Created by InfluxQL for database %s with retention policy %s", db, rp),
+			RetentionPolicyName: rp,
+			RetentionPeriod:     duration,
+		}
+		if err := e.BucketService.CreateBucket(ctx, b); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case b.RetentionPeriod != duration:
+		if b, err = e.BucketService.UpdateBucket(ctx, b.ID, influxdb.BucketUpdate{RetentionPeriod: &duration}); err != nil {
+			return err
+		}
+	}
+
+	return e.DBRP.Create(ctx, &influxdb.DBRPMappingV2{
+		Database:        db,
+		RetentionPolicy: rp,
+		Default:         isDefault,
+		OrganizationID:  orgID,
+		BucketID:        b.ID,
+	})
+}
+
+func (e *StatementExecutor) executeAlterRetentionPolicyStatement(ctx context.Context, stmt *influxql.AlterRetentionPolicyStatement, ectx *query.ExecutionContext) error {
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:           &ectx.OrgID,
+		Database:        &stmt.Database,
+		RetentionPolicy: &stmt.Name,
+	})
+	if err != nil {
+		return err
+	} else if len(mappings) == 0 {
+		return meta.ErrRetentionPolicyNotFound
+	}
+	mapping := mappings[0]
+
+	if stmt.Duration != nil {
+		if _, err := e.BucketService.UpdateBucket(ctx, mapping.BucketID, influxdb.BucketUpdate{RetentionPeriod: stmt.Duration}); err != nil {
+			return err
+		}
+	}
+
+	if stmt.Default && !mapping.Default {
+		mapping.Default = true
+		if err := e.DBRP.Update(ctx, mapping); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *StatementExecutor) executeDropDatabaseStatement(ctx context.Context, stmt *influxql.DropDatabaseStatement, ectx *query.ExecutionContext) error {
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		Database: &stmt.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Dropping a database that does not exist is a no-op.
+	for _, m := range mappings {
+		if err := e.dropRetentionPolicy(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *StatementExecutor) executeDropRetentionPolicyStatement(ctx context.Context, stmt *influxql.DropRetentionPolicyStatement, ectx *query.ExecutionContext) error {
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		Database: &stmt.Database,
+	})
+	if err != nil {
+		return err
+	} else if len(mappings) == 0 {
+		return query.ErrDatabaseNotFound(stmt.Database)
+	}
+
+	// Dropping a retention policy that does not exist is a no-op.
+	for _, m := range mappings {
+		if m.RetentionPolicy == stmt.Name {
+			return e.dropRetentionPolicy(ctx, m)
+		}
+	}
+	return nil
+}
+
+// dropRetentionPolicy removes the mapping and, if it was created for the
+// mapping alone, the bucket backing it.
+func (e *StatementExecutor) dropRetentionPolicy(ctx context.Context, mapping *influxdb.DBRPMappingV2) error {
+	if err := e.DBRP.Delete(ctx, mapping.OrganizationID, mapping.ID); err != nil {
+		return err
+	}
+
+	b, err := e.BucketService.FindBucketByID(ctx, mapping.BucketID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if b.Name != bucketName(mapping.Database, mapping.RetentionPolicy) {
+		return nil
+	}
+
+	others, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &mapping.OrganizationID,
+		BucketID: &mapping.BucketID,
+	})
+	if err != nil {
+		return err
+	} else if len(others) > 0 {
+		return nil
+	}
+	return e.BucketService.DeleteBucket(ctx, b.ID)
+}
+
 func (e *StatementExecutor) executeExplainStatement(ctx context.Context, q *influxql.ExplainStatement, ectx *query.ExecutionContext) (models.Rows, error) {
 	opt := query.SelectOptions{
 		OrgID:       ectx.OrgID,
@@ -317,9 +764,7 @@ func (e *StatementExecutor) createIterators(ctx context.Context, stmt *influxql.
 
 func (e *StatementExecutor) executeShowDatabasesStatement(ctx context.Context, q *influxql.ShowDatabasesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
 	row := &models.Row{Name: "databases", Columns: []string{"name"}}
-	dbrps, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
-		OrgID: &ectx.OrgID,
-	})
+	dbrps, err := e.readableMappings(ctx, ectx)
 	if err != nil {
 		return nil, err
 	}
@@ -329,18 +774,6 @@ func (e *StatementExecutor) executeShowDatabasesStatement(ctx context.Context, q
 		if _, ok := seenDbs[dbrp.Database]; ok {
 			continue
 		}
-
-		perm, err := influxdb.NewPermissionAtID(dbrp.BucketID, influxdb.ReadAction, influxdb.BucketsResourceType, dbrp.OrganizationID)
-		if err != nil {
-			return nil, err
-		}
-		err = authorizer.IsAllowed(ctx, *perm)
-		if err != nil {
-			if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
-				continue
-			}
-			return nil, err
-		}
 		seenDbs[dbrp.Database] = struct{}{}
 		row.Values = append(row.Values, []interface{}{dbrp.Database})
 	}
@@ -384,6 +817,66 @@ func (e *StatementExecutor) executeDropMeasurementStatement(ctx context.Context,
 	return e.TSDBStore.DeleteMeasurement(mapping.BucketID.String(), q.Name)
 }
 
+func (e *StatementExecutor) executeDropSeriesStatement(ctx context.Context, q *influxql.DropSeriesStatement, database string, ectx *query.ExecutionContext) error {
+	if database == "" {
+		return ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, database, ectx)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := authorizer.AuthorizeWrite(ctx, influxdb.BucketsResourceType, mapping.BucketID, mapping.OrganizationID); err != nil {
+		return err
+	}
+
+	// Check for time in WHERE clause (not supported).
+	if influxql.HasTimeExpr(q.Condition) {
+		return errors.New("DROP SERIES doesn't support time in WHERE clause")
+	}
+
+	// Deleting the series over the entire time range removes them from the
+	// index and, once no shard references them, the series file.
+	return e.TSDBStore.DeleteSeries(mapping.BucketID.String(), q.Sources, q.Condition)
+}
+
+func (e *StatementExecutor) executeDropShardStatement(ctx context.Context, q *influxql.DropShardStatement, ectx *query.ExecutionContext) error {
+	database, _, sgi := e.MetaClient.ShardOwner(q.ID)
+	if sgi == nil {
+		return fmt.Errorf("shard %d not found", q.ID)
+	}
+
+	bucketID, err := influxdb.IDFromString(database)
+	if err != nil {
+		return err
+	}
+
+	// The shard must belong to a bucket mapped in the organization of the
+	// caller. Shards of other organizations are reported as missing.
+	mappings, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		BucketID: bucketID,
+	})
+	if err != nil {
+		return err
+	} else if len(mappings) == 0 {
+		return fmt.Errorf("shard %d not found", q.ID)
+	}
+
+	if _, _, err := authorizer.AuthorizeWrite(ctx, influxdb.BucketsResourceType, *bucketID, mappings[0].OrganizationID); err != nil {
+		return err
+	}
+
+	// Locally delete the shard.
+	if err := e.TSDBStore.DeleteShard(q.ID); err != nil {
+		return err
+	}
+
+	// Remove the shard reference from the Meta Data.
+	return e.MetaClient.DropShard(q.ID)
+}
+
 func (e *StatementExecutor) executeShowMeasurementsStatement(ctx context.Context, q *influxql.ShowMeasurementsStatement, ectx *query.ExecutionContext) error {
 	if q.Database == "" {
 		return ErrDatabaseNameRequired
@@ -433,16 +926,117 @@ func (e *StatementExecutor) executeShowMeasurementsStatement(ctx context.Context
 	})
 }
 
-func (e *StatementExecutor) executeShowRetentionPoliciesStatement(ctx context.Context, q *influxql.ShowRetentionPoliciesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
-	if q.Database == "" {
-		return nil, ErrDatabaseNameRequired
+func (e *StatementExecutor) executeShowQueriesStatement(q *influxql.ShowQueriesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	queries := e.QueryTracker.Queries(ectx.OrgID)
+	values := make([][]interface{}, 0, len(queries))
+	for _, qi := range queries {
+		values = append(values, []interface{}{qi.ID, qi.Query, qi.Database, formatDuration(qi.Duration), qi.Status.String()})
 	}
+	return []*models.Row{{
+		Columns: []string{"qid", "query", "database", "duration", "status"},
+		Values:  values,
+	}}, nil
+}
+
+// formatDuration formats the duration of a running query with
+// the largest unit that keeps it an integer.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%dms", int(d.Seconds()*1000))
+	case d >= time.Microsecond:
+		return fmt.Sprintf("%dµs", int(d.Seconds()*1000000))
+	}
+	return fmt.Sprintf("%dns", int(d.Nanoseconds()))
+}
+
+// SHOW STATS and SHOW DIAGNOSTICS describe the whole server rather than an
+// organization, so they are restricted to operator tokens.
+
+func (e *StatementExecutor) executeShowStatsStatement(ctx context.Context, stmt *influxql.ShowStatsStatement) (models.Rows, error) {
+	if err := authorizer.IsAllowedAll(ctx, influxdb.OperPermissions()); err != nil {
+		return nil, err
+	}
+
+	var rows []*models.Row
+
+	if store, ok := e.TSDBStore.(*tsdb.Store); stmt.Module == "indexes" && ok {
+		// The cost of collecting indexes metrics grows with the size of the indexes, so only collect this
+		// stat when explicitly requested.
+		row := &models.Row{
+			Name:    "indexes",
+			Columns: []string{"memoryBytes"},
+			Values:  [][]interface{}{{store.IndexBytes()}},
+		}
+		rows = append(rows, row)
+	} else {
+		stats, err := e.Monitor.Statistics(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stat := range stats {
+			if stmt.Module != "" && stat.Name != stmt.Module {
+				continue
+			}
+			row := &models.Row{Name: stat.Name, Tags: stat.Tags}
+
+			values := make([]interface{}, 0, len(stat.Values))
+			for _, k := range stat.ValueNames() {
+				row.Columns = append(row.Columns, k)
+				values = append(values, stat.Values[k])
+			}
+			row.Values = [][]interface{}{values}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (e *StatementExecutor) executeShowDiagnosticsStatement(ctx context.Context, stmt *influxql.ShowDiagnosticsStatement) (models.Rows, error) {
+	if err := authorizer.IsAllowedAll(ctx, influxdb.OperPermissions()); err != nil {
+		return nil, err
+	}
+
+	diags, err := e.Monitor.Diagnostics()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get a sorted list of diagnostics keys.
+	sortedKeys := make([]string, 0, len(diags))
+	for k := range diags {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	rows := make([]*models.Row, 0, len(diags))
+	for _, k := range sortedKeys {
+		if stmt.Module != "" && k != stmt.Module {
+			continue
+		}
+
+		row := &models.Row{Name: k}
+
+		row.Columns = diags[k].Columns
+		row.Values = diags[k].Rows
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (e *StatementExecutor) executeShowRetentionPoliciesStatement(ctx context.Context, q *influxql.ShowRetentionPoliciesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	dbrps, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:    &ectx.OrgID,
+		Database: &q.Database,
+	})
 
-	dbrps, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
-		OrgID:    &ectx.OrgID,
-		Database: &q.Database,
-	})
-
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +1060,173 @@ func (e *StatementExecutor) executeShowRetentionPoliciesStatement(ctx context.Co
 	return []*models.Row{row}, nil
 }
 
-func (e *StatementExecutor) executeShowTagKeys(ctx context.Context, q *influxql.ShowTagKeysStatement, ectx *query.ExecutionContext) error {
+func (e *StatementExecutor) executeShowMeasurementCardinalityStatement(ctx context.Context, q *influxql.ShowMeasurementCardinalityStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, q.Database, ectx)
+	if err != nil {
+		return nil, err
+	}
+
+	valuer := &influxql.NowValuer{Now: time.Now()}
+	cond, timeRange, err := influxql.ConditionExpr(q.Condition, valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is a single count, which OFFSET skips and LIMIT keeps, as
+	// they do for the SELECT statement the query is otherwise rewritten into.
+	if q.Offset > 0 {
+		return nil, nil
+	}
+
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a predicate the cardinality can be estimated from the sketches
+	// maintained by the index of each shard.
+	if !q.Exact && cond == nil {
+		ss, ts, err := e.TSDBStore.MeasurementsSketchesByShards(shardIDs)
+		if err != nil {
+			return nil, err
+		}
+		return models.Rows{{
+			Columns: []string{"cardinality estimation"},
+			Values:  [][]interface{}{{sketchCount(ss, ts)}},
+		}}, nil
+	}
+
+	names, err := e.TSDBStore.MeasurementNamesByShards(ectx.Authorizer, shardIDs, cond)
+	if err != nil {
+		return nil, err
+	}
+	return models.Rows{{
+		Columns: []string{"count"},
+		Values:  [][]interface{}{{int64(len(names))}},
+	}}, nil
+}
+
+func (e *StatementExecutor) executeShowSeriesCardinalityStatement(ctx context.Context, q *influxql.ShowSeriesCardinalityStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, q.Database, ectx)
+	if err != nil {
+		return nil, err
+	}
+
+	valuer := &influxql.NowValuer{Now: time.Now()}
+	cond, timeRange, err := influxql.ConditionExpr(q.Condition, valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a predicate the cardinality can be estimated from the sketches
+	// maintained by the index of each shard.
+	if !q.Exact && cond == nil {
+		ss, ts, err := e.TSDBStore.SeriesSketchesByShards(shardIDs)
+		if err != nil {
+			return nil, err
+		}
+		return models.Rows{{
+			Columns: []string{"cardinality estimation"},
+			Values:  [][]interface{}{{sketchCount(ss, ts)}},
+		}}, nil
+	}
+
+	counts, err := e.TSDBStore.SeriesCardinalityByShards(ectx.Authorizer, shardIDs, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// LIMIT and OFFSET page through the measurements.
+	if q.Offset > 0 {
+		if q.Offset >= len(counts) {
+			counts = nil
+		} else {
+			counts = counts[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && q.Limit < len(counts) {
+		counts = counts[:q.Limit]
+	}
+
+	rows := make(models.Rows, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, &models.Row{
+			Name:    c.Measurement,
+			Columns: []string{"count"},
+			Values:  [][]interface{}{{c.N}},
+		})
+	}
+	return rows, nil
+}
+
+// executeShowFieldKeyCardinalityStatement counts the field keys of the
+// measurements in the shards overlapping the time range of the statement.
+// Statements with GROUP BY are rewritten into SELECT statements.
+func (e *StatementExecutor) executeShowFieldKeyCardinalityStatement(ctx context.Context, q *influxql.ShowFieldKeyCardinalityStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, q.Database, ectx)
+	if err != nil {
+		return nil, err
+	}
+
+	valuer := &influxql.NowValuer{Now: time.Now()}
+	cond, timeRange, err := influxql.ConditionExpr(q.Condition, valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldKeys, err := e.TSDBStore.FieldKeysByShards(ectx.Authorizer, shardIDs, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// LIMIT and OFFSET page through the measurements.
+	if q.Offset > 0 {
+		if q.Offset >= len(fieldKeys) {
+			fieldKeys = nil
+		} else {
+			fieldKeys = fieldKeys[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && q.Limit < len(fieldKeys) {
+		fieldKeys = fieldKeys[:q.Limit]
+	}
+
+	rows := make(models.Rows, 0, len(fieldKeys))
+	for _, fk := range fieldKeys {
+		rows = append(rows, &models.Row{
+			Name:    fk.Measurement,
+			Columns: []string{"count"},
+			Values:  [][]interface{}{{int64(len(fk.Keys))}},
+		})
+	}
+	return rows, nil
+}
+
+// executeShowFieldKeysStatement lists the field keys and types of the
+// measurements in the shards of the default retention policy.
+func (e *StatementExecutor) executeShowFieldKeysStatement(ctx context.Context, q *influxql.ShowFieldKeysStatement, ectx *query.ExecutionContext) error {
 	if q.Database == "" {
 		return ErrDatabaseNameRequired
 	}
@@ -476,19 +1236,129 @@ func (e *StatementExecutor) executeShowTagKeys(ctx context.Context, q *influxql.
 		return err
 	}
 
-	// Determine shard set based on database and time range.
-	// SHOW TAG KEYS returns all tag keys for the default retention policy.
-	di := e.MetaClient.Database(mapping.BucketID.String())
-	if di == nil {
-		return fmt.Errorf("database not found: %s", q.Database)
+	// SHOW FIELD KEYS has no WHERE clause, so every shard is used.
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, influxql.TimeRange{})
+	if err != nil {
+		return err
+	}
+
+	fieldKeys, err := e.TSDBStore.FieldKeysByShards(ectx.Authorizer, shardIDs, query.SourcesCondition(q.Sources))
+	if err != nil {
+		return ectx.Send(ctx, &query.Result{Err: err})
+	}
+
+	emitted := false
+	for _, m := range fieldKeys {
+		keys, types := m.Keys, m.Types
+
+		if q.Offset > 0 {
+			if q.Offset >= len(keys) {
+				keys, types = nil, nil
+			} else {
+				keys, types = keys[q.Offset:], types[q.Offset:]
+			}
+		}
+		if q.Limit > 0 && q.Limit < len(keys) {
+			keys, types = keys[:q.Limit], types[:q.Limit]
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		row := &models.Row{
+			Name:    m.Measurement,
+			Columns: []string{"fieldKey", "fieldType"},
+			Values:  make([][]interface{}, len(keys)),
+		}
+		for i, key := range keys {
+			row.Values[i] = []interface{}{key, types[i].String()}
+		}
+
+		if err := ectx.Send(ctx, &query.Result{
+			Series: []*models.Row{row},
+		}); err != nil {
+			return err
+		}
+		emitted = true
+	}
+
+	// Ensure at least one result is emitted.
+	if !emitted {
+		return ectx.Send(ctx, &query.Result{})
+	}
+	return nil
+}
+
+// executeShowSeriesStatement lists the keys of the series in the shards
+// overlapping the time range of the statement.
+func (e *StatementExecutor) executeShowSeriesStatement(ctx context.Context, q *influxql.ShowSeriesStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, q.Database, ectx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine appropriate time range. If one or fewer time boundaries provided
-	// then min/max possible time should be used instead.
 	valuer := &influxql.NowValuer{Now: time.Now()}
 	cond, timeRange, err := influxql.ConditionExpr(q.Condition, valuer)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := e.TSDBStore.SeriesKeysByShards(ectx.Authorizer, shardIDs, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Offset > 0 {
+		if q.Offset >= len(keys) {
+			keys = nil
+		} else {
+			keys = keys[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && q.Limit < len(keys) {
+		keys = keys[:q.Limit]
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	row := &models.Row{
+		Columns: []string{"key"},
+		Values:  make([][]interface{}, len(keys)),
+	}
+	for i, key := range keys {
+		row.Values[i] = []interface{}{key}
+	}
+	return models.Rows{row}, nil
+}
+
+// sketchCount returns the estimated number of items in ss that have not been
+// tombstoned in ts.
+func sketchCount(ss, ts estimator.Sketch) int64 {
+	n, d := ss.Count(), ts.Count()
+	if d >= n {
+		return 0
+	}
+	return int64(n - d)
+}
+
+// shardIDsByTimeRange returns the IDs of the shards of the bucket in mapping
+// which overlap timeRange.
+func (e *StatementExecutor) shardIDsByTimeRange(database string, mapping *influxdb.DBRPMappingV2, timeRange influxql.TimeRange) ([]uint64, error) {
+	di := e.MetaClient.Database(mapping.BucketID.String())
+	if di == nil {
+		return nil, fmt.Errorf("database not found: %s", database)
 	}
 
 	// Get all shards for all retention policies.
@@ -496,7 +1366,7 @@ func (e *StatementExecutor) executeShowTagKeys(ctx context.Context, q *influxql.
 	for _, rpi := range di.RetentionPolicies {
 		sgis, err := e.MetaClient.ShardGroupsByTimeRange(mapping.BucketID.String(), rpi.Name, timeRange.MinTime(), timeRange.MaxTime())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		allGroups = append(allGroups, sgis...)
 	}
@@ -507,6 +1377,183 @@ func (e *StatementExecutor) executeShowTagKeys(ctx context.Context, q *influxql.
 			shardIDs = append(shardIDs, si.ID)
 		}
 	}
+	return shardIDs, nil
+}
+
+// readableMappings returns the DBRP mappings of the organization whose bucket
+// the caller is permitted to read.
+func (e *StatementExecutor) readableMappings(ctx context.Context, ectx *query.ExecutionContext) ([]*influxdb.DBRPMappingV2, error) {
+	dbrps, _, err := e.DBRP.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID: &ectx.OrgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readable := make([]*influxdb.DBRPMappingV2, 0, len(dbrps))
+	for _, dbrp := range dbrps {
+		perm, err := influxdb.NewPermissionAtID(dbrp.BucketID, influxdb.ReadAction, influxdb.BucketsResourceType, dbrp.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		err = authorizer.IsAllowed(ctx, *perm)
+		if err != nil {
+			if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+				continue
+			}
+			return nil, err
+		}
+		readable = append(readable, dbrp)
+	}
+	return readable, nil
+}
+
+// bucketMappings returns one mapping per bucket out of dbrps, the default
+// mapping of the bucket if there is one, so that the shards of a bucket
+// mapped several times are listed once, under the database and retention
+// policy of that mapping.
+func bucketMappings(dbrps []*influxdb.DBRPMappingV2) []*influxdb.DBRPMappingV2 {
+	byBucket := make(map[influxdb.ID]int, len(dbrps))
+	mappings := make([]*influxdb.DBRPMappingV2, 0, len(dbrps))
+	for _, dbrp := range dbrps {
+		i, ok := byBucket[dbrp.BucketID]
+		if !ok {
+			byBucket[dbrp.BucketID] = len(mappings)
+			mappings = append(mappings, dbrp)
+			continue
+		}
+		if dbrp.Default && !mappings[i].Default {
+			mappings[i] = dbrp
+		}
+	}
+	return mappings
+}
+
+func (e *StatementExecutor) executeShowShardsStatement(ctx context.Context, q *influxql.ShowShardsStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	dbrps, err := e.readableMappings(ctx, ectx)
+	if err != nil {
+		return nil, err
+	}
+	dbrps = bucketMappings(dbrps)
+
+	rows := []*models.Row{}
+	rowsByDB := make(map[string]*models.Row)
+	for _, dbrp := range dbrps {
+		di := e.MetaClient.Database(dbrp.BucketID.String())
+		if di == nil {
+			continue
+		}
+
+		row, ok := rowsByDB[dbrp.Database]
+		if !ok {
+			row = &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners"}, Name: dbrp.Database}
+			rowsByDB[dbrp.Database] = row
+			rows = append(rows, row)
+		}
+
+		for _, rpi := range di.RetentionPolicies {
+			for _, sgi := range rpi.ShardGroups {
+				// Shards associated with deleted shard groups are effectively deleted.
+				// Don't list them.
+				if sgi.Deleted() {
+					continue
+				}
+
+				for _, si := range sgi.Shards {
+					ownerIDs := make([]uint64, len(si.Owners))
+					for i, owner := range si.Owners {
+						ownerIDs[i] = owner.NodeID
+					}
+
+					row.Values = append(row.Values, []interface{}{
+						si.ID,
+						dbrp.Database,
+						dbrp.RetentionPolicy,
+						sgi.ID,
+						sgi.StartTime.UTC().Format(time.RFC3339),
+						sgi.EndTime.UTC().Format(time.RFC3339),
+						sgi.EndTime.Add(rpi.Duration).UTC().Format(time.RFC3339),
+						joinUint64(ownerIDs),
+					})
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (e *StatementExecutor) executeShowShardGroupsStatement(ctx context.Context, q *influxql.ShowShardGroupsStatement, ectx *query.ExecutionContext) (models.Rows, error) {
+	dbrps, err := e.readableMappings(ctx, ectx)
+	if err != nil {
+		return nil, err
+	}
+	dbrps = bucketMappings(dbrps)
+
+	row := &models.Row{Columns: []string{"id", "database", "retention_policy", "start_time", "end_time", "expiry_time"}, Name: "shard groups"}
+	for _, dbrp := range dbrps {
+		di := e.MetaClient.Database(dbrp.BucketID.String())
+		if di == nil {
+			continue
+		}
+
+		for _, rpi := range di.RetentionPolicies {
+			for _, sgi := range rpi.ShardGroups {
+				// Shards associated with deleted shard groups are effectively deleted.
+				// Don't list them.
+				if sgi.Deleted() {
+					continue
+				}
+
+				row.Values = append(row.Values, []interface{}{
+					sgi.ID,
+					dbrp.Database,
+					dbrp.RetentionPolicy,
+					sgi.StartTime.UTC().Format(time.RFC3339),
+					sgi.EndTime.UTC().Format(time.RFC3339),
+					sgi.EndTime.Add(rpi.Duration).UTC().Format(time.RFC3339),
+				})
+			}
+		}
+	}
+	return []*models.Row{row}, nil
+}
+
+// joinUint64 returns a comma-delimited string of uint64 numbers.
+func joinUint64(a []uint64) string {
+	var buf strings.Builder
+	for i, x := range a {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(strconv.FormatUint(x, 10))
+	}
+	return buf.String()
+}
+
+func (e *StatementExecutor) executeShowTagKeys(ctx context.Context, q *influxql.ShowTagKeysStatement, ectx *query.ExecutionContext) error {
+	if q.Database == "" {
+		return ErrDatabaseNameRequired
+	}
+
+	mapping, err := e.getDefaultRP(ctx, q.Database, ectx)
+	if err != nil {
+		return err
+	}
+
+	// Determine appropriate time range. If one or fewer time boundaries provided
+	// then min/max possible time should be used instead.
+	valuer := &influxql.NowValuer{Now: time.Now()}
+	cond, timeRange, err := influxql.ConditionExpr(q.Condition, valuer)
+	if err != nil {
+		return err
+	}
+
+	// Determine shard set based on database and time range.
+	// SHOW TAG KEYS returns all tag keys for the default retention policy.
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return err
+	}
 
 	tagKeys, err := e.TSDBStore.TagKeys(ectx.Authorizer, shardIDs, cond)
 	if err != nil {
@@ -568,13 +1615,6 @@ func (e *StatementExecutor) executeShowTagValues(ctx context.Context, q *influxq
 		return err
 	}
 
-	// Determine shard set based on database and time range.
-	// SHOW TAG VALUES returns all tag values for the default retention policy.
-	di := e.MetaClient.Database(mapping.BucketID.String())
-	if di == nil {
-		return fmt.Errorf("database not found: %s", q.Database)
-	}
-
 	// Determine appropriate time range. If one or fewer time boundaries provided
 	// then min/max possible time should be used instead.
 	valuer := &influxql.NowValuer{Now: time.Now()}
@@ -583,21 +1623,11 @@ func (e *StatementExecutor) executeShowTagValues(ctx context.Context, q *influxq
 		return err
 	}
 
-	// Get all shards for all retention policies.
-	var allGroups []meta.ShardGroupInfo
-	for _, rpi := range di.RetentionPolicies {
-		sgis, err := e.MetaClient.ShardGroupsByTimeRange(mapping.BucketID.String(), rpi.Name, timeRange.MinTime(), timeRange.MaxTime())
-		if err != nil {
-			return err
-		}
-		allGroups = append(allGroups, sgis...)
-	}
-
-	var shardIDs []uint64
-	for _, sgi := range allGroups {
-		for _, si := range sgi.Shards {
-			shardIDs = append(shardIDs, si.ID)
-		}
+	// Determine shard set based on database and time range.
+	// SHOW TAG VALUES returns all tag values for the default retention policy.
+	shardIDs, err := e.shardIDsByTimeRange(q.Database, mapping, timeRange)
+	if err != nil {
+		return err
 	}
 
 	tagValues, err := e.TSDBStore.TagValues(ectx.Authorizer, shardIDs, cond)
@@ -683,6 +1713,18 @@ func (e *StatementExecutor) NormalizeStatement(ctx context.Context, stmt influxq
 			if node.Database == "" {
 				node.Database = defaultDatabase
 			}
+		case *influxql.ShowFieldKeyCardinalityStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowFieldKeysStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowSeriesStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
 		case *influxql.Measurement:
 			switch stmt.(type) {
 			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement:
@@ -757,7 +1799,14 @@ func (m mappings) DefaultRetentionPolicy(db string) string {
 type TSDBStore interface {
 	DeleteMeasurement(database, name string) error
 	DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error
+	DeleteShard(id uint64) error
+	FieldKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error)
 	MeasurementNames(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error)
+	MeasurementNamesByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error)
+	MeasurementsSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
+	SeriesCardinalityByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.SeriesCardinality, error)
+	SeriesKeysByShards(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error)
+	SeriesSketchesByShards(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error)
 	TagKeys(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
 	TagValues(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,10 +20,15 @@ import (
 	"github.com/influxdata/influxdb/v2/influxql/control"
 	"github.com/influxdata/influxdb/v2/influxql/query"
 	"github.com/influxdata/influxdb/v2/internal"
+	"github.com/influxdata/influxdb/v2/mock"
 	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/pkg/estimator"
+	"github.com/influxdata/influxdb/v2/pkg/estimator/hll"
 	itesting "github.com/influxdata/influxdb/v2/testing"
 	"github.com/influxdata/influxdb/v2/tsdb"
 	"github.com/influxdata/influxdb/v2/v1/coordinator"
+	"github.com/influxdata/influxdb/v2/v1/monitor"
+	"github.com/influxdata/influxdb/v2/v1/monitor/diagnostics"
 	"github.com/influxdata/influxdb/v2/v1/services/meta"
 	"github.com/influxdata/influxql"
 	"go.uber.org/zap/zaptest"
@@ -385,6 +391,1047 @@ func TestQueryExecutor_ExecuteQuery_ShowDatabases(t *testing.T) {
 	}
 }
 
+func TestQueryExecutor_ExecuteQuery_ShowShards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	orgID := influxdb.ID(0xff00)
+	filt := influxdb.DBRPMappingFilterV2{OrgID: &orgID}
+	res := []*influxdb.DBRPMappingV2{
+		{Database: "db1", RetentionPolicy: "rp1", OrganizationID: orgID, BucketID: 0xffe0},
+		// db2 and db3 map the same bucket, listed once under its default mapping.
+		{Database: "db3", RetentionPolicy: "rp3", OrganizationID: orgID, BucketID: 0xffe1},
+		{Database: "db2", RetentionPolicy: "rp2", Default: true, OrganizationID: orgID, BucketID: 0xffe1},
+	}
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), filt).
+		Return(res, 3, nil).
+		Times(2)
+
+	start := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	var metaClient MetaClient
+	metaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name: name,
+			RetentionPolicies: []meta.RetentionPolicyInfo{{
+				Name:     meta.DefaultRetentionPolicyName,
+				Duration: 72 * time.Hour,
+				ShardGroups: []meta.ShardGroupInfo{
+					{ID: 1, StartTime: start, EndTime: start.Add(24 * time.Hour), DeletedAt: start, Shards: []meta.ShardInfo{{ID: 1}}},
+					{ID: 2, StartTime: start.Add(24 * time.Hour), EndTime: start.Add(48 * time.Hour), Shards: []meta.ShardInfo{
+						{ID: 2, Owners: []meta.ShardOwner{{NodeID: 0}}},
+					}},
+				},
+			}},
+		}
+	}
+
+	qe := query.NewExecutor(zaptest.NewLogger(t), control.NewControllerMetrics([]string{}))
+	qe.StatementExecutor = &coordinator.StatementExecutor{
+		MetaClient: &metaClient,
+		DBRP:       dbrp,
+	}
+
+	opt := query.ExecutionOptions{
+		OrgID: orgID,
+	}
+
+	q, err := influxql.ParseQuery("SHOW SHARDS; SHOW SHARD GROUPS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ctx = icontext.SetAuthorizer(ctx, &influxdb.Authorization{
+		ID:     orgID,
+		OrgID:  orgID,
+		Status: influxdb.Active,
+		Permissions: []influxdb.Permission{
+			*itesting.MustNewPermissionAtID(0xffe1, influxdb.ReadAction, influxdb.BucketsResourceType, orgID),
+		},
+	})
+
+	results := ReadAllResults(qe.ExecuteQuery(ctx, q, opt))
+	exp := []*query.Result{
+		{
+			StatementID: 0,
+			Series: []*models.Row{{
+				Name:    "db2",
+				Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners"},
+				Values: [][]interface{}{
+					{uint64(2), "db2", "rp2", uint64(2), "2021-01-05T00:00:00Z", "2021-01-06T00:00:00Z", "2021-01-09T00:00:00Z", "0"},
+				},
+			}},
+		},
+		{
+			StatementID: 1,
+			Series: []*models.Row{{
+				Name:    "shard groups",
+				Columns: []string{"id", "database", "retention_policy", "start_time", "end_time", "expiry_time"},
+				Values: [][]interface{}{
+					{uint64(2), "db2", "rp2", "2021-01-05T00:00:00Z", "2021-01-06T00:00:00Z", "2021-01-09T00:00:00Z"},
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(results, exp) {
+		t.Fatalf("unexpected results: exp %s, got %s", spew.Sdump(exp), spew.Sdump(results))
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_DropSeries(t *testing.T) {
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffe0)
+	db := "db0"
+	isDefault := true
+
+	tests := []struct {
+		name    string
+		q       string
+		perms   []influxdb.Permission
+		deleted bool
+		err     string
+	}{
+		{
+			name:    "authorized",
+			q:       `DROP SERIES FROM cpu WHERE host = 'a'`,
+			perms:   []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)},
+			deleted: true,
+		},
+		{
+			name:  "read only",
+			q:     `DROP SERIES FROM cpu WHERE host = 'a'`,
+			perms: []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID)},
+			err:   "write:orgs/000000000000ff00/buckets/000000000000ffe0 is unauthorized",
+		},
+		{
+			name:  "time in where clause",
+			q:     `DROP SERIES FROM cpu WHERE time > 0`,
+			perms: []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)},
+			err:   "DROP SERIES doesn't support time in WHERE clause",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+			filt := influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db, Default: &isDefault}
+			res := []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID}}
+			dbrp.EXPECT().
+				FindMany(gomock.Any(), filt).
+				Return(res, 1, nil)
+
+			e := NewQueryExecutor(t, WithDBRP(dbrp))
+
+			var deleted bool
+			e.TSDBStore.DeleteSeriesFn = func(database string, sources []influxql.Source, condition influxql.Expr) error {
+				if database != bucketID.String() {
+					t.Errorf("unexpected database: %s", database)
+				}
+				if got, exp := influxql.Sources(sources).String(), "cpu"; got != exp {
+					t.Errorf("unexpected sources: exp %s, got %s", exp, got)
+				}
+				deleted = true
+				return nil
+			}
+
+			ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+				ID:          orgID,
+				OrgID:       orgID,
+				Status:      influxdb.Active,
+				Permissions: tt.perms,
+			})
+
+			results := ReadAllResults(e.ExecuteQuery(ctx, tt.q, db, 0, orgID))
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			if tt.err != "" {
+				if results[0].Err == nil || results[0].Err.Error() != tt.err {
+					t.Fatalf("unexpected error: exp %q, got %v", tt.err, results[0].Err)
+				}
+			} else if results[0].Err != nil {
+				t.Fatalf("unexpected error: %v", results[0].Err)
+			}
+			if deleted != tt.deleted {
+				t.Fatalf("unexpected deletion: exp %t, got %t", tt.deleted, deleted)
+			}
+		})
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_DropShard(t *testing.T) {
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffe0)
+
+	tests := []struct {
+		name     string
+		mappings []*influxdb.DBRPMappingV2
+		perms    []influxdb.Permission
+		dropped  bool
+		err      string
+	}{
+		{
+			name:     "authorized",
+			mappings: []*influxdb.DBRPMappingV2{{Database: "db0", RetentionPolicy: "autogen", OrganizationID: orgID, BucketID: bucketID}},
+			perms:    []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)},
+			dropped:  true,
+		},
+		{
+			name:     "read only",
+			mappings: []*influxdb.DBRPMappingV2{{Database: "db0", RetentionPolicy: "autogen", OrganizationID: orgID, BucketID: bucketID}},
+			perms:    []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID)},
+			err:      "write:orgs/000000000000ff00/buckets/000000000000ffe0 is unauthorized",
+		},
+		{
+			name:  "other organization",
+			perms: []influxdb.Permission{*itesting.MustNewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)},
+			err:   "shard 10 not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+			filt := influxdb.DBRPMappingFilterV2{OrgID: &orgID, BucketID: &bucketID}
+			dbrp.EXPECT().
+				FindMany(gomock.Any(), filt).
+				Return(tt.mappings, len(tt.mappings), nil)
+
+			e := NewQueryExecutor(t, WithDBRP(dbrp))
+			e.MetaClient.ShardOwnerFn = func(shardID uint64) (string, string, *meta.ShardGroupInfo) {
+				if shardID != 10 {
+					return "", "", nil
+				}
+				return bucketID.String(), meta.DefaultRetentionPolicyName, &meta.ShardGroupInfo{ID: 1}
+			}
+
+			var deleted, dropped bool
+			e.TSDBStore.DeleteShardFn = func(id uint64) error {
+				deleted = id == 10
+				return nil
+			}
+			e.MetaClient.DropShardFn = func(id uint64) error {
+				dropped = id == 10
+				return nil
+			}
+
+			ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+				ID:          orgID,
+				OrgID:       orgID,
+				Status:      influxdb.Active,
+				Permissions: tt.perms,
+			})
+
+			results := ReadAllResults(e.ExecuteQuery(ctx, `DROP SHARD 10`, "", 0, orgID))
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			if tt.err != "" {
+				if results[0].Err == nil || results[0].Err.Error() != tt.err {
+					t.Fatalf("unexpected error: exp %q, got %v", tt.err, results[0].Err)
+				}
+			} else if results[0].Err != nil {
+				t.Fatalf("unexpected error: %v", results[0].Err)
+			}
+			if deleted != tt.dropped || dropped != tt.dropped {
+				t.Fatalf("unexpected drop: exp %t, got store=%t meta=%t", tt.dropped, deleted, dropped)
+			}
+		})
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_CreateDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffe0)
+	db := "db0"
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db}).
+		Return(nil, 0, nil)
+	dbrp.EXPECT().
+		Create(gomock.Any(), &influxdb.DBRPMappingV2{
+			Database:        db,
+			RetentionPolicy: "rp0",
+			Default:         true,
+			OrganizationID:  orgID,
+			BucketID:        bucketID,
+		}).
+		Return(nil)
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+	e.BucketService.FindBucketByNameFn = func(_ context.Context, id influxdb.ID, name string) (*influxdb.Bucket, error) {
+		if id != orgID || name != "db0/rp0" {
+			t.Errorf("unexpected bucket lookup: %s %s", id, name)
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound}
+	}
+	e.BucketService.CreateBucketFn = func(_ context.Context, b *influxdb.Bucket) error {
+		if b.OrgID != orgID || b.Name != "db0/rp0" || b.RetentionPolicyName != "rp0" || b.RetentionPeriod != time.Hour {
+			t.Errorf("unexpected bucket: %+v", b)
+		}
+		b.ID = bucketID
+		return nil
+	}
+
+	results := ReadAllResults(e.ExecuteQuery(context.Background(), `CREATE DATABASE db0 WITH DURATION 1h NAME rp0`, "", 0, orgID))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	} else if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_CreateRetentionPolicy(t *testing.T) {
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffe0)
+	db := "db0"
+
+	tests := []struct {
+		name     string
+		q        string
+		mappings []*influxdb.DBRPMappingV2
+		created  bool
+		err      string
+	}{
+		{
+			name:     "new",
+			q:        `CREATE RETENTION POLICY rp0 ON db0 DURATION 1h REPLICATION 1`,
+			mappings: []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID + 1}},
+			created:  true,
+		},
+		{
+			name:     "identical",
+			q:        `CREATE RETENTION POLICY rp0 ON db0 DURATION 1h REPLICATION 1`,
+			mappings: []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "rp0", OrganizationID: orgID, BucketID: bucketID}},
+		},
+		{
+			name:     "conflicting",
+			q:        `CREATE RETENTION POLICY rp0 ON db0 DURATION 2h REPLICATION 1`,
+			mappings: []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "rp0", OrganizationID: orgID, BucketID: bucketID}},
+			err:      meta.ErrRetentionPolicyExists.Error(),
+		},
+		{
+			name: "database not found",
+			q:    `CREATE RETENTION POLICY rp0 ON db0 DURATION 1h REPLICATION 1`,
+			err:  "database not found: db0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+			dbrp.EXPECT().
+				FindMany(gomock.Any(), influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db}).
+				Return(tt.mappings, len(tt.mappings), nil)
+			if tt.created {
+				dbrp.EXPECT().
+					Create(gomock.Any(), &influxdb.DBRPMappingV2{
+						Database:        db,
+						RetentionPolicy: "rp0",
+						OrganizationID:  orgID,
+						BucketID:        bucketID,
+					}).
+					Return(nil)
+			}
+
+			e := NewQueryExecutor(t, WithDBRP(dbrp))
+			e.BucketService.FindBucketByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+				return &influxdb.Bucket{ID: id, OrgID: orgID, Name: "db0/rp0", RetentionPeriod: time.Hour}, nil
+			}
+			e.BucketService.FindBucketByNameFn = func(context.Context, influxdb.ID, string) (*influxdb.Bucket, error) {
+				return nil, &influxdb.Error{Code: influxdb.ENotFound}
+			}
+			e.BucketService.CreateBucketFn = func(_ context.Context, b *influxdb.Bucket) error {
+				b.ID = bucketID
+				return nil
+			}
+
+			results := ReadAllResults(e.ExecuteQuery(context.Background(), tt.q, "", 0, orgID))
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			if tt.err != "" {
+				if results[0].Err == nil || results[0].Err.Error() != tt.err {
+					t.Fatalf("unexpected error: exp %q, got %v", tt.err, results[0].Err)
+				}
+			} else if results[0].Err != nil {
+				t.Fatalf("unexpected error: %v", results[0].Err)
+			}
+		})
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_AlterRetentionPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffe0)
+	db, rp := "db0", "rp0"
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db, RetentionPolicy: &rp}).
+		Return([]*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: rp, OrganizationID: orgID, BucketID: bucketID}}, 1, nil)
+	dbrp.EXPECT().
+		Update(gomock.Any(), &influxdb.DBRPMappingV2{Database: db, RetentionPolicy: rp, Default: true, OrganizationID: orgID, BucketID: bucketID}).
+		Return(nil)
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+	var updated bool
+	e.BucketService.UpdateBucketFn = func(_ context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+		if id != bucketID {
+			t.Errorf("unexpected bucket: %s", id)
+		}
+		if upd.RetentionPeriod == nil || *upd.RetentionPeriod != 2*time.Hour {
+			t.Errorf("unexpected retention period: %v", upd.RetentionPeriod)
+		}
+		updated = true
+		return &influxdb.Bucket{ID: id}, nil
+	}
+
+	results := ReadAllResults(e.ExecuteQuery(context.Background(), `ALTER RETENTION POLICY rp0 ON db0 DURATION 2h DEFAULT`, "", 0, orgID))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	} else if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	} else if !updated {
+		t.Fatal("expected bucket to be updated")
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgID := influxdb.ID(0xff00)
+	db := "db0"
+	owned := &influxdb.DBRPMappingV2{ID: 1, Database: db, RetentionPolicy: "rp0", OrganizationID: orgID, BucketID: 0xffe0}
+	mapped := &influxdb.DBRPMappingV2{ID: 2, Database: db, RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: 0xffe1}
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db}).
+		Return([]*influxdb.DBRPMappingV2{owned, mapped}, 2, nil)
+	dbrp.EXPECT().Delete(gomock.Any(), orgID, owned.ID).Return(nil)
+	dbrp.EXPECT().Delete(gomock.Any(), orgID, mapped.ID).Return(nil)
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), influxdb.DBRPMappingFilterV2{OrgID: &orgID, BucketID: &owned.BucketID}).
+		Return(nil, 0, nil)
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+	e.BucketService.FindBucketByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+		if id == owned.BucketID {
+			return &influxdb.Bucket{ID: id, OrgID: orgID, Name: "db0/rp0"}, nil
+		}
+		// Buckets mapped manually are not named after the mapping.
+		return &influxdb.Bucket{ID: id, OrgID: orgID, Name: "telegraf"}, nil
+	}
+	var deleted []influxdb.ID
+	e.BucketService.DeleteBucketFn = func(_ context.Context, id influxdb.ID) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+
+	results := ReadAllResults(e.ExecuteQuery(context.Background(), `DROP DATABASE db0`, "", 0, orgID))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	} else if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	} else if !reflect.DeepEqual(deleted, []influxdb.ID{owned.BucketID}) {
+		t.Fatalf("unexpected deleted buckets: %v", deleted)
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ContinuousQueries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgID := influxdb.ID(0xff00)
+	userID := influxdb.ID(0xaa)
+	bucketID := influxdb.ID(0xffe0)
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), gomock.Any()).
+		Return([]*influxdb.DBRPMappingV2{{Database: "db0", RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID}}, 1, nil).
+		AnyTimes()
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+
+	var (
+		labels   []*influxdb.Label
+		tasks    []*influxdb.Task
+		mappings = make(map[influxdb.ID][]*influxdb.Label)
+	)
+	e.LabelService.FindLabelsFn = func(_ context.Context, filter influxdb.LabelFilter) ([]*influxdb.Label, error) {
+		return labels, nil
+	}
+	e.LabelService.CreateLabelFn = func(_ context.Context, l *influxdb.Label) error {
+		if l.Name != coordinator.ContinuousQueryLabel || l.OrgID != orgID {
+			t.Errorf("unexpected label: %+v", l)
+		}
+		l.ID = 1
+		labels = append(labels, l)
+		return nil
+	}
+	e.LabelService.CreateLabelMappingFn = func(_ context.Context, m *influxdb.LabelMapping) error {
+		mappings[m.ResourceID] = append(mappings[m.ResourceID], labels[0])
+		return nil
+	}
+	e.LabelService.FindResourceLabelsFn = func(_ context.Context, filter influxdb.LabelMappingFilter) ([]*influxdb.Label, error) {
+		return mappings[filter.ResourceID], nil
+	}
+	e.TaskService.CreateTaskFn = func(_ context.Context, tc influxdb.TaskCreate) (*influxdb.Task, error) {
+		if tc.OrganizationID != orgID || tc.OwnerID != userID {
+			t.Errorf("unexpected task owner: %+v", tc)
+		}
+		if !strings.Contains(tc.Flux, `option task = {name: "cq0", every: 1h}`) {
+			t.Errorf("unexpected task options:\n%s", tc.Flux)
+		}
+		if !strings.Contains(tc.Flux, `experimental.to(bucketID: "000000000000ffe0", orgID: "000000000000ff00")`) {
+			t.Errorf("unexpected task target:\n%s", tc.Flux)
+		}
+		task := &influxdb.Task{ID: influxdb.ID(len(tasks) + 1), OrganizationID: orgID, Name: "cq0", Description: tc.Description, Flux: tc.Flux}
+		tasks = append(tasks, task)
+		return task, nil
+	}
+	e.TaskService.FindTasksFn = func(_ context.Context, filter influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
+		return tasks, len(tasks), nil
+	}
+	e.TaskService.DeleteTaskFn = func(_ context.Context, id influxdb.ID) error {
+		for i, task := range tasks {
+			if task.ID == id {
+				tasks = append(tasks[:i], tasks[i+1:]...)
+				return nil
+			}
+		}
+		return errors.New("task not found")
+	}
+
+	ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+		ID:     orgID,
+		OrgID:  orgID,
+		UserID: userID,
+		Status: influxdb.Active,
+		Permissions: []influxdb.Permission{
+			*itesting.MustNewPermissionAtID(bucketID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID),
+		},
+	})
+
+	const cq = `CREATE CONTINUOUS QUERY cq0 ON db0 BEGIN SELECT mean(value) INTO cpu_1h FROM cpu GROUP BY time(1h) END`
+	for _, tt := range []struct {
+		q    string
+		rows models.Rows
+		err  string
+	}{
+		{q: cq},
+		{q: cq, err: meta.ErrContinuousQueryExists.Error()},
+		{
+			q: `SHOW CONTINUOUS QUERIES`,
+			rows: models.Rows{{
+				Name:    "db0",
+				Columns: []string{"name", "query"},
+				Values:  [][]interface{}{{"cq0", `CREATE CONTINUOUS QUERY cq0 ON db0 BEGIN SELECT mean(value) INTO cpu_1h FROM cpu GROUP BY time(1h) END`}},
+			}},
+		},
+		{q: `DROP CONTINUOUS QUERY cq0 ON db0`},
+		{q: `DROP CONTINUOUS QUERY cq0 ON db0`, err: meta.ErrContinuousQueryNotFound.Error()},
+		{
+			q: `SHOW CONTINUOUS QUERIES`,
+			rows: models.Rows{{
+				Name:    "db0",
+				Columns: []string{"name", "query"},
+			}},
+		},
+	} {
+		results := ReadAllResults(e.ExecuteQuery(ctx, tt.q, "", 0, orgID))
+		if len(results) != 1 {
+			t.Fatalf("%s: expected 1 result, got %d", tt.q, len(results))
+		}
+		if tt.err != "" {
+			if results[0].Err == nil || results[0].Err.Error() != tt.err {
+				t.Fatalf("%s: unexpected error: exp %q, got %v", tt.q, tt.err, results[0].Err)
+			}
+			continue
+		} else if results[0].Err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.q, results[0].Err)
+		}
+		if !reflect.DeepEqual(results[0].Series, tt.rows) {
+			t.Fatalf("%s: unexpected rows: %s", tt.q, spew.Sdump(results[0].Series))
+		}
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowQueries(t *testing.T) {
+	orgID := influxdb.ID(0xff00)
+	e := NewQueryExecutor(t)
+
+	// The query listing the running queries is running itself.
+	results := ReadAllResults(e.ExecuteQuery(context.Background(), `SHOW QUERIES`, "db0", 0, orgID))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	} else if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+	rows := results[0].Series
+	if len(rows) != 1 || len(rows[0].Values) != 1 {
+		t.Fatalf("unexpected rows: %s", spew.Sdump(rows))
+	}
+	if got, exp := rows[0].Columns, []string{"qid", "query", "database", "duration", "status"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected columns: exp %v, got %v", exp, got)
+	}
+	values := rows[0].Values[0]
+	if values[1] != "SHOW QUERIES" || values[2] != "db0" || values[4] != "running" {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// Queries of other organizations are not visible.
+	results = ReadAllResults(e.ExecuteQuery(context.Background(), `SHOW QUERIES`, "db0", 0, orgID+1))
+	if len(results) != 1 || len(results[0].Series) != 1 || len(results[0].Series[0].Values) != 1 {
+		t.Fatalf("unexpected results: %s", spew.Sdump(results))
+	}
+
+	results = ReadAllResults(e.ExecuteQuery(context.Background(), `KILL QUERY 42`, "db0", 0, orgID))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	} else if results[0].Err == nil || results[0].Err.Error() != "no such query id: 42" {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffee)
+	db := "db0"
+	isDefault := true
+	filt := influxdb.DBRPMappingFilterV2{OrgID: &orgID, Database: &db, Default: &isDefault}
+	res := []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID}}
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), filt).
+		Return(res, 1, nil).
+		AnyTimes()
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		if name != bucketID.String() {
+			t.Fatalf("unexpected database: %s", name)
+		}
+		return &meta.DatabaseInfo{
+			Name:                   name,
+			DefaultRetentionPolicy: meta.DefaultRetentionPolicyName,
+			RetentionPolicies:      []meta.RetentionPolicyInfo{{Name: meta.DefaultRetentionPolicyName}},
+		}
+	}
+	e.MetaClient.ShardGroupsByTimeRangeFn = func(database, policy string, min, max time.Time) ([]meta.ShardGroupInfo, error) {
+		groups := []meta.ShardGroupInfo{
+			{ID: 1, StartTime: time.Unix(0, 0), EndTime: time.Unix(100, 0), Shards: []meta.ShardInfo{{ID: 100}}},
+			{ID: 2, StartTime: time.Unix(100, 0), EndTime: time.Unix(200, 0), Shards: []meta.ShardInfo{{ID: 101}}},
+		}
+		var a []meta.ShardGroupInfo
+		for _, g := range groups {
+			if g.Overlaps(min, max) {
+				a = append(a, g)
+			}
+		}
+		return a, nil
+	}
+
+	newSketches := func(shardIDs []uint64) (estimator.Sketch, estimator.Sketch, error) {
+		ss, ts := hll.NewDefaultPlus(), hll.NewDefaultPlus()
+		for _, id := range shardIDs {
+			for i := 0; i < 10; i++ {
+				ss.Add([]byte(fmt.Sprintf("cpu,shard=%d,i=%d", id, i)))
+			}
+		}
+		return ss, ts, nil
+	}
+	e.TSDBStore.SeriesSketchesByShardsFn = newSketches
+	e.TSDBStore.MeasurementsSketchesByShardsFn = newSketches
+	e.TSDBStore.SeriesCardinalityByShardsFn = func(_ query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.SeriesCardinality, error) {
+		if exp := []uint64{101}; !reflect.DeepEqual(shardIDs, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+		}
+		if exp := `(_name = 'cpu') AND (host = 'a')`; cond.String() != exp {
+			t.Fatalf("unexpected condition: exp %s, got %s", exp, cond)
+		}
+		return []tsdb.SeriesCardinality{{Measurement: "cpu", N: 3}}, nil
+	}
+	e.TSDBStore.FieldKeysByShardsFn = func(_ query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error) {
+		if exp := []uint64{100, 101}; !reflect.DeepEqual(shardIDs, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+		}
+		if exp := `host = 'a'`; cond.String() != exp {
+			t.Fatalf("unexpected condition: exp %s, got %s", exp, cond)
+		}
+		return []tsdb.FieldKeys{
+			{Measurement: "cpu", Keys: []string{"idle", "value"}},
+			{Measurement: "mem", Keys: []string{"free"}},
+		}, nil
+	}
+	e.TSDBStore.MeasurementNamesByShardsFn = func(_ query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+		if exp := []uint64{100, 101}; !reflect.DeepEqual(shardIDs, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+		}
+		return [][]byte{[]byte("cpu"), []byte("mem")}, nil
+	}
+
+	tests := []struct {
+		name string
+		q    string
+		exp  []*models.Row
+	}{
+		{
+			name: "estimated series",
+			q:    `SHOW SERIES CARDINALITY ON db0`,
+			exp: []*models.Row{{
+				Columns: []string{"cardinality estimation"},
+				Values:  [][]interface{}{{int64(20)}},
+			}},
+		},
+		{
+			name: "estimated series with time range",
+			q:    `SHOW SERIES CARDINALITY ON db0 WHERE time >= 150s`,
+			exp: []*models.Row{{
+				Columns: []string{"cardinality estimation"},
+				Values:  [][]interface{}{{int64(10)}},
+			}},
+		},
+		{
+			name: "exact series with time range",
+			q:    `SHOW SERIES EXACT CARDINALITY ON db0 FROM cpu WHERE host = 'a' AND time >= 150s`,
+			exp: []*models.Row{{
+				Name:    "cpu",
+				Columns: []string{"count"},
+				Values:  [][]interface{}{{int64(3)}},
+			}},
+		},
+		{
+			name: "field keys with time range",
+			q:    `SHOW FIELD KEY CARDINALITY ON db0 WHERE host = 'a' AND time >= 0s`,
+			exp: []*models.Row{
+				{
+					Name:    "cpu",
+					Columns: []string{"count"},
+					Values:  [][]interface{}{{int64(2)}},
+				},
+				{
+					Name:    "mem",
+					Columns: []string{"count"},
+					Values:  [][]interface{}{{int64(1)}},
+				},
+			},
+		},
+		{
+			name: "field keys",
+			q:    `SHOW FIELD KEY CARDINALITY ON db0 WHERE host = 'a'`,
+			exp: []*models.Row{
+				{
+					Name:    "cpu",
+					Columns: []string{"count"},
+					Values:  [][]interface{}{{int64(2)}},
+				},
+				{
+					Name:    "mem",
+					Columns: []string{"count"},
+					Values:  [][]interface{}{{int64(1)}},
+				},
+			},
+		},
+		{
+			name: "field keys with time range and offset",
+			q:    `SHOW FIELD KEY CARDINALITY ON db0 WHERE host = 'a' AND time >= 0s OFFSET 1`,
+			exp: []*models.Row{{
+				Name:    "mem",
+				Columns: []string{"count"},
+				Values:  [][]interface{}{{int64(1)}},
+			}},
+		},
+		{
+			name: "estimated measurements",
+			q:    `SHOW MEASUREMENT CARDINALITY ON db0`,
+			exp: []*models.Row{{
+				Columns: []string{"cardinality estimation"},
+				Values:  [][]interface{}{{int64(20)}},
+			}},
+		},
+		{
+			name: "exact measurements with time range",
+			q:    `SHOW MEASUREMENT EXACT CARDINALITY ON db0 WHERE time >= 0s`,
+			exp: []*models.Row{{
+				Columns: []string{"count"},
+				Values:  [][]interface{}{{int64(2)}},
+			}},
+		},
+		{
+			name: "exact measurements with time range and limit",
+			q:    `SHOW MEASUREMENT EXACT CARDINALITY ON db0 WHERE time >= 0s LIMIT 1`,
+			exp: []*models.Row{{
+				Columns: []string{"count"},
+				Values:  [][]interface{}{{int64(2)}},
+			}},
+		},
+		{
+			name: "exact measurements with time range and offset",
+			q:    `SHOW MEASUREMENT EXACT CARDINALITY ON db0 WHERE time >= 0s OFFSET 1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ReadAllResults(e.ExecuteQuery(context.Background(), tt.q, db, 0, orgID))
+			exp := []*query.Result{{StatementID: 0, Series: tt.exp}}
+			if !reflect.DeepEqual(results, exp) {
+				t.Fatalf("unexpected results: exp %s, got %s", spew.Sdump(exp), spew.Sdump(results))
+			}
+		})
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowSeriesAndFieldKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbrp := mocks.NewMockDBRPMappingServiceV2(ctrl)
+	orgID := influxdb.ID(0xff00)
+	bucketID := influxdb.ID(0xffee)
+	db := "db0"
+	res := []*influxdb.DBRPMappingV2{{Database: db, RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID}}
+	dbrp.EXPECT().
+		FindMany(gomock.Any(), gomock.Any()).
+		Return(res, 1, nil).
+		AnyTimes()
+
+	e := NewQueryExecutor(t, WithDBRP(dbrp))
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		if name != bucketID.String() {
+			t.Fatalf("unexpected database: %s", name)
+		}
+		return &meta.DatabaseInfo{
+			Name:                   name,
+			DefaultRetentionPolicy: meta.DefaultRetentionPolicyName,
+			RetentionPolicies:      []meta.RetentionPolicyInfo{{Name: meta.DefaultRetentionPolicyName}},
+		}
+	}
+	e.MetaClient.ShardGroupsByTimeRangeFn = func(database, policy string, min, max time.Time) ([]meta.ShardGroupInfo, error) {
+		groups := []meta.ShardGroupInfo{
+			{ID: 1, StartTime: time.Unix(0, 0), EndTime: time.Unix(100, 0), Shards: []meta.ShardInfo{{ID: 100}}},
+			{ID: 2, StartTime: time.Unix(100, 0), EndTime: time.Unix(200, 0), Shards: []meta.ShardInfo{{ID: 101}}},
+		}
+		var a []meta.ShardGroupInfo
+		for _, g := range groups {
+			if g.Overlaps(min, max) {
+				a = append(a, g)
+			}
+		}
+		return a, nil
+	}
+
+	e.TSDBStore.SeriesKeysByShardsFn = func(_ query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]string, error) {
+		keys := []string{"cpu,host=a", "cpu,host=b", "mem,host=a"}
+		if cond == nil {
+			if exp := []uint64{100, 101}; !reflect.DeepEqual(shardIDs, exp) {
+				t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+			}
+			return keys, nil
+		}
+		if exp := []uint64{101}; !reflect.DeepEqual(shardIDs, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+		}
+		if exp := `(_name = 'cpu') AND (host = 'a')`; cond.String() != exp {
+			t.Fatalf("unexpected condition: exp %s, got %s", exp, cond)
+		}
+		return keys[:1], nil
+	}
+	e.TSDBStore.FieldKeysByShardsFn = func(_ query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.FieldKeys, error) {
+		if exp := []uint64{100, 101}; !reflect.DeepEqual(shardIDs, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, shardIDs)
+		}
+		fieldKeys := []tsdb.FieldKeys{
+			{Measurement: "cpu", Keys: []string{"idle", "value"}, Types: []influxql.DataType{influxql.Integer, influxql.Float}},
+			{Measurement: "mem", Keys: []string{"free"}, Types: []influxql.DataType{influxql.Integer}},
+		}
+		if cond == nil {
+			return fieldKeys, nil
+		}
+		if exp := `_name =~ /c.*/`; cond.String() != exp {
+			t.Fatalf("unexpected condition: exp %s, got %s", exp, cond)
+		}
+		return fieldKeys[:1], nil
+	}
+
+	tests := []struct {
+		name string
+		q    string
+		exp  []*query.Result
+	}{
+		{
+			name: "series",
+			q:    `SHOW SERIES ON db0`,
+			exp: []*query.Result{{Series: []*models.Row{{
+				Columns: []string{"key"},
+				Values:  [][]interface{}{{"cpu,host=a"}, {"cpu,host=b"}, {"mem,host=a"}},
+			}}}},
+		},
+		{
+			name: "series with limit and offset",
+			q:    `SHOW SERIES ON db0 LIMIT 1 OFFSET 1`,
+			exp: []*query.Result{{Series: []*models.Row{{
+				Columns: []string{"key"},
+				Values:  [][]interface{}{{"cpu,host=b"}},
+			}}}},
+		},
+		{
+			name: "series with time range",
+			q:    `SHOW SERIES ON db0 FROM cpu WHERE host = 'a' AND time >= 150s`,
+			exp: []*query.Result{{Series: []*models.Row{{
+				Columns: []string{"key"},
+				Values:  [][]interface{}{{"cpu,host=a"}},
+			}}}},
+		},
+		{
+			name: "series with offset past the end",
+			q:    `SHOW SERIES ON db0 OFFSET 3`,
+			exp:  []*query.Result{{}},
+		},
+		{
+			name: "field keys",
+			q:    `SHOW FIELD KEYS ON db0`,
+			exp: []*query.Result{
+				{Series: []*models.Row{{
+					Name:    "cpu",
+					Columns: []string{"fieldKey", "fieldType"},
+					Values:  [][]interface{}{{"idle", "integer"}, {"value", "float"}},
+				}}},
+				{Series: []*models.Row{{
+					Name:    "mem",
+					Columns: []string{"fieldKey", "fieldType"},
+					Values:  [][]interface{}{{"free", "integer"}},
+				}}},
+			},
+		},
+		{
+			name: "field keys from regex with offset",
+			q:    `SHOW FIELD KEYS ON db0 FROM /c.*/ OFFSET 1`,
+			exp: []*query.Result{{Series: []*models.Row{{
+				Name:    "cpu",
+				Columns: []string{"fieldKey", "fieldType"},
+				Values:  [][]interface{}{{"value", "float"}},
+			}}}},
+		},
+		{
+			name: "field keys with limit",
+			q:    `SHOW FIELD KEYS ON db0 LIMIT 1`,
+			exp: []*query.Result{
+				{Series: []*models.Row{{
+					Name:    "cpu",
+					Columns: []string{"fieldKey", "fieldType"},
+					Values:  [][]interface{}{{"idle", "integer"}},
+				}}},
+				{Series: []*models.Row{{
+					Name:    "mem",
+					Columns: []string{"fieldKey", "fieldType"},
+					Values:  [][]interface{}{{"free", "integer"}},
+				}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ReadAllResults(e.ExecuteQuery(context.Background(), tt.q, db, 0, orgID))
+			if !reflect.DeepEqual(results, tt.exp) {
+				t.Fatalf("unexpected results: exp %s, got %s", spew.Sdump(tt.exp), spew.Sdump(results))
+			}
+		})
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowStatsAndDiagnostics(t *testing.T) {
+	orgID := influxdb.ID(0xff00)
+
+	reporter := coordinator.NewPointsWriter()
+	m := monitor.New(reporter, monitor.Config{})
+	m.RegisterDiagnosticsClient("test", diagnostics.ClientFunc(func() (*diagnostics.Diagnostics, error) {
+		return diagnostics.RowFromMap(map[string]interface{}{"key": "value"}), nil
+	}))
+
+	e := NewQueryExecutor(t)
+	e.StatementExecutor.Monitor = m
+
+	tests := []struct {
+		name  string
+		perms []influxdb.Permission
+		err   string
+	}{
+		{
+			name:  "operator",
+			perms: influxdb.OperPermissions(),
+		},
+		{
+			name:  "organization owner",
+			perms: influxdb.OwnerPermissions(orgID),
+			err:   "read:authorizations is unauthorized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+				ID:          orgID,
+				OrgID:       orgID,
+				Status:      influxdb.Active,
+				Permissions: tt.perms,
+			})
+
+			results := ReadAllResults(e.ExecuteQuery(ctx, `SHOW STATS FOR 'write'; SHOW DIAGNOSTICS FOR 'test'`, "", 0, orgID))
+			if len(results) != 2 {
+				t.Fatalf("expected 2 results, got %d", len(results))
+			}
+			if tt.err != "" {
+				if results[0].Err == nil || results[0].Err.Error() != tt.err {
+					t.Fatalf("unexpected error: exp %q, got %v", tt.err, results[0].Err)
+				}
+				results = ReadAllResults(e.ExecuteQuery(ctx, `SHOW DIAGNOSTICS`, "", 0, orgID))
+				if len(results) != 1 || results[0].Err == nil || results[0].Err.Error() != tt.err {
+					t.Fatalf("unexpected results: %s", spew.Sdump(results))
+				}
+				return
+			}
+
+			for _, result := range results {
+				if result.Err != nil {
+					t.Fatalf("unexpected error: %v", result.Err)
+				}
+			}
+			if rows := results[0].Series; len(rows) != 1 || rows[0].Name != "write" || len(rows[0].Columns) == 0 {
+				t.Fatalf("unexpected stats: %s", spew.Sdump(rows))
+			}
+			exp := models.Rows{{
+				Name:    "test",
+				Columns: []string{"key"},
+				Values:  [][]interface{}{{"value"}},
+			}}
+			if got := results[1].Series; !reflect.DeepEqual(got, exp) {
+				t.Fatalf("unexpected diagnostics: exp %s, got %s", spew.Sdump(exp), spew.Sdump(got))
+			}
+		})
+	}
+}
+
 // QueryExecutor is a test wrapper for coordinator.QueryExecutor.
 type QueryExecutor struct {
 	*query.Executor
@@ -392,6 +1439,9 @@ type QueryExecutor struct {
 	MetaClient        MetaClient
 	TSDBStore         *internal.TSDBStoreMock
 	DBRP              *mocks.MockDBRPMappingServiceV2
+	BucketService     *mock.BucketService
+	TaskService       *mock.TaskService
+	LabelService      *mock.LabelService
 	StatementExecutor *coordinator.StatementExecutor
 	LogOutput         bytes.Buffer
 }
@@ -400,8 +1450,11 @@ type QueryExecutor struct {
 // This query executor always has a node id of 0.
 func NewQueryExecutor(t *testing.T, opts ...optFn) *QueryExecutor {
 	e := &QueryExecutor{
-		Executor:  query.NewExecutor(zaptest.NewLogger(t), control.NewControllerMetrics([]string{})),
-		TSDBStore: &internal.TSDBStoreMock{},
+		Executor:      query.NewExecutor(zaptest.NewLogger(t), control.NewControllerMetrics([]string{})),
+		TSDBStore:     &internal.TSDBStoreMock{},
+		BucketService: mock.NewBucketService(),
+		TaskService:   mock.NewTaskService(),
+		LabelService:  mock.NewLabelService(),
 	}
 
 	for _, opt := range opts {
@@ -421,15 +1474,19 @@ func NewQueryExecutor(t *testing.T, opts ...optFn) *QueryExecutor {
 	}
 
 	e.StatementExecutor = &coordinator.StatementExecutor{
-		MetaClient: &e.MetaClient,
-		TSDBStore:  e.TSDBStore,
-		DBRP:       e.DBRP,
+		MetaClient:    &e.MetaClient,
+		TSDBStore:     e.TSDBStore,
+		DBRP:          e.DBRP,
+		BucketService: e.BucketService,
+		TaskService:   e.TaskService,
+		LabelService:  e.LabelService,
 		ShardMapper: &coordinator.LocalShardMapper{
 			MetaClient: &e.MetaClient,
 			TSDBStore:  e.TSDBStore,
 			DBRP:       e.DBRP,
 		},
 	}
+	e.StatementExecutor.QueryTracker = e.Executor.Tracker
 	e.Executor.StatementExecutor = e.StatementExecutor
 
 	return e